@@ -0,0 +1,86 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package deployment
+
+import (
+	"fmt"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phr"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// ContractName is the name every organization deploys the phr contract under
+const ContractName = "org.phrnet.phr"
+
+// Title is the title of the phr chaincode
+const Title = "PHRChaincode"
+
+// Version is the version of the phr chaincode
+const Version = "0.0.1"
+
+// Config holds the settings every organization deploys the phr
+// chaincode with. Organizations must deploy the same config so
+// their peers expose and endorse the same transactions
+type Config struct {
+	Version  string
+	Settings *phr.Settings
+}
+
+// Validate checks the required fields of the config are set
+func (c *Config) Validate() error {
+	if c.Settings == nil {
+		return fmt.Errorf("Invalid deployment config. settings is required")
+	}
+
+	required := []struct {
+		field string
+		set   bool
+	}{
+		{"version", c.Version != ""},
+		{"settings.owners", len(c.Settings.Owners) > 0},
+		{"settings.roles", len(c.Settings.Roles) > 0},
+		{"settings.tokenAdminMSP", c.Settings.TokenAdminMSP != ""},
+	}
+
+	for _, r := range required {
+		if !r.set {
+			return fmt.Errorf("Invalid deployment config. %s is required", r.field)
+		}
+	}
+
+	return nil
+}
+
+// Default returns the config the chaincode starts with, carrying
+// the MSP mappings of the network. Both organizations deploy it
+func Default() *Config {
+	return &Config{Version: Version, Settings: phr.DefaultSettings()}
+}
+
+// NewChaincode creates the phr chaincode from a config
+func NewChaincode(config *Config) (*contractapi.ContractChaincode, error) {
+	err := config.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	contract := new(phr.Contract)
+	contract.TransactionContextHandler = new(phr.TransactionContext)
+	contract.Name = ContractName
+	contract.Info.Version = config.Version
+	contract.Settings = config.Settings
+
+	chaincode, err := contractapi.NewChaincode(contract)
+
+	if err != nil {
+		return nil, err
+	}
+
+	chaincode.Info.Title = Title
+	chaincode.Info.Version = config.Version
+
+	return chaincode, nil
+}
@@ -0,0 +1,100 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package deployment
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phr"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-contract-api-go/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+// #########
+// HELPERS
+// #########
+
+func transactionSet(t *testing.T, config *Config) []string {
+	t.Helper()
+
+	chaincode, err := NewChaincode(config)
+	assert.Nil(t, err, "should create chaincode")
+
+	stub := shimtest.NewMockStub("phr", chaincode)
+	response := stub.MockInvoke("tx1", [][]byte{[]byte("org.hyperledger.fabric:GetMetadata")})
+	assert.Equal(t, int32(200), response.Status, "should return metadata")
+
+	ccMetadata := metadata.ContractChaincodeMetadata{}
+	err = json.Unmarshal(response.Payload, &ccMetadata)
+	assert.Nil(t, err, "should parse metadata")
+
+	set := []string{}
+	for name, contract := range ccMetadata.Contracts {
+		for _, tx := range contract.Transactions {
+			set = append(set, name+":"+tx.Name)
+		}
+	}
+	sort.Strings(set)
+
+	return set
+}
+
+// #########
+// TESTS
+// #########
+
+func TestValidate(t *testing.T) {
+	config := Default()
+	assert.Nil(t, config.Validate(), "should validate default config")
+
+	config.Settings.TokenAdminMSP = ""
+	assert.EqualError(t, config.Validate(), "Invalid deployment config. settings.tokenAdminMSP is required", "should require token admin msp id")
+
+	config.Settings.Roles = nil
+	assert.EqualError(t, config.Validate(), "Invalid deployment config. settings.roles is required", "should require roles")
+
+	config.Settings.Owners = map[string]string{}
+	assert.EqualError(t, config.Validate(), "Invalid deployment config. settings.owners is required", "should require owners")
+
+	config.Version = ""
+	assert.EqualError(t, config.Validate(), "Invalid deployment config. version is required", "should require version")
+
+	config.Settings = nil
+	assert.EqualError(t, config.Validate(), "Invalid deployment config. settings is required", "should require settings")
+}
+
+func TestDefault(t *testing.T) {
+	config := Default()
+	assert.Equal(t, Version, config.Version, "should use chaincode version")
+	assert.Equal(t, phr.DefaultSettings(), config.Settings, "should carry the MSP mappings of the network")
+}
+
+func TestNewChaincode(t *testing.T) {
+	chaincode, err := NewChaincode(Default())
+	assert.Nil(t, err, "should create chaincode")
+	assert.Equal(t, Title, chaincode.Info.Title, "should set title")
+	assert.Equal(t, Version, chaincode.Info.Version, "should set version")
+	assert.Equal(t, ContractName, chaincode.DefaultContract, "should use shared contract name")
+
+	chaincode, err = NewChaincode(&Config{Version: Version})
+	assert.EqualError(t, err, "Invalid deployment config. settings is required", "should error when config invalid")
+	assert.Nil(t, chaincode, "should not create chaincode when config invalid")
+}
+
+// Both organizations deploy Default by design, so there is no per-org
+// config to compare. Settings differing still must not change which
+// transactions the chaincode exposes
+func TestTransactionSetIndependentOfSettings(t *testing.T) {
+	expected := transactionSet(t, Default())
+	assert.Contains(t, expected, ContractName+":Issue", "should expose phr transactions")
+
+	config := Default()
+	config.Settings.TokenAdminMSP = "someadminMSP"
+	config.Settings.Owners["someMSP"] = "someowner"
+	assert.Equal(t, expected, transactionSet(t, config), "should expose identical transaction set whatever the settings")
+}
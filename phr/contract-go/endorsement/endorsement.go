@@ -0,0 +1,73 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package endorsement
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric-chaincode-go/pkg/statebased"
+)
+
+// NewPolicy returns a key-level endorsement policy requiring
+// a peer of each of the passed MSPs to endorse changes to the key
+func NewPolicy(mspIDs ...string) ([]byte, error) {
+	if len(mspIDs) == 0 {
+		return nil, fmt.Errorf("Endorsement policy requires at least one MSP")
+	}
+
+	for _, mspID := range mspIDs {
+		if mspID == "" {
+			return nil, fmt.Errorf("Endorsement policy cannot require an empty MSP ID")
+		}
+	}
+
+	ep, err := statebased.NewStateEP(nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = ep.AddOrgs(statebased.RoleTypePeer, mspIDs...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ep.Policy()
+}
+
+// Orgs returns the sorted MSP IDs a key-level endorsement policy
+// requires. A nil policy, as read for a key without one, requires none
+func Orgs(policy []byte) ([]string, error) {
+	ep, err := statebased.NewStateEP(policy)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing endorsement policy. %s", err.Error())
+	}
+
+	orgs := ep.ListOrgs()
+	sort.Strings(orgs)
+
+	return orgs, nil
+}
+
+// RequiresOrg returns whether a key-level endorsement policy
+// requires a peer of the passed MSP to endorse
+func RequiresOrg(policy []byte, mspID string) (bool, error) {
+	orgs, err := Orgs(policy)
+
+	if err != nil {
+		return false, err
+	}
+
+	for _, org := range orgs {
+		if org == mspID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,74 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package endorsement
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPolicy(t *testing.T) {
+	var policy []byte
+	var err error
+
+	policy, err = NewPolicy()
+	assert.EqualError(t, err, "Endorsement policy requires at least one MSP", "should error when no msp passed")
+	assert.Nil(t, policy, "should not return policy when no msp passed")
+
+	policy, err = NewPolicy("Org2MSP", "")
+	assert.EqualError(t, err, "Endorsement policy cannot require an empty MSP ID", "should error when msp is empty")
+	assert.Nil(t, policy, "should not return policy when msp is empty")
+
+	policy, err = NewPolicy("Org2MSP")
+	assert.Nil(t, err, "should not error when msp passed")
+	assert.NotEmpty(t, policy, "should return policy when msp passed")
+
+	stub := shimtest.NewMockStub("phr", nil)
+	stub.SetStateValidationParameter("somekey", policy)
+	stored, _ := stub.GetStateValidationParameter("somekey")
+	orgs, err := Orgs(stored)
+	assert.Nil(t, err, "should not error parsing policy read from stub")
+	assert.Equal(t, []string{"Org2MSP"}, orgs, "should require passed msp")
+
+	policy, _ = NewPolicy("Org2MSP", "Org1MSP")
+	orgs, _ = Orgs(policy)
+	assert.Equal(t, []string{"Org1MSP", "Org2MSP"}, orgs, "should require every passed msp")
+}
+
+func TestOrgs(t *testing.T) {
+	var orgs []string
+	var err error
+
+	stub := shimtest.NewMockStub("phr", nil)
+	stored, _ := stub.GetStateValidationParameter("somekey")
+	orgs, err = Orgs(stored)
+	assert.Nil(t, err, "should not error when key has no policy")
+	assert.Empty(t, orgs, "should require no msp when key has no policy")
+
+	orgs, err = Orgs([]byte("bad policy"))
+	assert.Contains(t, err.Error(), "Error parsing endorsement policy.", "should error when policy does not parse")
+	assert.Nil(t, orgs, "should not return orgs when policy does not parse")
+}
+
+func TestRequiresOrg(t *testing.T) {
+	var required bool
+	var err error
+
+	policy, _ := NewPolicy("Org2MSP")
+
+	required, err = RequiresOrg(policy, "Org2MSP")
+	assert.Nil(t, err, "should not error when policy parses")
+	assert.True(t, required, "should require msp in policy")
+
+	required, err = RequiresOrg(policy, "Org1MSP")
+	assert.Nil(t, err, "should not error when policy parses")
+	assert.False(t, required, "should not require msp missing from policy")
+
+	required, err = RequiresOrg([]byte("bad policy"), "Org2MSP")
+	assert.Contains(t, err.Error(), "Error parsing endorsement policy.", "should error when policy does not parse")
+	assert.False(t, required, "should not require msp when policy does not parse")
+}
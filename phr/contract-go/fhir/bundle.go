@@ -0,0 +1,244 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package fhir
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// PatientResourceType resource type of the patient a bundle is about.
+// A bundle holds at most one
+const PatientResourceType = "Patient"
+
+// bundleTypes the values of Bundle.type in FHIR R4
+var bundleTypes = map[string]bool{
+	"document":             true,
+	"message":              true,
+	"transaction":          true,
+	"transaction-response": true,
+	"batch":                true,
+	"batch-response":       true,
+	"history":              true,
+	"searchset":            true,
+	"collection":           true,
+}
+
+var resourceTypePattern = regexp.MustCompile("^[A-Z][A-Za-z]+$")
+
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9\-.]{1,64}$`)
+
+// dateFields fields of resources whose date or date time
+// values make up the date range of a bundle
+var dateFields = map[string]bool{
+	"abatementDateTime":  true,
+	"authoredOn":         true,
+	"date":               true,
+	"effectiveDateTime":  true,
+	"effectiveInstant":   true,
+	"end":                true,
+	"issued":             true,
+	"occurrenceDateTime": true,
+	"onsetDateTime":      true,
+	"performedDateTime":  true,
+	"recordedDate":       true,
+	"start":              true,
+}
+
+// dateLayouts the precisions of a FHIR date time
+var dateLayouts = []string{time.RFC3339Nano, "2006-01-02", "2006-01", "2006"}
+
+// Bundle defines the parts of a FHIR R4 bundle
+// needed to validate and summarize it
+type Bundle struct {
+	ResourceType string  `json:"resourceType"`
+	ID           string  `json:"id"`
+	Type         string  `json:"type"`
+	Entry        []Entry `json:"entry"`
+}
+
+// Entry defines an entry of a bundle
+type Entry struct {
+	FullURL  string          `json:"fullUrl"`
+	Resource json.RawMessage `json:"resource"`
+}
+
+// resource defines the fields every resource has
+type resource struct {
+	ResourceType string `json:"resourceType"`
+	ID           string `json:"id"`
+}
+
+// ParseBundle formats a bundle from JSON bytes
+func ParseBundle(bytes []byte) (*Bundle, error) {
+	bundle := new(Bundle)
+
+	err := json.Unmarshal(bytes, bundle)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing FHIR bundle. %s", err.Error())
+	}
+
+	return bundle, nil
+}
+
+// Validate checks the structure of the bundle. It must be of a known
+// type and hold at least one resource. Every resource needs a resource
+// type and an id unique within that type. At most one is a Patient
+func (b *Bundle) Validate() error {
+	if b.ResourceType != "Bundle" {
+		return fmt.Errorf("Invalid FHIR bundle. Resource type is %q, expected \"Bundle\"", b.ResourceType)
+	}
+
+	if !bundleTypes[b.Type] {
+		return fmt.Errorf("Invalid FHIR bundle. Unknown bundle type %q", b.Type)
+	}
+
+	if len(b.Entry) == 0 {
+		return fmt.Errorf("Invalid FHIR bundle. Bundle has no entries")
+	}
+
+	seen := map[string]bool{}
+	patients := 0
+
+	for i, entry := range b.Entry {
+		r := new(resource)
+
+		if len(entry.Resource) == 0 || json.Unmarshal(entry.Resource, r) != nil {
+			return fmt.Errorf("Invalid FHIR bundle. Entry %d has no resource", i)
+		}
+
+		if !resourceTypePattern.MatchString(r.ResourceType) {
+			return fmt.Errorf("Invalid FHIR bundle. Entry %d has invalid resource type %q", i, r.ResourceType)
+		}
+
+		if !idPattern.MatchString(r.ID) {
+			return fmt.Errorf("Invalid FHIR bundle. Entry %d has invalid id %q", i, r.ID)
+		}
+
+		reference := r.ResourceType + "/" + r.ID
+
+		if seen[reference] {
+			return fmt.Errorf("Invalid FHIR bundle. Resource %s appears more than once", reference)
+		}
+
+		seen[reference] = true
+
+		if r.ResourceType == PatientResourceType {
+			patients++
+		}
+	}
+
+	if patients > 1 {
+		return fmt.Errorf("Invalid FHIR bundle. Bundle holds %d Patient resources, expected at most one", patients)
+	}
+
+	return nil
+}
+
+// Summarize validates the bundle and returns its summary
+func (b *Bundle) Summarize() (*Summary, error) {
+	err := b.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &Summary{BundleType: b.Type, ResourceCounts: map[string]int{}}
+	systems := map[string]bool{}
+	var from, to time.Time
+
+	for _, entry := range b.Entry {
+		var value interface{}
+
+		err = json.Unmarshal(entry.Resource, &value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		fields := value.(map[string]interface{})
+		summary.ResourceCounts[fields["resourceType"].(string)]++
+
+		walk(value, "", func(date string, t time.Time) {
+			// ties go to the smaller string so the summary does
+			// not depend on the order fields are visited in
+			if summary.DateFrom == "" || t.Before(from) || t.Equal(from) && date < summary.DateFrom {
+				summary.DateFrom, from = date, t
+			}
+
+			if summary.DateTo == "" || t.After(to) || t.Equal(to) && date < summary.DateTo {
+				summary.DateTo, to = date, t
+			}
+		}, systems)
+	}
+
+	for resourceType := range summary.ResourceCounts {
+		summary.ResourceTypes = append(summary.ResourceTypes, resourceType)
+	}
+
+	sort.Strings(summary.ResourceTypes)
+
+	for system := range systems {
+		summary.CodingSystems = append(summary.CodingSystems, system)
+	}
+
+	sort.Strings(summary.CodingSystems)
+
+	return summary, nil
+}
+
+// Summarize parses, validates and summarizes a bundle from JSON bytes
+func Summarize(bytes []byte) (*Summary, error) {
+	bundle, err := ParseBundle(bytes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bundle.Summarize()
+}
+
+// walk visits every value of a resource, calling onDate for each date
+// field and adding the system of each coding to systems
+func walk(value interface{}, key string, onDate func(string, time.Time), systems map[string]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if system, ok := v["system"].(string); ok && v["code"] != nil {
+			systems[system] = true
+		}
+
+		for k, child := range v {
+			walk(child, k, onDate, systems)
+		}
+	case []interface{}:
+		for _, child := range v {
+			walk(child, key, onDate, systems)
+		}
+	case string:
+		if dateFields[key] {
+			if t, err := parseDate(v); err == nil {
+				onDate(v, t)
+			}
+		}
+	}
+}
+
+// parseDate parses a FHIR date or date time of any precision
+// into the earliest time it can stand for
+func parseDate(value string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		t, err := time.Parse(layout, value)
+
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("Expected a FHIR date or date time")
+}
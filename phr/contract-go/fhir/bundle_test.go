@@ -0,0 +1,122 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package fhir
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// #########
+// HELPERS
+// #########
+
+const someBundle = `{
+	"resourceType": "Bundle",
+	"id": "somebundle",
+	"type": "collection",
+	"entry": [
+		{"fullUrl": "urn:uuid:1", "resource": {"resourceType": "Patient", "id": "somepatient", "birthDate": "1970-01-01", "identifier": [{"system": "urn:oid:1.2.36.146.595.217.0.1", "value": "somemrn"}]}},
+		{"fullUrl": "urn:uuid:2", "resource": {"resourceType": "Observation", "id": "someobservation", "code": {"coding": [{"system": "http://loinc.org", "code": "29463-7"}]}, "effectiveDateTime": "2021-03-01T09:30:00+09:00"}},
+		{"fullUrl": "urn:uuid:3", "resource": {"resourceType": "Observation", "id": "someotherobservation", "code": {"coding": [{"system": "http://loinc.org", "code": "8302-2"}]}, "effectiveDateTime": "2021-06"}},
+		{"fullUrl": "urn:uuid:4", "resource": {"resourceType": "Condition", "id": "somecondition", "code": {"coding": [{"system": "http://snomed.info/sct", "code": "44054006"}]}, "onsetDateTime": "2019", "recordedDate": "2020-11-20"}},
+		{"fullUrl": "urn:uuid:5", "resource": {"resourceType": "Encounter", "id": "someencounter", "class": {"system": "http://terminology.hl7.org/CodeSystem/v3-ActCode", "code": "AMB"}, "period": {"start": "2021-06-15T10:00:00Z", "end": "2021-06-15T11:00:00Z"}}}
+	]
+}`
+
+// #########
+// TESTS
+// #########
+
+func TestParseBundle(t *testing.T) {
+	var bundle *Bundle
+	var err error
+
+	bundle, err = ParseBundle([]byte(someBundle))
+	assert.Nil(t, err, "should not error for good JSON")
+	assert.Equal(t, "collection", bundle.Type, "should read the bundle type")
+	assert.Len(t, bundle.Entry, 5, "should read every entry")
+
+	bundle, err = ParseBundle([]byte("bad json"))
+	assert.EqualError(t, err, "Error parsing FHIR bundle. invalid character 'b' looking for beginning of value", "should error for bad JSON")
+	assert.Nil(t, bundle, "should not return bundle for bad JSON")
+}
+
+func TestBundleValidate(t *testing.T) {
+	bundle, _ := ParseBundle([]byte(someBundle))
+	assert.Nil(t, bundle.Validate(), "should not error for good bundle")
+
+	tests := []struct {
+		bundle string
+		err    string
+	}{
+		{`{"resourceType": "Patient", "type": "collection"}`, `Invalid FHIR bundle. Resource type is "Patient", expected "Bundle"`},
+		{`{"resourceType": "Bundle", "type": "somebundletype"}`, `Invalid FHIR bundle. Unknown bundle type "somebundletype"`},
+		{`{"resourceType": "Bundle", "type": "collection"}`, "Invalid FHIR bundle. Bundle has no entries"},
+		{`{"resourceType": "Bundle", "type": "collection", "entry": [{"fullUrl": "urn:uuid:1"}]}`, "Invalid FHIR bundle. Entry 0 has no resource"},
+		{`{"resourceType": "Bundle", "type": "collection", "entry": [{"resource": {"resourceType": "observation", "id": "someobservation"}}]}`, `Invalid FHIR bundle. Entry 0 has invalid resource type "observation"`},
+		{`{"resourceType": "Bundle", "type": "collection", "entry": [{"resource": {"resourceType": "Observation"}}]}`, `Invalid FHIR bundle. Entry 0 has invalid id ""`},
+		{`{"resourceType": "Bundle", "type": "collection", "entry": [{"resource": {"resourceType": "Observation", "id": "some observation"}}]}`, `Invalid FHIR bundle. Entry 0 has invalid id "some observation"`},
+		{`{"resourceType": "Bundle", "type": "collection", "entry": [{"resource": {"resourceType": "Observation", "id": "someobservation"}}, {"resource": {"resourceType": "Observation", "id": "someobservation"}}]}`, "Invalid FHIR bundle. Resource Observation/someobservation appears more than once"},
+		{`{"resourceType": "Bundle", "type": "collection", "entry": [{"resource": {"resourceType": "Patient", "id": "somepatient"}}, {"resource": {"resourceType": "Patient", "id": "someotherpatient"}}]}`, "Invalid FHIR bundle. Bundle holds 2 Patient resources, expected at most one"},
+	}
+
+	for _, test := range tests {
+		bundle, _ = ParseBundle([]byte(test.bundle))
+		assert.EqualError(t, bundle.Validate(), test.err, "should error for bad bundle "+test.bundle)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	var summary *Summary
+	var err error
+
+	summary, err = Summarize([]byte(someBundle))
+	assert.Nil(t, err, "should not error for good bundle")
+	assert.Equal(t, &Summary{
+		BundleType:     "collection",
+		ResourceTypes:  []string{"Condition", "Encounter", "Observation", "Patient"},
+		ResourceCounts: map[string]int{"Condition": 1, "Encounter": 1, "Observation": 2, "Patient": 1},
+		DateFrom:       "2019",
+		DateTo:         "2021-06-15T11:00:00Z",
+		CodingSystems:  []string{"http://loinc.org", "http://snomed.info/sct", "http://terminology.hl7.org/CodeSystem/v3-ActCode"},
+	}, summary, "should count resources by type, and collect the date range and coding systems")
+	assert.Nil(t, summary.Validate(), "should return a summary which validates")
+
+	summary, err = Summarize([]byte(`{"resourceType": "Bundle", "type": "collection", "entry": [{"resource": {"resourceType": "Observation", "id": "someobservation", "issued": "2021-03-01", "effectiveDateTime": "2021-03-01T00:00:00Z"}}]}`))
+	assert.Nil(t, err, "should not error for bundle with equal dates")
+	assert.Equal(t, "2021-03-01", summary.DateFrom, "should pick the smaller of equal dates as from")
+	assert.Equal(t, "2021-03-01", summary.DateTo, "should pick the smaller of equal dates as to")
+
+	summary, err = Summarize([]byte(`{"resourceType": "Bundle", "type": "collection", "entry": [{"resource": {"resourceType": "Patient", "id": "somepatient", "birthDate": "1970-01-01"}}]}`))
+	assert.Nil(t, err, "should not error for bundle without dates or codings")
+	assert.Equal(t, &Summary{BundleType: "collection", ResourceTypes: []string{"Patient"}, ResourceCounts: map[string]int{"Patient": 1}}, summary, "should leave out date range and coding systems when there are none")
+
+	summary, err = Summarize([]byte(`{"resourceType": "Bundle", "type": "collection"}`))
+	assert.EqualError(t, err, "Invalid FHIR bundle. Bundle has no entries", "should error for invalid bundle")
+	assert.Nil(t, summary, "should not return summary for invalid bundle")
+
+	summary, err = Summarize([]byte("bad json"))
+	assert.EqualError(t, err, "Error parsing FHIR bundle. invalid character 'b' looking for beginning of value", "should error for bad JSON")
+	assert.Nil(t, summary, "should not return summary for bad JSON")
+}
+
+func TestParseDate(t *testing.T) {
+	var date time.Time
+	var err error
+
+	date, err = parseDate("2021-03-01T09:30:00+09:00")
+	assert.Nil(t, err, "should parse date time")
+	assert.True(t, date.Equal(time.Date(2021, 3, 1, 0, 30, 0, 0, time.UTC)), "should keep time zone of date time")
+
+	date, err = parseDate("2021-03")
+	assert.Nil(t, err, "should parse year and month")
+	assert.Equal(t, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), date, "should parse partial date to its first day")
+
+	_, err = parseDate("01/03/2021")
+	assert.EqualError(t, err, "Expected a FHIR date or date time", "should error for other formats")
+}
@@ -0,0 +1,125 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package fhir
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+)
+
+// Summary defines the searchable summary of a FHIR bundle
+type Summary struct {
+	BundleType     string         `json:"bundleType"`
+	ResourceTypes  []string       `json:"resourceTypes"`
+	ResourceCounts map[string]int `json:"resourceCounts"`
+	DateFrom       string         `json:"dateFrom,omitempty" metadata:"dateFrom,optional"`
+	DateTo         string         `json:"dateTo,omitempty" metadata:"dateTo,optional"`
+	CodingSystems  []string       `json:"codingSystems,omitempty" metadata:"codingSystems,optional"`
+}
+
+// ParseSummary formats a summary from JSON bytes
+func ParseSummary(bytes []byte) (*Summary, error) {
+	summary := new(Summary)
+
+	err := json.Unmarshal(bytes, summary)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing FHIR summary. %s", err.Error())
+	}
+
+	return summary, nil
+}
+
+// Validate checks the summary is one Summarize could return. Resource
+// types are the sorted types counted, every count is positive, the date
+// range is ordered and coding systems are sorted absolute URIs.
+// Errors are VALIDATION errors of the catalogue
+func (s *Summary) Validate() error {
+	if !bundleTypes[s.BundleType] {
+		return phrerror.Errorf(phrerror.Validation, "Invalid FHIR summary. Unknown bundle type %q", s.BundleType)
+	}
+
+	if len(s.ResourceCounts) == 0 {
+		return phrerror.Errorf(phrerror.Validation, "Invalid FHIR summary. Summary counts no resources")
+	}
+
+	types := []string{}
+
+	for resourceType, count := range s.ResourceCounts {
+		if !resourceTypePattern.MatchString(resourceType) {
+			return phrerror.Errorf(phrerror.Validation, "Invalid FHIR summary. Invalid resource type %q", resourceType)
+		}
+
+		if count <= 0 {
+			return phrerror.Errorf(phrerror.Validation, "Invalid FHIR summary. Count %d of %s is not positive", count, resourceType)
+		}
+
+		types = append(types, resourceType)
+	}
+
+	sort.Strings(types)
+
+	if !equal(types, s.ResourceTypes) {
+		return phrerror.Errorf(phrerror.Validation, "Invalid FHIR summary. Resource types %v do not match the types counted %v", s.ResourceTypes, types)
+	}
+
+	if s.ResourceCounts[PatientResourceType] > 1 {
+		return phrerror.Errorf(phrerror.Validation, "Invalid FHIR summary. Summary counts %d Patient resources, expected at most one", s.ResourceCounts[PatientResourceType])
+	}
+
+	if (s.DateFrom == "") != (s.DateTo == "") {
+		return phrerror.Errorf(phrerror.Validation, "Invalid FHIR summary. Date range needs both a from and a to date")
+	}
+
+	if s.DateFrom != "" {
+		from, err := parseDate(s.DateFrom)
+
+		if err != nil {
+			return phrerror.Errorf(phrerror.Validation, "Invalid FHIR summary. Date from %q. %s", s.DateFrom, err.Error())
+		}
+
+		to, err := parseDate(s.DateTo)
+
+		if err != nil {
+			return phrerror.Errorf(phrerror.Validation, "Invalid FHIR summary. Date to %q. %s", s.DateTo, err.Error())
+		}
+
+		if to.Before(from) {
+			return phrerror.Errorf(phrerror.Validation, "Invalid FHIR summary. Date from %s is after date to %s", s.DateFrom, s.DateTo)
+		}
+	}
+
+	for i, system := range s.CodingSystems {
+		uri, err := url.Parse(system)
+
+		if err != nil || uri.Scheme == "" {
+			return phrerror.Errorf(phrerror.Validation, "Invalid FHIR summary. Coding system %q is not an absolute URI", system)
+		}
+
+		if i > 0 && system <= s.CodingSystems[i-1] {
+			return phrerror.Errorf(phrerror.Validation, "Invalid FHIR summary. Coding systems are not sorted and unique")
+		}
+	}
+
+	return nil
+}
+
+func equal(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
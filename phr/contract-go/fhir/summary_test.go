@@ -0,0 +1,80 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package fhir
+
+import (
+	"testing"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/stretchr/testify/assert"
+)
+
+// #########
+// HELPERS
+// #########
+
+func assertCodedError(t *testing.T, err error, code phrerror.Code, message string, msgAndArgs ...interface{}) {
+	t.Helper()
+
+	decoded, ok := phrerror.DecodeError(err)
+
+	if assert.True(t, ok, msgAndArgs...) {
+		assert.Equal(t, code, decoded.Code, msgAndArgs...)
+		assert.Equal(t, message, decoded.Message, msgAndArgs...)
+	}
+}
+
+// #########
+// TESTS
+// #########
+
+func TestParseSummary(t *testing.T) {
+	var summary *Summary
+	var err error
+
+	summary, err = ParseSummary([]byte(`{"bundleType":"collection","resourceTypes":["Observation"],"resourceCounts":{"Observation":2},"dateFrom":"2021","dateTo":"2022","codingSystems":["http://loinc.org"]}`))
+	assert.Nil(t, err, "should not error for good JSON")
+	assert.Equal(t, &Summary{BundleType: "collection", ResourceTypes: []string{"Observation"}, ResourceCounts: map[string]int{"Observation": 2}, DateFrom: "2021", DateTo: "2022", CodingSystems: []string{"http://loinc.org"}}, summary, "should create expected summary")
+
+	summary, err = ParseSummary([]byte("bad json"))
+	assert.EqualError(t, err, "Error parsing FHIR summary. invalid character 'b' looking for beginning of value", "should error for bad JSON")
+	assert.Nil(t, summary, "should not return summary for bad JSON")
+}
+
+func TestSummaryValidate(t *testing.T) {
+	good := func() *Summary {
+		return &Summary{BundleType: "collection", ResourceTypes: []string{"Observation", "Patient"}, ResourceCounts: map[string]int{"Observation": 2, "Patient": 1}, DateFrom: "2021", DateTo: "2021-06-15T11:00:00Z", CodingSystems: []string{"http://loinc.org", "urn:oid:2.16.840.1.113883.6.1"}}
+	}
+
+	assert.Nil(t, good().Validate(), "should not error for good summary")
+
+	tests := []struct {
+		change func(*Summary)
+		err    string
+	}{
+		{func(s *Summary) { s.BundleType = "" }, `Invalid FHIR summary. Unknown bundle type ""`},
+		{func(s *Summary) { s.ResourceTypes = nil; s.ResourceCounts = nil }, "Invalid FHIR summary. Summary counts no resources"},
+		{func(s *Summary) { s.ResourceCounts["observation"] = 1 }, `Invalid FHIR summary. Invalid resource type "observation"`},
+		{func(s *Summary) { s.ResourceCounts["Observation"] = 0 }, "Invalid FHIR summary. Count 0 of Observation is not positive"},
+		{func(s *Summary) { s.ResourceTypes = []string{"Patient", "Observation"} }, "Invalid FHIR summary. Resource types [Patient Observation] do not match the types counted [Observation Patient]"},
+		{func(s *Summary) { s.ResourceCounts["Patient"] = 2 }, "Invalid FHIR summary. Summary counts 2 Patient resources, expected at most one"},
+		{func(s *Summary) { s.DateTo = "" }, "Invalid FHIR summary. Date range needs both a from and a to date"},
+		{func(s *Summary) { s.DateFrom = "someday" }, `Invalid FHIR summary. Date from "someday". Expected a FHIR date or date time`},
+		{func(s *Summary) { s.DateTo = "someday" }, `Invalid FHIR summary. Date to "someday". Expected a FHIR date or date time`},
+		{func(s *Summary) { s.DateFrom = "2022" }, "Invalid FHIR summary. Date from 2022 is after date to 2021-06-15T11:00:00Z"},
+		{func(s *Summary) { s.CodingSystems = []string{"loinc"} }, `Invalid FHIR summary. Coding system "loinc" is not an absolute URI`},
+		{func(s *Summary) { s.CodingSystems = []string{"http://loinc.org", "http://loinc.org"} }, "Invalid FHIR summary. Coding systems are not sorted and unique"},
+	}
+
+	for _, test := range tests {
+		summary := good()
+		test.change(summary)
+		assertCodedError(t, summary.Validate(), phrerror.Validation, test.err, "should error for bad summary")
+	}
+
+	summary := good()
+	summary.DateFrom, summary.DateTo, summary.CodingSystems = "", "", nil
+	assert.Nil(t, summary.Validate(), "should not error when summary has no date range or coding systems")
+}
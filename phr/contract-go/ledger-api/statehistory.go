@@ -0,0 +1,77 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ledgerapi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+)
+
+// StateModification describes the transaction
+// which wrote a value of a state
+type StateModification struct {
+	TxID      string
+	Timestamp time.Time
+	IsDelete  bool
+}
+
+// StateHistoryIteratorInterface functions that an iterator
+// over the history of a state should have
+type StateHistoryIteratorInterface interface {
+	HasNext() bool
+	Next(StateInterface) (*StateModification, error)
+	Close() error
+}
+
+// StateHistoryIterator implementation of StateHistoryIteratorInterface.
+// Wraps a ledger history query iterator
+type StateHistoryIterator struct {
+	Iterator    shim.HistoryQueryIteratorInterface
+	Deserialize func([]byte, StateInterface) error
+}
+
+// HasNext returns true if there is another modification to read
+func (shi *StateHistoryIterator) HasNext() bool {
+	return shi.Iterator.HasNext()
+}
+
+// Next reads the next modification from the iterator. Unmarshalls
+// the JSON value into passed state unless the modification is a delete
+func (shi *StateHistoryIterator) Next(state StateInterface) (*StateModification, error) {
+	if !shi.Iterator.HasNext() {
+		return nil, fmt.Errorf("No more modifications in iterator")
+	}
+
+	km, err := shi.Iterator.Next()
+
+	if err != nil {
+		return nil, err
+	}
+
+	modification := new(StateModification)
+	modification.TxID = km.TxId
+	modification.IsDelete = km.IsDelete
+
+	if km.Timestamp != nil {
+		modification.Timestamp = time.Unix(km.Timestamp.Seconds, int64(km.Timestamp.Nanos)).UTC()
+	}
+
+	if !km.IsDelete {
+		err = shi.Deserialize(km.Value, state)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return modification, nil
+}
+
+// Close closes the underlying ledger history query iterator
+func (shi *StateHistoryIterator) Close() error {
+	return shi.Iterator.Close()
+}
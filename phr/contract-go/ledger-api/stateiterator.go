@@ -0,0 +1,80 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ledgerapi
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+)
+
+// StateIteratorInterface functions that an iterator
+// over states returned by a query should have
+type StateIteratorInterface interface {
+	HasNext() bool
+	Next(StateInterface) error
+	Close() error
+}
+
+// StateIterator implementation of StateIteratorInterface.
+// Wraps a ledger query iterator, optionally skipping results
+// whose ledger key does not pass Filter
+type StateIterator struct {
+	Iterator    shim.StateQueryIteratorInterface
+	Deserialize func([]byte, StateInterface) error
+	Filter      func(string) bool
+	next        *queryresult.KV
+	err         error
+}
+
+// HasNext returns true if there is another state to read
+func (si *StateIterator) HasNext() bool {
+	if si.next != nil || si.err != nil {
+		return true
+	}
+
+	for si.Iterator.HasNext() {
+		kv, err := si.Iterator.Next()
+
+		if err != nil {
+			si.err = err
+			return true
+		}
+
+		if si.Filter != nil && !si.Filter(kv.Key) {
+			continue
+		}
+
+		si.next = kv
+		return true
+	}
+
+	return false
+}
+
+// Next reads the next state from the iterator. Unmarshalls
+// the JSON into passed state
+func (si *StateIterator) Next(state StateInterface) error {
+	if !si.HasNext() {
+		return fmt.Errorf("No more states in iterator")
+	}
+
+	if si.err != nil {
+		err := si.err
+		si.err = nil
+		return err
+	}
+
+	kv := si.next
+	si.next = nil
+
+	return si.Deserialize(kv.Value, state)
+}
+
+// Close closes the underlying ledger query iterator
+func (si *StateIterator) Close() error {
+	return si.Iterator.Close()
+}
@@ -0,0 +1,102 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ledgerapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/stretchr/testify/assert"
+)
+
+// #########
+// HELPERS
+// #########
+
+type MockStateQueryIterator struct {
+	results []*queryresult.KV
+	errs    []error
+	closed  bool
+}
+
+func (msqi *MockStateQueryIterator) HasNext() bool {
+	return len(msqi.results) > 0
+}
+
+func (msqi *MockStateQueryIterator) Next() (*queryresult.KV, error) {
+	result, err := msqi.results[0], msqi.errs[0]
+	msqi.results = msqi.results[1:]
+	msqi.errs = msqi.errs[1:]
+
+	return result, err
+}
+
+func (msqi *MockStateQueryIterator) Close() error {
+	msqi.closed = true
+
+	return nil
+}
+
+func newMockStateQueryIterator(keys ...string) *MockStateQueryIterator {
+	msqi := new(MockStateQueryIterator)
+
+	for _, key := range keys {
+		parts := SplitKey(key)
+		value, _ := (&MockState{Issuer: parts[0], Number: parts[1]}).Serialize()
+		msqi.results = append(msqi.results, &queryresult.KV{Key: key, Value: value})
+		msqi.errs = append(msqi.errs, nil)
+	}
+
+	return msqi
+}
+
+// #########
+// TESTS
+// #########
+
+func TestStateIterator(t *testing.T) {
+	msqi := newMockStateQueryIterator("a:1", "a:2")
+	si := &StateIterator{Iterator: msqi, Deserialize: deserializeMockState}
+
+	assert.True(t, si.HasNext(), "should have next when underlying iterator has results")
+	assert.True(t, si.HasNext(), "should not advance when HasNext called twice")
+	assert.Equal(t, []string{"a:1", "a:2"}, readMockStates(t, si), "should read every result in order")
+	assert.False(t, si.HasNext(), "should not have next when results read")
+	assert.EqualError(t, si.Next(new(MockState)), "No more states in iterator", "should error when reading past the end")
+
+	assert.Nil(t, si.Close(), "should close iterator")
+	assert.True(t, msqi.closed, "should close underlying iterator")
+}
+
+func TestStateIteratorFilter(t *testing.T) {
+	msqi := newMockStateQueryIterator("a:1", "a:2", "a:3", "a:4")
+	si := &StateIterator{Iterator: msqi, Deserialize: deserializeMockState, Filter: func(key string) bool { return key != "a:1" && key != "a:3" }}
+
+	assert.Equal(t, []string{"a:2", "a:4"}, readMockStates(t, si), "should skip results whose key does not pass filter")
+
+	msqi = newMockStateQueryIterator("a:1")
+	si = &StateIterator{Iterator: msqi, Deserialize: deserializeMockState, Filter: func(key string) bool { return false }}
+
+	assert.False(t, si.HasNext(), "should not have next when no result passes filter")
+}
+
+func TestStateIteratorErrors(t *testing.T) {
+	msqi := newMockStateQueryIterator("a:1", "a:2")
+	msqi.errs[0] = errors.New("Next error")
+	si := &StateIterator{Iterator: msqi, Deserialize: deserializeMockState}
+
+	assert.True(t, si.HasNext(), "should have next when underlying iterator errors")
+	assert.EqualError(t, si.Next(new(MockState)), "Next error", "should return error of underlying iterator")
+
+	state := new(MockState)
+	assert.Nil(t, si.Next(state), "should continue after error of underlying iterator")
+	assert.Equal(t, "2", state.Number, "should read result after error")
+
+	msqi = &MockStateQueryIterator{results: []*queryresult.KV{{Key: "a:1", Value: []byte("bad json")}}, errs: []error{nil}}
+	si = &StateIterator{Iterator: msqi, Deserialize: deserializeMockState}
+
+	assert.NotNil(t, si.Next(new(MockState)), "should error when value cannot be deserialized")
+}
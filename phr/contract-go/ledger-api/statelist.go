@@ -0,0 +1,307 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ledgerapi
+
+import (
+	"fmt"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// StateExistsError returned when adding a state whose
+// key is already in world state
+type StateExistsError struct {
+	Key string
+}
+
+func (err *StateExistsError) Error() string {
+	return phrerror.New(phrerror.AlreadyExists, fmt.Sprintf("State already exists for %s", err.Key), map[string]string{"key": err.Key}).Error()
+}
+
+// StateNotFoundError returned when getting a state
+// whose key is not in world state
+type StateNotFoundError struct {
+	Key string
+}
+
+func (err *StateNotFoundError) Error() string {
+	return phrerror.New(phrerror.NotFound, fmt.Sprintf("No state found for %s", err.Key), map[string]string{"key": err.Key}).Error()
+}
+
+// QueryMetadata describes the page of states
+// returned by a paginated query
+type QueryMetadata struct {
+	FetchedRecordsCount int32
+	Bookmark            string
+}
+
+// StateListInterface functions that a state list
+// should have
+type StateListInterface interface {
+	AddState(StateInterface) error
+	GetState(string, StateInterface) error
+	UpdateState(StateInterface) error
+	DeleteState(string) error
+	PutPrivateData(string, StateInterface) error
+	GetPrivateData(string, string, StateInterface) error
+	GetPrivateDataHash(string, string) ([]byte, error)
+	SetStateValidationParameter(string, []byte) error
+	GetStateHistory(string) (StateHistoryIteratorInterface, error)
+	GetStatesByPartialKey(string) (StateIteratorInterface, error)
+	GetStatesByRange(string, string) (StateIteratorInterface, error)
+	GetStatesByQuery(string) (StateIteratorInterface, error)
+	GetStatesByPartialKeyWithPagination(string, int32, string) (StateIteratorInterface, *QueryMetadata, error)
+	GetStatesByQueryWithPagination(string, int32, string) (StateIteratorInterface, *QueryMetadata, error)
+}
+
+// StateList useful for managing putting data in and out
+// of the ledger. Implementation of StateListInterface
+type StateList struct {
+	Ctx         contractapi.TransactionContextInterface
+	Name        string
+	Deserialize func([]byte, StateInterface) error
+}
+
+// AddState puts a new state into world state. Returns a
+// StateExistsError if a state with the same key already exists
+func (sl *StateList) AddState(state StateInterface) error {
+	key, _ := sl.Ctx.GetStub().CreateCompositeKey(sl.Name, state.GetSplitKey())
+	data, err := sl.Ctx.GetStub().GetState(key)
+
+	if err != nil {
+		return err
+	} else if data != nil {
+		return &StateExistsError{Key: MakeKey(state.GetSplitKey()...)}
+	}
+
+	return sl.putState(key, state)
+}
+
+// GetState returns state from world state. Unmarshalls the JSON
+// into passed state. Key is the split key value used in Add/Update
+// joined using a colon
+func (sl *StateList) GetState(key string, state StateInterface) error {
+	ledgerKey, _ := sl.Ctx.GetStub().CreateCompositeKey(sl.Name, SplitKey(key))
+	data, err := sl.Ctx.GetStub().GetState(ledgerKey)
+
+	if err != nil {
+		return err
+	} else if data == nil {
+		return &StateNotFoundError{Key: key}
+	}
+
+	return sl.Deserialize(data, state)
+}
+
+// UpdateState puts state into world state. Same as AddState but
+// overwrites any existing state with the same key
+func (sl *StateList) UpdateState(state StateInterface) error {
+	key, _ := sl.Ctx.GetStub().CreateCompositeKey(sl.Name, state.GetSplitKey())
+
+	return sl.putState(key, state)
+}
+
+// DeleteState removes state from world state. Key is the split
+// key value used in Add/Update joined using a colon
+func (sl *StateList) DeleteState(key string) error {
+	ledgerKey, _ := sl.Ctx.GetStub().CreateCompositeKey(sl.Name, SplitKey(key))
+
+	return sl.Ctx.GetStub().DelState(ledgerKey)
+}
+
+// PutPrivateData puts state into a private data collection,
+// overwriting any existing state with the same key. Only a hash
+// of the state is written to the channel ledger
+func (sl *StateList) PutPrivateData(collection string, state StateInterface) error {
+	key, _ := sl.Ctx.GetStub().CreateCompositeKey(sl.Name, state.GetSplitKey())
+	data, err := state.Serialize()
+
+	if err != nil {
+		return err
+	}
+
+	return sl.Ctx.GetStub().PutPrivateData(collection, key, data)
+}
+
+// GetPrivateData returns state from a private data collection. Unmarshalls
+// the JSON into passed state. Key is the split key value used in
+// PutPrivateData joined using a colon
+func (sl *StateList) GetPrivateData(collection string, key string, state StateInterface) error {
+	ledgerKey, _ := sl.Ctx.GetStub().CreateCompositeKey(sl.Name, SplitKey(key))
+	data, err := sl.Ctx.GetStub().GetPrivateData(collection, ledgerKey)
+
+	if err != nil {
+		return err
+	} else if data == nil {
+		return &StateNotFoundError{Key: key}
+	}
+
+	return sl.Deserialize(data, state)
+}
+
+// GetPrivateDataHash returns the hash of state in a private data
+// collection as written to the channel ledger, or nil if there is
+// none. Readable by peers of any org. Key is the split key value
+// used in PutPrivateData joined using a colon
+func (sl *StateList) GetPrivateDataHash(collection string, key string) ([]byte, error) {
+	ledgerKey, _ := sl.Ctx.GetStub().CreateCompositeKey(sl.Name, SplitKey(key))
+
+	return sl.Ctx.GetStub().GetPrivateDataHash(collection, ledgerKey)
+}
+
+// SetStateValidationParameter sets the key-level endorsement policy
+// of state, overriding the chaincode endorsement policy for changes to
+// it. Key is the split key value used in Add/Update joined using a colon
+func (sl *StateList) SetStateValidationParameter(key string, policy []byte) error {
+	ledgerKey, _ := sl.Ctx.GetStub().CreateCompositeKey(sl.Name, SplitKey(key))
+
+	return sl.Ctx.GetStub().SetStateValidationParameter(ledgerKey, policy)
+}
+
+func (sl *StateList) putState(key string, state StateInterface) error {
+	data, err := state.Serialize()
+
+	if err != nil {
+		return err
+	}
+
+	return sl.Ctx.GetStub().PutState(key, data)
+}
+
+// GetStateHistory returns an iterator over every value written
+// for the state. Key is the split key value used in Add/Update
+// joined using a colon
+func (sl *StateList) GetStateHistory(key string) (StateHistoryIteratorInterface, error) {
+	ledgerKey, _ := sl.Ctx.GetStub().CreateCompositeKey(sl.Name, SplitKey(key))
+	iterator, err := sl.Ctx.GetStub().GetHistoryForKey(ledgerKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &StateHistoryIterator{Iterator: iterator, Deserialize: sl.Deserialize}, nil
+}
+
+// GetStatesByPartialKey returns an iterator over all states whose
+// key starts with the passed split key parts joined using a colon
+func (sl *StateList) GetStatesByPartialKey(partialKey string) (StateIteratorInterface, error) {
+	iterator, err := sl.Ctx.GetStub().GetStateByPartialCompositeKey(sl.Name, splitPartialKey(partialKey))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &StateIterator{Iterator: iterator, Deserialize: sl.Deserialize}, nil
+}
+
+// GetStatesByRange returns an iterator over all states whose key
+// falls between startKey (inclusive) and endKey (exclusive). Keys
+// are compared part by part. An empty endKey has no upper bound
+func (sl *StateList) GetStatesByRange(startKey string, endKey string) (StateIteratorInterface, error) {
+	startParts := splitPartialKey(startKey)
+	endParts := splitPartialKey(endKey)
+
+	iterator, err := sl.Ctx.GetStub().GetStateByPartialCompositeKey(sl.Name, commonKeyParts(startParts, endParts))
+
+	if err != nil {
+		return nil, err
+	}
+
+	filter := func(ledgerKey string) bool {
+		_, keyParts, err := sl.Ctx.GetStub().SplitCompositeKey(ledgerKey)
+
+		if err != nil {
+			return false
+		}
+
+		return compareKeyParts(keyParts, startParts) >= 0 && (len(endParts) == 0 || compareKeyParts(keyParts, endParts) < 0)
+	}
+
+	return &StateIterator{Iterator: iterator, Deserialize: sl.Deserialize, Filter: filter}, nil
+}
+
+// GetStatesByQuery returns an iterator over all states matching
+// the passed rich query. Only supported when the world state is CouchDB
+func (sl *StateList) GetStatesByQuery(query string) (StateIteratorInterface, error) {
+	iterator, err := sl.Ctx.GetStub().GetQueryResult(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &StateIterator{Iterator: iterator, Deserialize: sl.Deserialize}, nil
+}
+
+// GetStatesByPartialKeyWithPagination returns an iterator over a page
+// of states whose key starts with the passed split key parts joined
+// using a colon. Pass the bookmark of the previous page to continue
+func (sl *StateList) GetStatesByPartialKeyWithPagination(partialKey string, pageSize int32, bookmark string) (StateIteratorInterface, *QueryMetadata, error) {
+	iterator, metadata, err := sl.Ctx.GetStub().GetStateByPartialCompositeKeyWithPagination(sl.Name, splitPartialKey(partialKey), pageSize, bookmark)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &StateIterator{Iterator: iterator, Deserialize: sl.Deserialize}, newQueryMetadata(metadata), nil
+}
+
+// GetStatesByQueryWithPagination returns an iterator over a page of
+// states matching the passed rich query. Only supported when the
+// world state is CouchDB. Pass the bookmark of the previous page to continue
+func (sl *StateList) GetStatesByQueryWithPagination(query string, pageSize int32, bookmark string) (StateIteratorInterface, *QueryMetadata, error) {
+	iterator, metadata, err := sl.Ctx.GetStub().GetQueryResultWithPagination(query, pageSize, bookmark)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &StateIterator{Iterator: iterator, Deserialize: sl.Deserialize}, newQueryMetadata(metadata), nil
+}
+
+func newQueryMetadata(metadata *peer.QueryResponseMetadata) *QueryMetadata {
+	if metadata == nil {
+		return &QueryMetadata{}
+	}
+
+	return &QueryMetadata{FetchedRecordsCount: metadata.FetchedRecordsCount, Bookmark: metadata.Bookmark}
+}
+
+func splitPartialKey(partialKey string) []string {
+	if partialKey == "" {
+		return []string{}
+	}
+
+	return SplitKey(partialKey)
+}
+
+// commonKeyParts returns the leading parts shared by both keys.
+// When endParts is empty there is no upper bound so none are shared
+func commonKeyParts(startParts []string, endParts []string) []string {
+	common := []string{}
+
+	for i := 0; i < len(startParts) && i < len(endParts); i++ {
+		if startParts[i] != endParts[i] {
+			break
+		}
+
+		common = append(common, startParts[i])
+	}
+
+	return common
+}
+
+func compareKeyParts(a []string, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] < b[i] {
+			return -1
+		} else if a[i] > b[i] {
+			return 1
+		}
+	}
+
+	return len(a) - len(b)
+}
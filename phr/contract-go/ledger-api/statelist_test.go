@@ -0,0 +1,312 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package ledgerapi
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+// #########
+// HELPERS
+// #########
+
+const stateListName = "org.phrnet.teststatelist"
+
+type MockState struct {
+	Issuer string `json:"issuer"`
+	Number string `json:"number"`
+	Owner  string `json:"owner"`
+}
+
+func (ms *MockState) GetSplitKey() []string {
+	return []string{ms.Issuer, ms.Number}
+}
+
+func (ms *MockState) Serialize() ([]byte, error) {
+	return json.Marshal(ms)
+}
+
+func deserializeMockState(bytes []byte, state StateInterface) error {
+	return json.Unmarshal(bytes, state)
+}
+
+// MockLedgerStub adds the history and paginated queries the
+// shimtest stub does not implement
+type MockLedgerStub struct {
+	*shimtest.MockStub
+	history       map[string][]*queryresult.KeyModification
+	pageSize      int32
+	bookmark      string
+	pageAttribute []string
+}
+
+func (mls *MockLedgerStub) GetHistoryForKey(key string) (shim.HistoryQueryIteratorInterface, error) {
+	modifications, ok := mls.history[key]
+
+	if !ok {
+		return nil, errors.New("GetHistoryForKey error")
+	}
+
+	return &MockHistoryQueryIterator{modifications: modifications}, nil
+}
+
+func (mls *MockLedgerStub) GetStateByPartialCompositeKeyWithPagination(objectType string, keys []string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	mls.pageSize = pageSize
+	mls.bookmark = bookmark
+	mls.pageAttribute = keys
+
+	iterator, err := mls.GetStateByPartialCompositeKey(objectType, keys)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return iterator, &peer.QueryResponseMetadata{FetchedRecordsCount: pageSize, Bookmark: "somenextbookmark"}, nil
+}
+
+type MockHistoryQueryIterator struct {
+	modifications []*queryresult.KeyModification
+	closed        bool
+}
+
+func (mhqi *MockHistoryQueryIterator) HasNext() bool {
+	return len(mhqi.modifications) > 0
+}
+
+func (mhqi *MockHistoryQueryIterator) Next() (*queryresult.KeyModification, error) {
+	modification := mhqi.modifications[0]
+	mhqi.modifications = mhqi.modifications[1:]
+
+	return modification, nil
+}
+
+func (mhqi *MockHistoryQueryIterator) Close() error {
+	mhqi.closed = true
+
+	return nil
+}
+
+func newMockLedgerStub() *MockLedgerStub {
+	stub := shimtest.NewMockStub("ledgerapi", nil)
+	stub.TxID = "sometx"
+
+	return &MockLedgerStub{MockStub: stub, history: map[string][]*queryresult.KeyModification{}}
+}
+
+func newTestStateList(stub shim.ChaincodeStubInterface) *StateList {
+	ctx := new(contractapi.TransactionContext)
+	ctx.SetStub(stub)
+
+	return &StateList{Ctx: ctx, Name: stateListName, Deserialize: deserializeMockState}
+}
+
+func addMockStates(t *testing.T, sl *StateList, keys ...string) {
+	t.Helper()
+
+	for _, key := range keys {
+		parts := SplitKey(key)
+		err := sl.AddState(&MockState{Issuer: parts[0], Number: parts[1], Owner: "someowner"})
+		assert.Nil(t, err, "should add state %s", key)
+	}
+}
+
+func readMockStates(t *testing.T, iterator StateIteratorInterface) []string {
+	t.Helper()
+
+	keys := []string{}
+
+	for iterator.HasNext() {
+		state := new(MockState)
+		err := iterator.Next(state)
+		assert.Nil(t, err, "should read next state")
+		keys = append(keys, MakeKey(state.GetSplitKey()...))
+	}
+
+	return keys
+}
+
+// #########
+// TESTS
+// #########
+
+func TestAddState(t *testing.T) {
+	sl := newTestStateList(newMockLedgerStub())
+
+	err := sl.AddState(&MockState{Issuer: "someissuer", Number: "somenumber", Owner: "someowner"})
+	assert.Nil(t, err, "should not error when state is new")
+
+	state := new(MockState)
+	err = sl.GetState("someissuer:somenumber", state)
+	assert.Nil(t, err, "should not error when getting added state")
+	assert.Equal(t, &MockState{Issuer: "someissuer", Number: "somenumber", Owner: "someowner"}, state, "should store added state")
+
+	err = sl.AddState(&MockState{Issuer: "someissuer", Number: "somenumber", Owner: "someotherowner"})
+	assert.IsType(t, &StateExistsError{}, err, "should return state exists error when key already exists")
+	assert.Equal(t, "someissuer:somenumber", err.(*StateExistsError).Key, "should set key of existing state")
+	decoded, ok := phrerror.DecodeError(err)
+	assert.True(t, ok, "should return catalogued error when key already exists")
+	assert.Equal(t, phrerror.AlreadyExists, decoded.Code, "should return already exists code")
+	assert.Equal(t, "State already exists for someissuer:somenumber", decoded.Message, "should name key in message")
+
+	state = new(MockState)
+	sl.GetState("someissuer:somenumber", state)
+	assert.Equal(t, "someowner", state.Owner, "should not overwrite existing state")
+}
+
+func TestGetState(t *testing.T) {
+	sl := newTestStateList(newMockLedgerStub())
+
+	err := sl.GetState("someissuer:somemissingnumber", new(MockState))
+	assert.IsType(t, &StateNotFoundError{}, err, "should return state not found error when key missing")
+	assert.Equal(t, "someissuer:somemissingnumber", err.(*StateNotFoundError).Key, "should set key of missing state")
+
+	addMockStates(t, sl, "someissuer:somenumber")
+	err = sl.UpdateState(&MockState{Issuer: "someissuer", Number: "somenumber", Owner: "someotherowner"})
+	assert.Nil(t, err, "should not error when updating state")
+
+	state := new(MockState)
+	err = sl.GetState("someissuer:somenumber", state)
+	assert.Nil(t, err, "should not error when state exists")
+	assert.Equal(t, "someotherowner", state.Owner, "should return updated state")
+
+	err = sl.DeleteState("someissuer:somenumber")
+	assert.Nil(t, err, "should not error when deleting state")
+	err = sl.GetState("someissuer:somenumber", new(MockState))
+	assert.IsType(t, &StateNotFoundError{}, err, "should not find deleted state")
+}
+
+func TestGetStatesByPartialKey(t *testing.T) {
+	stub := newMockLedgerStub()
+	sl := newTestStateList(stub)
+	addMockStates(t, sl, "someissuer:b", "someissuer:a", "someotherissuer:a", "someissuerprefix:a")
+
+	otherList := &StateList{Ctx: sl.Ctx, Name: "org.phrnet.otherstatelist", Deserialize: deserializeMockState}
+	addMockStates(t, otherList, "someissuer:c")
+
+	iterator, err := sl.GetStatesByPartialKey("someissuer")
+	assert.Nil(t, err, "should not error when querying by partial key")
+	assert.Equal(t, []string{"someissuer:a", "someissuer:b"}, readMockStates(t, iterator), "should return states of whole leading key parts in key order")
+	assert.Nil(t, iterator.Close(), "should close iterator")
+
+	iterator, _ = sl.GetStatesByPartialKey("")
+	assert.Equal(t, []string{"someissuer:a", "someissuer:b", "someissuerprefix:a", "someotherissuer:a"}, readMockStates(t, iterator), "should return all states of list for empty partial key")
+
+	iterator, _ = sl.GetStatesByPartialKey("somemissingissuer")
+	assert.False(t, iterator.HasNext(), "should return empty iterator when no key matches")
+	assert.EqualError(t, iterator.Next(new(MockState)), "No more states in iterator", "should error when reading past the end")
+}
+
+func TestGetStatesByRange(t *testing.T) {
+	sl := newTestStateList(newMockLedgerStub())
+	addMockStates(t, sl, "a:1", "a:2", "a:3", "b:1", "b:2", "c:1")
+
+	iterator, err := sl.GetStatesByRange("a:2", "b:2")
+	assert.Nil(t, err, "should not error when querying by range")
+	assert.Equal(t, []string{"a:2", "a:3", "b:1"}, readMockStates(t, iterator), "should include start key and exclude end key")
+
+	iterator, _ = sl.GetStatesByRange("a:2", "a:3")
+	assert.Equal(t, []string{"a:2"}, readMockStates(t, iterator), "should bound range sharing leading key parts")
+
+	iterator, _ = sl.GetStatesByRange("b", "")
+	assert.Equal(t, []string{"b:1", "b:2", "c:1"}, readMockStates(t, iterator), "should not bound range with empty end key")
+
+	iterator, _ = sl.GetStatesByRange("a", "b")
+	assert.Equal(t, []string{"a:1", "a:2", "a:3"}, readMockStates(t, iterator), "should compare partial keys part by part")
+
+	iterator, _ = sl.GetStatesByRange("b:2", "b:2")
+	assert.Equal(t, []string{}, readMockStates(t, iterator), "should return no states for empty range")
+}
+
+func TestGetStatesByPartialKeyWithPagination(t *testing.T) {
+	stub := newMockLedgerStub()
+	sl := newTestStateList(stub)
+	addMockStates(t, sl, "someissuer:a", "someissuer:b", "someotherissuer:a")
+
+	iterator, metadata, err := sl.GetStatesByPartialKeyWithPagination("someissuer", 2, "somebookmark")
+	assert.Nil(t, err, "should not error when querying a page")
+	assert.Equal(t, int32(2), stub.pageSize, "should pass page size to stub")
+	assert.Equal(t, "somebookmark", stub.bookmark, "should pass bookmark to stub")
+	assert.Equal(t, []string{"someissuer"}, stub.pageAttribute, "should pass split partial key to stub")
+	assert.Equal(t, &QueryMetadata{FetchedRecordsCount: 2, Bookmark: "somenextbookmark"}, metadata, "should return fetched count and bookmark of page")
+	assert.Equal(t, []string{"someissuer:a", "someissuer:b"}, readMockStates(t, iterator), "should return states of page")
+
+	assert.Equal(t, &QueryMetadata{}, newQueryMetadata(nil), "should return empty metadata when stub returns none")
+}
+
+func TestGetStateHistory(t *testing.T) {
+	stub := newMockLedgerStub()
+	sl := newTestStateList(stub)
+
+	_, err := sl.GetStateHistory("someissuer:somenumber")
+	assert.EqualError(t, err, "GetHistoryForKey error", "should return error when stub errors")
+
+	key, _ := stub.CreateCompositeKey(stateListName, []string{"someissuer", "somenumber"})
+	issued, _ := (&MockState{Issuer: "someissuer", Number: "somenumber", Owner: "someowner"}).Serialize()
+	bought, _ := (&MockState{Issuer: "someissuer", Number: "somenumber", Owner: "somebuyer"}).Serialize()
+	stub.history[key] = []*queryresult.KeyModification{
+		{TxId: "someissuetx", Value: issued, Timestamp: &timestamp.Timestamp{Seconds: 1639044000}},
+		{TxId: "somebuytx", Value: bought, Timestamp: &timestamp.Timestamp{Seconds: 1639130400, Nanos: 500}},
+		{TxId: "somedeletetx", IsDelete: true},
+	}
+
+	iterator, err := sl.GetStateHistory("someissuer:somenumber")
+	assert.Nil(t, err, "should not error when stub returns history")
+
+	owners := []string{}
+	modifications := []*StateModification{}
+
+	for iterator.HasNext() {
+		state := new(MockState)
+		modification, err := iterator.Next(state)
+		assert.Nil(t, err, "should read next modification")
+		owners = append(owners, state.Owner)
+		modifications = append(modifications, modification)
+	}
+
+	assert.Equal(t, []string{"someowner", "somebuyer", ""}, owners, "should deserialize values in ledger order and skip deletes")
+	assert.Equal(t, "someissuetx", modifications[0].TxID, "should set tx id")
+	assert.Equal(t, "2021-12-09T10:00:00Z", modifications[0].Timestamp.Format("2006-01-02T15:04:05Z07:00"), "should set timestamp in UTC")
+	assert.Equal(t, 500, modifications[1].Timestamp.Nanosecond(), "should keep nanoseconds of timestamp")
+	assert.True(t, modifications[2].IsDelete, "should flag delete")
+	assert.True(t, modifications[2].Timestamp.IsZero(), "should leave timestamp zero when missing")
+
+	_, err = iterator.Next(new(MockState))
+	assert.EqualError(t, err, "No more modifications in iterator", "should error when reading past the end")
+
+	assert.Nil(t, iterator.Close(), "should close iterator")
+	assert.True(t, iterator.(*StateHistoryIterator).Iterator.(*MockHistoryQueryIterator).closed, "should close underlying iterator")
+
+	stub.history[key] = []*queryresult.KeyModification{{TxId: "somebadtx", Value: []byte("bad json")}}
+	iterator, _ = sl.GetStateHistory("someissuer:somenumber")
+	_, err = iterator.Next(new(MockState))
+	assert.NotNil(t, err, "should error when value cannot be deserialized")
+}
+
+func TestCommonKeyParts(t *testing.T) {
+	assert.Equal(t, []string{"a"}, commonKeyParts([]string{"a", "1"}, []string{"a", "2"}), "should return leading parts shared by both keys")
+	assert.Equal(t, []string{"a", "1"}, commonKeyParts([]string{"a", "1"}, []string{"a", "1", "x"}), "should stop at the shorter key")
+	assert.Equal(t, []string{}, commonKeyParts([]string{"a", "1"}, []string{"b", "1"}), "should return no parts when first parts differ")
+	assert.Equal(t, []string{}, commonKeyParts([]string{"a", "1"}, []string{}), "should return no parts when end key is empty")
+}
+
+func TestCompareKeyParts(t *testing.T) {
+	assert.Equal(t, 0, compareKeyParts([]string{"a", "1"}, []string{"a", "1"}), "should return zero for equal keys")
+	assert.True(t, compareKeyParts([]string{"a", "1"}, []string{"a", "2"}) < 0, "should order by first differing part")
+	assert.True(t, compareKeyParts([]string{"b"}, []string{"a", "9"}) > 0, "should order by first part before later parts")
+	assert.True(t, compareKeyParts([]string{"a"}, []string{"a", "1"}) < 0, "should order prefix before longer key")
+	assert.True(t, compareKeyParts([]string{"a", "10"}, []string{"a", "9"}) < 0, "should compare parts as strings")
+	assert.Equal(t, 0, compareKeyParts([]string{}, []string{}), "should return zero for empty keys")
+}
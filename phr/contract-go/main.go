@@ -0,0 +1,26 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/deployment"
+)
+
+func main() {
+
+	chaincode, err := deployment.NewChaincode(deployment.Default())
+
+	if err != nil {
+		panic(fmt.Sprintf("Error creating chaincode. %s", err.Error()))
+	}
+
+	err = chaincode.Start()
+
+	if err != nil {
+		panic(fmt.Sprintf("Error starting chaincode. %s", err.Error()))
+	}
+}
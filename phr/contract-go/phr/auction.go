@@ -0,0 +1,74 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AuctionState enum for auction state property
+type AuctionState uint
+
+const (
+	// COMMITTING state for when an auction takes sealed bids
+	COMMITTING AuctionState = iota + 1
+	// REVEALING state for when bidders reveal their sealed bids
+	REVEALING
+	// CLOSED state for when an auction has been closed
+	CLOSED
+)
+
+func (state AuctionState) String() string {
+	names := []string{"COMMITTING", "REVEALING", "CLOSED"}
+
+	if state < COMMITTING || state > CLOSED {
+		return "UNKNOWN"
+	}
+
+	return names[state-1]
+}
+
+// Auction defines a sealed-bid auction of a phr. Bids are committed
+// until the commit deadline and revealed until the reveal deadline
+type Auction struct {
+	Issuer         string       `json:"issuer"`
+	PHRNumber      string       `json:"phrNumber"`
+	AuctionID      string       `json:"auctionId"`
+	Seller         string       `json:"seller"`
+	ReservePrice   int          `json:"reservePrice"`
+	StartDateTime  string       `json:"startDateTime"`
+	CommitDeadline string       `json:"commitDeadline"`
+	RevealDeadline string       `json:"revealDeadline"`
+	Winner         string       `json:"winner,omitempty" metadata:"winner,optional"`
+	WinningPrice   int          `json:"winningPrice,omitempty" metadata:"winningPrice,optional"`
+	State          AuctionState `json:"state"`
+}
+
+// IsClosed returns true if the auction has been closed
+func (auction *Auction) IsClosed() bool {
+	return auction.State == CLOSED
+}
+
+// GetSplitKey returns values which should be used to form key
+func (auction *Auction) GetSplitKey() []string {
+	return []string{auction.Issuer, auction.PHRNumber}
+}
+
+// Serialize formats the auction as JSON bytes
+func (auction *Auction) Serialize() ([]byte, error) {
+	return json.Marshal(auction)
+}
+
+// DeserializeAuction formats the auction from JSON bytes
+func DeserializeAuction(bytes []byte, auction *Auction) error {
+	err := json.Unmarshal(bytes, auction)
+
+	if err != nil {
+		return fmt.Errorf("Error deserializing auction. %s", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuctionStateString(t *testing.T) {
+	assert.Equal(t, "COMMITTING", COMMITTING.String(), "should return string for committing")
+	assert.Equal(t, "REVEALING", REVEALING.String(), "should return string for revealing")
+	assert.Equal(t, "CLOSED", CLOSED.String(), "should return string for closed")
+	assert.Equal(t, "UNKNOWN", AuctionState(CLOSED+1).String(), "should return unknown when not one of constants")
+}
+
+func TestAuctionIsClosed(t *testing.T) {
+	assert.False(t, (&Auction{State: COMMITTING}).IsClosed(), "should not be closed when committing")
+	assert.False(t, (&Auction{State: REVEALING}).IsClosed(), "should not be closed when revealing")
+	assert.True(t, (&Auction{State: CLOSED}).IsClosed(), "should be closed when closed")
+}
+
+func TestAuctionGetSplitKey(t *testing.T) {
+	auction := &Auction{Issuer: "someissuer", PHRNumber: "somephr", AuctionID: "sometx"}
+
+	assert.Equal(t, []string{"someissuer", "somephr"}, auction.GetSplitKey(), "should return issuer and phr number as split key")
+}
+
+func TestAuctionSerialize(t *testing.T) {
+	auction := &Auction{Issuer: "someissuer", PHRNumber: "somephr", AuctionID: "sometx", Seller: "someowner", ReservePrice: 100, StartDateTime: "2021-12-10T10:00:00Z", CommitDeadline: "2021-12-11T10:00:00Z", RevealDeadline: "2021-12-12T10:00:00Z", State: COMMITTING}
+
+	bytes, err := auction.Serialize()
+	assert.Nil(t, err, "should not error on serialize")
+	assert.Equal(t, `{"issuer":"someissuer","phrNumber":"somephr","auctionId":"sometx","seller":"someowner","reservePrice":100,"startDateTime":"2021-12-10T10:00:00Z","commitDeadline":"2021-12-11T10:00:00Z","revealDeadline":"2021-12-12T10:00:00Z","state":1}`, string(bytes), "should return JSON formatted value without winner while open")
+}
+
+func TestDeserializeAuction(t *testing.T) {
+	var auction *Auction
+	var err error
+
+	auction = new(Auction)
+	err = DeserializeAuction([]byte(`{"issuer":"someissuer","phrNumber":"somephr","winner":"somebidder","winningPrice":200,"state":3}`), auction)
+	assert.Nil(t, err, "should not return error for deserialize")
+	assert.Equal(t, &Auction{Issuer: "someissuer", PHRNumber: "somephr", Winner: "somebidder", WinningPrice: 200, State: CLOSED}, auction, "should create expected auction")
+
+	auction = new(Auction)
+	err = DeserializeAuction([]byte(`{"reservePrice":"NaN"}`), auction)
+	assert.EqualError(t, err, "Error deserializing auction. json: cannot unmarshal string into Go struct field Auction.reservePrice of type int", "should return error for bad data")
+}
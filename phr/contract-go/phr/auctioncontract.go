@@ -0,0 +1,345 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"fmt"
+
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+)
+
+// BidHashTransientKey key of the transient data holding
+// the commitment of CommitBid, as returned by HashBid
+const BidHashTransientKey = "bidHash"
+
+// StartAuction starts a sealed-bid auction of a phr. Bids are committed until
+// commitDeadline and revealed until revealDeadline. Submitted by the owner
+func (c *Contract) StartAuction(ctx TransactionContextInterface, issuer string, phrNumber string, reservePrice int, commitDeadline string, revealDeadline string) (*Auction, error) {
+	commitDeadline, err := parseDateTime("commitDeadline", commitDeadline, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	revealDeadline, err = parseDateTime("revealDeadline", revealDeadline, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if reservePrice < 0 {
+		return nil, &ValidationError{Field: "reservePrice", Value: fmt.Sprint(reservePrice), Reason: "Must not be negative"}
+	}
+
+	phr, err := ctx.GetPHRList().GetPHR(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := ctx.GetCaller()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if phr.Owner != caller {
+		return nil, phrerror.Errorf(phrerror.NotOwner, "PHR %s:%s is not owned by submitter %s", issuer, phrNumber, caller)
+	}
+
+	if phr.IsExpired() {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s is already expired", issuer, phrNumber)
+	}
+
+	txTime, err := getTxTime(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if phr.IsMatured(txTime) {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s has matured. Maturity date time = %s", issuer, phrNumber, phr.MaturityDateTime)
+	}
+
+	startDateTime := FormatDateTime(txTime)
+
+	if commitDeadline <= startDateTime {
+		return nil, &ValidationError{Field: "commitDeadline", Value: commitDeadline, Reason: "Must be after the start date time " + startDateTime}
+	}
+
+	if revealDeadline <= commitDeadline {
+		return nil, &ValidationError{Field: "revealDeadline", Value: revealDeadline, Reason: "Must be after the commit deadline " + commitDeadline}
+	}
+
+	// an auction left open by a previous owner does not block a new one
+	existing, err := ctx.GetAuctionList().GetAuction(issuer, phrNumber)
+
+	if _, ok := err.(*ledgerapi.StateNotFoundError); !ok && err != nil {
+		return nil, err
+	} else if err == nil && !existing.IsClosed() && existing.Seller == phr.Owner {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s is already being auctioned", issuer, phrNumber)
+	}
+
+	auction := Auction{Issuer: issuer, PHRNumber: phrNumber, AuctionID: ctx.GetStub().GetTxID(), Seller: caller, ReservePrice: reservePrice, StartDateTime: startDateTime, CommitDeadline: commitDeadline, RevealDeadline: revealDeadline, State: COMMITTING}
+
+	err = ctx.GetAuctionList().UpdateAuction(&auction)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = setEvent(ctx, &PHREvent{Name: AuctionStartedEvent, Issuer: issuer, PHRNumber: phrNumber, PreviousOwner: phr.Owner, NewOwner: phr.Owner, Price: reservePrice, PreviousState: phr.GetState(), NewState: phr.GetState()})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &auction, nil
+}
+
+// CommitBid commits the submitter to a sealed bid in the auction of a phr.
+// The commitment is read from the transient data so it stays out of the
+// transaction arguments. A bid on a phr naming a patient states the
+// purpose of use the same as Buy. Replaces any earlier commitment of
+// the submitter
+func (c *Contract) CommitBid(ctx TransactionContextInterface, issuer string, phrNumber string, purpose string) (*SealedBid, error) {
+	auction, err := ctx.GetAuctionList().GetAuction(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := ctx.GetCaller()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if auction.Seller == caller {
+		return nil, phrerror.Errorf(phrerror.Forbidden, "Seller %s cannot bid in auction of PHR %s:%s", caller, issuer, phrNumber)
+	}
+
+	txTime, err := getTxTime(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	committedDateTime := FormatDateTime(txTime)
+
+	if auction.State != COMMITTING || committedDateTime >= auction.CommitDeadline {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "Auction of PHR %s:%s is not taking bids. Commit deadline = %s", issuer, phrNumber, auction.CommitDeadline)
+	}
+
+	transient, err := ctx.GetStub().GetTransient()
+
+	if err != nil {
+		return nil, err
+	}
+
+	hash := string(transient[BidHashTransientKey])
+
+	if !sha256HexPattern.MatchString(hash) {
+		return nil, &ValidationError{Field: BidHashTransientKey, Value: hash, Reason: "Expected a hex encoded SHA-256 hash in the transient data"}
+	}
+
+	bid := SealedBid{Issuer: issuer, PHRNumber: phrNumber, AuctionID: auction.AuctionID, Bidder: caller, Hash: hash, CommittedDateTime: committedDateTime, Purpose: purpose}
+
+	err = ctx.GetAuctionList().UpdateSealedBid(&bid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = setEvent(ctx, &PHREvent{Name: BidCommittedEvent, Issuer: issuer, PHRNumber: phrNumber, PreviousOwner: auction.Seller, NewOwner: caller})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &bid, nil
+}
+
+// RevealBid reveals the price and salt of the sealed bid of the submitter.
+// Only allowed between the commit and reveal deadlines of the auction
+func (c *Contract) RevealBid(ctx TransactionContextInterface, issuer string, phrNumber string, price int, salt string) (*SealedBid, error) {
+	auction, err := ctx.GetAuctionList().GetAuction(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := ctx.GetCaller()
+
+	if err != nil {
+		return nil, err
+	}
+
+	txTime, err := getTxTime(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	revealedDateTime := FormatDateTime(txTime)
+
+	if auction.IsClosed() || revealedDateTime < auction.CommitDeadline || revealedDateTime >= auction.RevealDeadline {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "Auction of PHR %s:%s is not revealing bids. Reveal period = %s to %s", issuer, phrNumber, auction.CommitDeadline, auction.RevealDeadline)
+	}
+
+	bid, err := ctx.GetAuctionList().GetSealedBid(issuer, phrNumber, auction.AuctionID, caller)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if bid.IsRevealed() {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "Bid of %s in auction of PHR %s:%s is already revealed", caller, issuer, phrNumber)
+	}
+
+	if HashBid(issuer, phrNumber, caller, price, salt) != bid.Hash {
+		return nil, phrerror.Errorf(phrerror.Validation, "Bid of %s in auction of PHR %s:%s does not match its commitment", caller, issuer, phrNumber)
+	}
+
+	bid.Price = price
+	bid.Salt = salt
+	bid.RevealedDateTime = revealedDateTime
+
+	err = ctx.GetAuctionList().UpdateSealedBid(bid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if auction.State == COMMITTING {
+		auction.State = REVEALING
+
+		err = ctx.GetAuctionList().UpdateAuction(auction)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = setEvent(ctx, &PHREvent{Name: BidRevealedEvent, Issuer: issuer, PHRNumber: phrNumber, PreviousOwner: auction.Seller, NewOwner: caller, Price: price})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bid, nil
+}
+
+// CloseAuction closes the auction of a phr after the reveal deadline and
+// transfers the phr to the highest revealed bid at or above the reserve
+// price the same way as Buy. Bids of bidders who cannot receive the phr
+// or pay the price are passed over, and an auction of a phr that has
+// matured or left trading closes without a winner. Submitted by the seller
+func (c *Contract) CloseAuction(ctx TransactionContextInterface, issuer string, phrNumber string) (*Auction, error) {
+	auction, err := ctx.GetAuctionList().GetAuction(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if auction.IsClosed() {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "Auction of PHR %s:%s is already closed", issuer, phrNumber)
+	}
+
+	phr, err := ctx.GetPHRList().GetPHR(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := ctx.GetCaller()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if auction.Seller != caller || phr.Owner != caller {
+		return nil, phrerror.Errorf(phrerror.NotOwner, "PHR %s:%s is not owned by submitter %s", issuer, phrNumber, caller)
+	}
+
+	txTime, err := getTxTime(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if FormatDateTime(txTime) < auction.RevealDeadline {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "Auction of PHR %s:%s cannot close before the reveal deadline %s", issuer, phrNumber, auction.RevealDeadline)
+	}
+
+	bids, err := ctx.GetAuctionList().GetSealedBids(issuer, phrNumber, auction.AuctionID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var winner *SealedBid
+
+	if (phr.IsIssued() || phr.IsTrading()) && !phr.IsMatured(txTime) {
+		for _, candidate := range RankSealedBids(bids, auction.ReservePrice) {
+			err = checkBid(ctx, phr, candidate.Bidder, candidate.Price, candidate.Purpose, txTime)
+
+			if err == nil {
+				winner = candidate
+				break
+			} else if phrerror.CodeOf(err) == "" {
+				return nil, err
+			}
+		}
+	}
+
+	previousState := phr.GetState()
+
+	if winner != nil {
+		if phr.IsIssued() {
+			phr.SetTrading()
+		}
+
+		auction.Winner = winner.Bidder
+		auction.WinningPrice = winner.Price
+	}
+
+	auction.State = CLOSED
+
+	err = ctx.GetAuctionList().UpdateAuction(auction)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if winner == nil {
+		err = setEvent(ctx, &PHREvent{Name: AuctionClosedEvent, Issuer: issuer, PHRNumber: phrNumber, PreviousOwner: phr.Owner, NewOwner: phr.Owner, PreviousState: previousState, NewState: phr.GetState()})
+	} else {
+		err = transfer(ctx, phr, winner.Bidder, winner.Price, "", previousState, txTime)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return auction, nil
+}
+
+// GetAuction returns the latest auction of a phr
+func (c *Contract) GetAuction(ctx TransactionContextInterface, issuer string, phrNumber string) (*Auction, error) {
+	return ctx.GetAuctionList().GetAuction(issuer, phrNumber)
+}
+
+// GetSealedBids returns the sealed bids of the latest auction of a phr
+func (c *Contract) GetSealedBids(ctx TransactionContextInterface, issuer string, phrNumber string) ([]*SealedBid, error) {
+	auction, err := ctx.GetAuctionList().GetAuction(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx.GetAuctionList().GetSealedBids(issuer, phrNumber, auction.AuctionID)
+}
@@ -0,0 +1,478 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"errors"
+	"testing"
+
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// #########
+// HELPERS
+// #########
+
+func setTransient(ctx *MockTransactionContext, transient map[string][]byte) {
+	stub, ok := ctx.GetStub().(*shimtest.MockStub)
+
+	if !ok {
+		stub = ctx.GetStub().(*MockTransientStub).MockStub
+	}
+
+	ctx.SetStub(&MockTransientStub{MockStub: stub, transient: transient})
+}
+
+// #########
+// TESTS
+// #########
+
+func TestStartAuction(t *testing.T) {
+	var auction *Auction
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mpl := ctx.phrList
+	mal := ctx.auctionList
+
+	contract := new(Contract)
+
+	wsPHR := new(PHR)
+	resetPHR(wsPHR)
+	wsPHR.MaturityDateTime = "2022-12-10T10:00:00Z"
+
+	var emptyPHR *PHR
+	var emptyAuction *Auction
+	var sentAuction *Auction
+	shouldError := false
+
+	mpl.On("GetPHR", "someissuer", "somephr").Return(wsPHR, nil)
+	mpl.On("GetPHR", "someissuer", "someauctionedphr").Return(wsPHR, nil)
+	mpl.On("GetPHR", "someissuer", "somestalephr").Return(wsPHR, nil)
+	mpl.On("GetPHR", "someissuer", "somebadphr").Return(wsPHR, nil)
+	mpl.On("GetPHR", "someotherissuer", "someotherphr").Return(emptyPHR, errors.New("GetPHR error"))
+	mal.On("GetAuction", "someissuer", "somephr").Return(emptyAuction, &ledgerapi.StateNotFoundError{Key: "someissuer:somephr"})
+	mal.On("GetAuction", "someissuer", "someauctionedphr").Return(&Auction{Seller: "someowner", State: REVEALING}, nil)
+	mal.On("GetAuction", "someissuer", "somestalephr").Return(&Auction{Seller: "someformerowner", State: COMMITTING}, nil)
+	mal.On("GetAuction", "someissuer", "somebadphr").Return(emptyAuction, errors.New("GetAuction error"))
+	mal.On("UpdateAuction", mock.MatchedBy(func(auction *Auction) bool { return shouldError })).Return(errors.New("UpdateAuction error"))
+	mal.On("UpdateAuction", mock.MatchedBy(func(auction *Auction) bool { sentAuction = auction; return !shouldError })).Return(nil)
+
+	auction, err = contract.StartAuction(ctx, "someissuer", "somephr", 100, "2021-12-11", "2021-12-12T10:00:00Z")
+	assertCodedError(t, err, phrerror.Validation, `Invalid commitDeadline "2021-12-11". Expected an RFC 3339 date time`, "should error when commit deadline does not parse")
+	assert.Nil(t, auction, "should not return auction when commit deadline does not parse")
+
+	auction, err = contract.StartAuction(ctx, "someissuer", "somephr", 100, "2021-12-11T10:00:00Z", "")
+	assertCodedError(t, err, phrerror.Validation, `Invalid revealDeadline "". Value is required`, "should error when reveal deadline missing")
+	assert.Nil(t, auction, "should not return auction when reveal deadline missing")
+
+	auction, err = contract.StartAuction(ctx, "someissuer", "somephr", -1, "2021-12-11T10:00:00Z", "2021-12-12T10:00:00Z")
+	assertCodedError(t, err, phrerror.Validation, `Invalid reservePrice "-1". Must not be negative`, "should error when reserve price negative")
+	assert.Nil(t, auction, "should not return auction when reserve price negative")
+
+	auction, err = contract.StartAuction(ctx, "someotherissuer", "someotherphr", 100, "2021-12-11T10:00:00Z", "2021-12-12T10:00:00Z")
+	assert.EqualError(t, err, "GetPHR error", "should error when GetPHR errors")
+	assert.Nil(t, auction, "should not return auction when GetPHR errors")
+
+	otherCtx := newMockTransactionContext("someotherowner")
+	otherCtx.phrList = mpl
+	auction, err = contract.StartAuction(otherCtx, "someissuer", "somephr", 100, "2021-12-11T10:00:00Z", "2021-12-12T10:00:00Z")
+	assertCodedError(t, err, phrerror.NotOwner, "PHR someissuer:somephr is not owned by submitter someotherowner", "should error when submitter not owner")
+	assert.Nil(t, auction, "should not return auction when submitter not owner")
+
+	wsPHR.SetExpired()
+	auction, err = contract.StartAuction(ctx, "someissuer", "somephr", 100, "2021-12-11T10:00:00Z", "2021-12-12T10:00:00Z")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:somephr is already expired", "should error when phr expired")
+	assert.Nil(t, auction, "should not return auction when phr expired")
+	resetPHR(wsPHR)
+
+	wsPHR.MaturityDateTime = "2021-12-10T10:00:00Z"
+	auction, err = contract.StartAuction(ctx, "someissuer", "somephr", 100, "2021-12-11T10:00:00Z", "2021-12-12T10:00:00Z")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:somephr has matured. Maturity date time = 2021-12-10T10:00:00Z", "should error when phr matured")
+	assert.Nil(t, auction, "should not return auction when phr matured")
+	wsPHR.MaturityDateTime = "2022-12-10T10:00:00Z"
+
+	auction, err = contract.StartAuction(ctx, "someissuer", "somephr", 100, "2021-12-10T10:00:00Z", "2021-12-12T10:00:00Z")
+	assertCodedError(t, err, phrerror.Validation, `Invalid commitDeadline "2021-12-10T10:00:00Z". Must be after the start date time 2021-12-10T10:00:00Z`, "should error when commit deadline not after tx time")
+	assert.Nil(t, auction, "should not return auction when commit deadline not after tx time")
+
+	auction, err = contract.StartAuction(ctx, "someissuer", "somephr", 100, "2021-12-11T10:00:00Z", "2021-12-11T10:00:00Z")
+	assertCodedError(t, err, phrerror.Validation, `Invalid revealDeadline "2021-12-11T10:00:00Z". Must be after the commit deadline 2021-12-11T10:00:00Z`, "should error when reveal deadline not after commit deadline")
+	assert.Nil(t, auction, "should not return auction when reveal deadline not after commit deadline")
+
+	auction, err = contract.StartAuction(ctx, "someissuer", "somebadphr", 100, "2021-12-11T10:00:00Z", "2021-12-12T10:00:00Z")
+	assert.EqualError(t, err, "GetAuction error", "should error when GetAuction errors")
+	assert.Nil(t, auction, "should not return auction when GetAuction errors")
+
+	auction, err = contract.StartAuction(ctx, "someissuer", "someauctionedphr", 100, "2021-12-11T10:00:00Z", "2021-12-12T10:00:00Z")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:someauctionedphr is already being auctioned", "should error when owner already auctioning phr")
+	assert.Nil(t, auction, "should not return auction when already auctioning")
+
+	auction, err = contract.StartAuction(ctx, "someissuer", "somestalephr", 100, "2021-12-11T10:00:00Z", "2021-12-12T10:00:00Z")
+	assert.Nil(t, err, "should not error when open auction is by former owner")
+	assert.Equal(t, "someowner", auction.Seller, "should replace auction of former owner")
+	getEvents(ctx)
+
+	wsPHR.PatientHash = "somepatienthash"
+	wsPHR.PrivateDetailsHash = "somehash"
+	auction, err = contract.StartAuction(ctx, "someissuer", "somephr", 100, "2021-12-11T10:00:00Z", "2021-12-12T10:00:00Z")
+	assert.Nil(t, err, "should not error when phr names a patient and has private details")
+	assert.Equal(t, COMMITTING, auction.State, "should start auction of phr naming a patient with private details")
+	wsPHR.PatientHash = ""
+	wsPHR.PrivateDetailsHash = ""
+	getEvents(ctx)
+
+	shouldError = true
+	auction, err = contract.StartAuction(ctx, "someissuer", "somephr", 100, "2021-12-11T10:00:00Z", "2021-12-12T10:00:00Z")
+	assert.EqualError(t, err, "UpdateAuction error", "should error when UpdateAuction errors")
+	assert.Nil(t, auction, "should not return auction when UpdateAuction errors")
+	shouldError = false
+
+	auction, err = contract.StartAuction(ctx, "someissuer", "somephr", 100, "2021-12-11T10:00:00Z", "2021-12-12T10:00:00Z")
+	assert.Nil(t, err, "should not error on good start")
+	assert.Equal(t, &Auction{Issuer: "someissuer", PHRNumber: "somephr", AuctionID: "sometx", Seller: "someowner", ReservePrice: 100, StartDateTime: "2021-12-10T10:00:00Z", CommitDeadline: "2021-12-11T10:00:00Z", RevealDeadline: "2021-12-12T10:00:00Z", State: COMMITTING}, auction, "should return committing auction identified by tx")
+	assert.Equal(t, sentAuction, auction, "should put same auction as it returns in the world state")
+	assert.Equal(t, []*PHREvent{{Version: EventVersion, Name: AuctionStartedEvent, Issuer: "someissuer", PHRNumber: "somephr", PreviousOwner: "someowner", NewOwner: "someowner", Price: 100, PreviousState: TRADING, NewState: TRADING, Timestamp: "2021-12-10T10:00:00Z"}}, getEvents(ctx), "should emit auction started event")
+}
+
+func TestCommitBid(t *testing.T) {
+	var bid *SealedBid
+	var err error
+
+	ctx := newMockTransactionContext("somebidder")
+	mal := ctx.auctionList
+
+	contract := new(Contract)
+
+	hash := HashBid("someissuer", "somephr", "somebidder", 150, "somesalt")
+	wsAuction := &Auction{Issuer: "someissuer", PHRNumber: "somephr", AuctionID: "someauction", Seller: "someowner", CommitDeadline: "2021-12-11T10:00:00Z", RevealDeadline: "2021-12-12T10:00:00Z", State: COMMITTING}
+	lateAuction := &Auction{Issuer: "someissuer", PHRNumber: "somelatephr", AuctionID: "someauction", Seller: "someowner", CommitDeadline: "2021-12-10T10:00:00Z", RevealDeadline: "2021-12-12T10:00:00Z", State: COMMITTING}
+
+	var emptyAuction *Auction
+	var sentBid *SealedBid
+	shouldError := false
+
+	mal.On("GetAuction", "someissuer", "somephr").Return(wsAuction, nil)
+	mal.On("GetAuction", "someissuer", "somelatephr").Return(lateAuction, nil)
+	mal.On("GetAuction", "someotherissuer", "someotherphr").Return(emptyAuction, &ledgerapi.StateNotFoundError{Key: "someotherissuer:someotherphr"})
+	mal.On("UpdateSealedBid", mock.MatchedBy(func(bid *SealedBid) bool { return shouldError })).Return(errors.New("UpdateSealedBid error"))
+	mal.On("UpdateSealedBid", mock.MatchedBy(func(bid *SealedBid) bool { sentBid = bid; return !shouldError })).Return(nil)
+
+	bid, err = contract.CommitBid(ctx, "someotherissuer", "someotherphr", "")
+	assertCodedError(t, err, phrerror.NotFound, "No state found for someotherissuer:someotherphr", "should error when phr not auctioned")
+	assert.Nil(t, bid, "should not return bid when phr not auctioned")
+
+	sellerCtx := newMockTransactionContext("someowner")
+	sellerCtx.auctionList = mal
+	bid, err = contract.CommitBid(sellerCtx, "someissuer", "somephr", "")
+	assertCodedError(t, err, phrerror.Forbidden, "Seller someowner cannot bid in auction of PHR someissuer:somephr", "should error when seller bids")
+	assert.Nil(t, bid, "should not return bid when seller bids")
+
+	bid, err = contract.CommitBid(ctx, "someissuer", "somelatephr", "")
+	assertCodedError(t, err, phrerror.InvalidState, "Auction of PHR someissuer:somelatephr is not taking bids. Commit deadline = 2021-12-10T10:00:00Z", "should error when commit deadline passed")
+	assert.Nil(t, bid, "should not return bid when commit deadline passed")
+
+	wsAuction.State = REVEALING
+	bid, err = contract.CommitBid(ctx, "someissuer", "somephr", "")
+	assertCodedError(t, err, phrerror.InvalidState, "Auction of PHR someissuer:somephr is not taking bids. Commit deadline = 2021-12-11T10:00:00Z", "should error when auction not committing")
+	assert.Nil(t, bid, "should not return bid when auction not committing")
+	wsAuction.State = COMMITTING
+
+	bid, err = contract.CommitBid(ctx, "someissuer", "somephr", "")
+	assertCodedError(t, err, phrerror.Validation, `Invalid bidHash "". Expected a hex encoded SHA-256 hash in the transient data`, "should error when transient data has no hash")
+	assert.Nil(t, bid, "should not return bid when transient data has no hash")
+
+	setTransient(ctx, map[string][]byte{BidHashTransientKey: []byte("150")})
+	bid, err = contract.CommitBid(ctx, "someissuer", "somephr", "")
+	assertCodedError(t, err, phrerror.Validation, `Invalid bidHash "150". Expected a hex encoded SHA-256 hash in the transient data`, "should error when transient data is not a hash")
+	assert.Nil(t, bid, "should not return bid when transient data is not a hash")
+
+	setTransient(ctx, map[string][]byte{BidHashTransientKey: []byte(hash)})
+
+	shouldError = true
+	bid, err = contract.CommitBid(ctx, "someissuer", "somephr", "")
+	assert.EqualError(t, err, "UpdateSealedBid error", "should error when UpdateSealedBid errors")
+	assert.Nil(t, bid, "should not return bid when UpdateSealedBid errors")
+	shouldError = false
+
+	bid, err = contract.CommitBid(ctx, "someissuer", "somephr", "research")
+	assert.Nil(t, err, "should not error on good commit")
+	assert.Equal(t, &SealedBid{Issuer: "someissuer", PHRNumber: "somephr", AuctionID: "someauction", Bidder: "somebidder", Hash: hash, CommittedDateTime: "2021-12-10T10:00:00Z", Purpose: "research"}, bid, "should return sealed bid of submitter")
+	assert.Equal(t, sentBid, bid, "should put same sealed bid as it returns in the world state")
+	assert.Equal(t, []*PHREvent{{Version: EventVersion, Name: BidCommittedEvent, Issuer: "someissuer", PHRNumber: "somephr", PreviousOwner: "someowner", NewOwner: "somebidder", Timestamp: "2021-12-10T10:00:00Z"}}, getEvents(ctx), "should emit bid committed event without price")
+}
+
+func TestRevealBid(t *testing.T) {
+	var bid *SealedBid
+	var err error
+
+	ctx := newMockTransactionContext("somebidder")
+	mal := ctx.auctionList
+
+	contract := new(Contract)
+
+	wsAuction := &Auction{Issuer: "someissuer", PHRNumber: "somephr", AuctionID: "someauction", Seller: "someowner", CommitDeadline: "2021-12-10T10:00:00Z", RevealDeadline: "2021-12-11T10:00:00Z", State: COMMITTING}
+	earlyAuction := &Auction{Issuer: "someissuer", PHRNumber: "someearlyphr", AuctionID: "someauction", CommitDeadline: "2021-12-10T10:00:01Z", RevealDeadline: "2021-12-11T10:00:00Z", State: COMMITTING}
+	lateAuction := &Auction{Issuer: "someissuer", PHRNumber: "somelatephr", AuctionID: "someauction", CommitDeadline: "2021-12-09T10:00:00Z", RevealDeadline: "2021-12-10T10:00:00Z", State: REVEALING}
+	wsBid := &SealedBid{Issuer: "someissuer", PHRNumber: "somephr", AuctionID: "someauction", Bidder: "somebidder", Hash: HashBid("someissuer", "somephr", "somebidder", 150, "somesalt")}
+
+	var emptyAuction *Auction
+	var emptyBid *SealedBid
+	shouldError := false
+
+	mal.On("GetAuction", "someissuer", "somephr").Return(wsAuction, nil)
+	mal.On("GetAuction", "someissuer", "someearlyphr").Return(earlyAuction, nil)
+	mal.On("GetAuction", "someissuer", "somelatephr").Return(lateAuction, nil)
+	mal.On("GetAuction", "someotherissuer", "someotherphr").Return(emptyAuction, errors.New("GetAuction error"))
+	mal.On("GetSealedBid", "someissuer", "somephr", "someauction", "somebidder").Return(wsBid, nil)
+	mal.On("GetSealedBid", "someissuer", "somephr", "someauction", "someotherbidder").Return(emptyBid, &ledgerapi.StateNotFoundError{Key: "someissuer:somephr:someauction:someotherbidder"})
+	mal.On("UpdateSealedBid", mock.MatchedBy(func(bid *SealedBid) bool { return shouldError })).Return(errors.New("UpdateSealedBid error"))
+	mal.On("UpdateSealedBid", mock.MatchedBy(func(bid *SealedBid) bool { return !shouldError })).Return(nil)
+	mal.On("UpdateAuction", wsAuction).Return(nil)
+
+	bid, err = contract.RevealBid(ctx, "someotherissuer", "someotherphr", 150, "somesalt")
+	assert.EqualError(t, err, "GetAuction error", "should error when GetAuction errors")
+	assert.Nil(t, bid, "should not return bid when GetAuction errors")
+
+	bid, err = contract.RevealBid(ctx, "someissuer", "someearlyphr", 150, "somesalt")
+	assertCodedError(t, err, phrerror.InvalidState, "Auction of PHR someissuer:someearlyphr is not revealing bids. Reveal period = 2021-12-10T10:00:01Z to 2021-12-11T10:00:00Z", "should error before commit deadline")
+	assert.Nil(t, bid, "should not return bid before commit deadline")
+
+	bid, err = contract.RevealBid(ctx, "someissuer", "somelatephr", 150, "somesalt")
+	assertCodedError(t, err, phrerror.InvalidState, "Auction of PHR someissuer:somelatephr is not revealing bids. Reveal period = 2021-12-09T10:00:00Z to 2021-12-10T10:00:00Z", "should error at reveal deadline")
+	assert.Nil(t, bid, "should not return bid at reveal deadline")
+
+	otherCtx := newMockTransactionContext("someotherbidder")
+	otherCtx.auctionList = mal
+	bid, err = contract.RevealBid(otherCtx, "someissuer", "somephr", 150, "somesalt")
+	assertCodedError(t, err, phrerror.NotFound, "No state found for someissuer:somephr:someauction:someotherbidder", "should error when submitter committed no bid")
+	assert.Nil(t, bid, "should not return bid when submitter committed no bid")
+
+	bid, err = contract.RevealBid(ctx, "someissuer", "somephr", 160, "somesalt")
+	assertCodedError(t, err, phrerror.Validation, "Bid of somebidder in auction of PHR someissuer:somephr does not match its commitment", "should error when price does not match commitment")
+	assert.Nil(t, bid, "should not return bid when price does not match commitment")
+
+	bid, err = contract.RevealBid(ctx, "someissuer", "somephr", 150, "someothersalt")
+	assertCodedError(t, err, phrerror.Validation, "Bid of somebidder in auction of PHR someissuer:somephr does not match its commitment", "should error when salt does not match commitment")
+	assert.Nil(t, bid, "should not return bid when salt does not match commitment")
+
+	copyCtx := newMockTransactionContext("somecopybidder")
+	copyCtx.auctionList = mal
+	mal.On("GetSealedBid", "someissuer", "somephr", "someauction", "somecopybidder").Return(&SealedBid{Issuer: "someissuer", PHRNumber: "somephr", AuctionID: "someauction", Bidder: "somecopybidder", Hash: wsBid.Hash}, nil)
+	bid, err = contract.RevealBid(copyCtx, "someissuer", "somephr", 150, "somesalt")
+	assertCodedError(t, err, phrerror.Validation, "Bid of somecopybidder in auction of PHR someissuer:somephr does not match its commitment", "should error when commitment was copied from another bidder")
+	assert.Nil(t, bid, "should not return bid when commitment was copied from another bidder")
+
+	shouldError = true
+	bid, err = contract.RevealBid(ctx, "someissuer", "somephr", 150, "somesalt")
+	assert.EqualError(t, err, "UpdateSealedBid error", "should error when UpdateSealedBid errors")
+	assert.Nil(t, bid, "should not return bid when UpdateSealedBid errors")
+	shouldError = false
+	wsBid.RevealedDateTime = ""
+
+	bid, err = contract.RevealBid(ctx, "someissuer", "somephr", 150, "somesalt")
+	assert.Nil(t, err, "should not error on good reveal")
+	assert.Equal(t, &SealedBid{Issuer: "someissuer", PHRNumber: "somephr", AuctionID: "someauction", Bidder: "somebidder", Hash: HashBid("someissuer", "somephr", "somebidder", 150, "somesalt"), Price: 150, Salt: "somesalt", RevealedDateTime: "2021-12-10T10:00:00Z"}, bid, "should return revealed bid")
+	assert.Equal(t, REVEALING, wsAuction.State, "should move auction to revealing")
+	mal.AssertCalled(t, "UpdateAuction", wsAuction)
+	assert.Equal(t, []*PHREvent{{Version: EventVersion, Name: BidRevealedEvent, Issuer: "someissuer", PHRNumber: "somephr", PreviousOwner: "someowner", NewOwner: "somebidder", Price: 150, Timestamp: "2021-12-10T10:00:00Z"}}, getEvents(ctx), "should emit bid revealed event")
+
+	bid, err = contract.RevealBid(ctx, "someissuer", "somephr", 150, "somesalt")
+	assertCodedError(t, err, phrerror.InvalidState, "Bid of somebidder in auction of PHR someissuer:somephr is already revealed", "should error when bid already revealed")
+	assert.Nil(t, bid, "should not return bid when already revealed")
+}
+
+func TestCloseAuction(t *testing.T) {
+	var auction *Auction
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mpl := ctx.phrList
+	mtl := ctx.tradeList
+	mll := ctx.listingList
+	mal := ctx.auctionList
+	mtkl := ctx.tokenList
+
+	contract := new(Contract)
+
+	wsPHR := &PHR{Issuer: "someissuer", PHRNumber: "somephr"}
+	resetPHR(wsPHR)
+	unsoldPHR := &PHR{Issuer: "someissuer", PHRNumber: "someunsoldphr"}
+	resetPHR(unsoldPHR)
+
+	wsAuction := &Auction{Issuer: "someissuer", PHRNumber: "somephr", AuctionID: "someauction", Seller: "someowner", ReservePrice: 100, CommitDeadline: "2021-12-09T10:00:00Z", RevealDeadline: "2021-12-10T10:00:00Z", State: REVEALING}
+	unsoldAuction := &Auction{Issuer: "someissuer", PHRNumber: "someunsoldphr", AuctionID: "someauction", Seller: "someowner", ReservePrice: 100, CommitDeadline: "2021-12-09T10:00:00Z", RevealDeadline: "2021-12-10T10:00:00Z", State: COMMITTING}
+	openAuction := &Auction{Issuer: "someissuer", PHRNumber: "someopenphr", AuctionID: "someauction", Seller: "someowner", CommitDeadline: "2021-12-09T10:00:00Z", RevealDeadline: "2021-12-10T10:00:01Z", State: REVEALING}
+	closedAuction := &Auction{Issuer: "someissuer", PHRNumber: "someclosedphr", Seller: "someowner", State: CLOSED}
+
+	highBid := &SealedBid{Bidder: "somehighbidder", Price: 200, RevealedDateTime: "2021-12-09T12:00:00Z"}
+	lowBid := &SealedBid{Bidder: "somelowbidder", Price: 150, RevealedDateTime: "2021-12-09T12:00:00Z"}
+	sealedBid := &SealedBid{Bidder: "somesealedbidder", Hash: HashBid("someissuer", "somephr", "somesealedbidder", 500, "somesalt")}
+	cheapBid := &SealedBid{Bidder: "somecheapbidder", Price: 50, RevealedDateTime: "2021-12-09T12:00:00Z"}
+	brokeBid := &SealedBid{Bidder: "somebrokebidder", Price: 400, RevealedDateTime: "2021-12-09T12:00:00Z"}
+	brokePHR := &PHR{Issuer: "someissuer", PHRNumber: "somebrokephr"}
+	resetPHR(brokePHR)
+	brokeAuction := &Auction{Issuer: "someissuer", PHRNumber: "somebrokephr", AuctionID: "someauction", Seller: "someowner", ReservePrice: 100, CommitDeadline: "2021-12-09T10:00:00Z", RevealDeadline: "2021-12-10T10:00:00Z", State: REVEALING}
+
+	var emptyAuction *Auction
+	var sentTrade *Trade
+
+	mpl.On("GetPHR", "someissuer", "somephr").Return(wsPHR, nil)
+	mpl.On("GetPHR", "someissuer", "someunsoldphr").Return(unsoldPHR, nil)
+	mpl.On("GetPHR", "someissuer", "someopenphr").Return(unsoldPHR, nil)
+	mpl.On("UpdatePHR", wsPHR).Return(nil)
+	mpl.On("SetPHROwnerOrg", "someissuer", "somephr", "Org1MSP").Return(nil)
+	mal.On("GetAuction", "someissuer", "somephr").Return(wsAuction, nil)
+	mal.On("GetAuction", "someissuer", "someunsoldphr").Return(unsoldAuction, nil)
+	mal.On("GetAuction", "someissuer", "someopenphr").Return(openAuction, nil)
+	mal.On("GetAuction", "someissuer", "someclosedphr").Return(closedAuction, nil)
+	mal.On("GetAuction", "someotherissuer", "someotherphr").Return(emptyAuction, errors.New("GetAuction error"))
+	mal.On("GetSealedBids", "someissuer", "somephr", "someauction").Return([]*SealedBid{lowBid, sealedBid, brokeBid, highBid}, nil)
+	mpl.On("GetPHR", "someissuer", "somebrokephr").Return(brokePHR, nil)
+	mal.On("GetAuction", "someissuer", "somebrokephr").Return(brokeAuction, nil)
+	mal.On("GetSealedBids", "someissuer", "somebrokephr", "someauction").Return([]*SealedBid{brokeBid}, nil)
+	mal.On("GetSealedBids", "someissuer", "someunsoldphr", "someauction").Return([]*SealedBid{sealedBid, cheapBid}, nil)
+	mal.On("UpdateAuction", mock.Anything).Return(nil)
+	mll.On("DeleteListing", "someissuer", "somephr").Return(nil)
+	mtl.On("AddTrade", mock.MatchedBy(func(trade *Trade) bool { sentTrade = trade; return true })).Return(nil)
+	sellerBalance := &Balance{Owner: "someowner"}
+	winnerBalance := &Balance{Owner: "somehighbidder", Amount: 500}
+	mtkl.On("GetBalance", "someowner").Return(sellerBalance, nil)
+	mtkl.On("GetBalance", "somehighbidder").Return(winnerBalance, nil)
+	mtkl.On("GetBalance", "somebrokebidder").Return(&Balance{Owner: "somebrokebidder", Amount: 100}, nil)
+	mtkl.On("UpdateBalance", mock.Anything).Return(nil)
+
+	auction, err = contract.CloseAuction(ctx, "someotherissuer", "someotherphr")
+	assert.EqualError(t, err, "GetAuction error", "should error when GetAuction errors")
+	assert.Nil(t, auction, "should not return auction when GetAuction errors")
+
+	auction, err = contract.CloseAuction(ctx, "someissuer", "someclosedphr")
+	assertCodedError(t, err, phrerror.InvalidState, "Auction of PHR someissuer:someclosedphr is already closed", "should error when auction already closed")
+	assert.Nil(t, auction, "should not return auction when already closed")
+
+	otherCtx := newMockTransactionContext("someotherowner")
+	otherCtx.phrList = mpl
+	otherCtx.auctionList = mal
+	auction, err = contract.CloseAuction(otherCtx, "someissuer", "somephr")
+	assertCodedError(t, err, phrerror.NotOwner, "PHR someissuer:somephr is not owned by submitter someotherowner", "should error when submitter not seller")
+	assert.Nil(t, auction, "should not return auction when submitter not seller")
+
+	auction, err = contract.CloseAuction(ctx, "someissuer", "someopenphr")
+	assertCodedError(t, err, phrerror.InvalidState, "Auction of PHR someissuer:someopenphr cannot close before the reveal deadline 2021-12-10T10:00:01Z", "should error before reveal deadline")
+	assert.Nil(t, auction, "should not return auction before reveal deadline")
+
+	wsPHR.MaturityDateTime = "2021-12-10T10:00:00Z"
+	auction, err = contract.CloseAuction(ctx, "someissuer", "somephr")
+	assert.Nil(t, err, "should not error when phr matured")
+	assert.True(t, auction.IsClosed(), "should close auction when phr matured")
+	assert.Equal(t, "", auction.Winner, "should not set winner when phr matured")
+	assert.Equal(t, "someowner", wsPHR.Owner, "should keep owner when phr matured")
+	assert.Equal(t, []*PHREvent{{Version: EventVersion, Name: AuctionClosedEvent, Issuer: "someissuer", PHRNumber: "somephr", PreviousOwner: "someowner", NewOwner: "someowner", PreviousState: TRADING, NewState: TRADING, Timestamp: "2021-12-10T10:00:00Z"}}, getEvents(ctx), "should emit auction closed event when phr matured")
+	wsPHR.MaturityDateTime = ""
+	wsAuction.State = REVEALING
+
+	wsPHR.SetExpired()
+	auction, err = contract.CloseAuction(ctx, "someissuer", "somephr")
+	assert.Nil(t, err, "should not error when phr not trading")
+	assert.True(t, auction.IsClosed(), "should close auction when phr not trading")
+	assert.Equal(t, "", auction.Winner, "should not set winner when phr not trading")
+	assert.Equal(t, []*PHREvent{{Version: EventVersion, Name: AuctionClosedEvent, Issuer: "someissuer", PHRNumber: "somephr", PreviousOwner: "someowner", NewOwner: "someowner", PreviousState: EXPIRED, NewState: EXPIRED, Timestamp: "2021-12-10T10:00:00Z"}}, getEvents(ctx), "should emit auction closed event when phr not trading")
+	mtl.AssertNotCalled(t, "AddTrade", mock.Anything)
+	wsAuction.State = REVEALING
+	resetPHR(wsPHR)
+	wsPHR.SetIssued()
+
+	auction, err = contract.CloseAuction(ctx, "someissuer", "someunsoldphr")
+	assert.Nil(t, err, "should not error when no bid meets reserve price")
+	assert.True(t, auction.IsClosed(), "should close unsold auction")
+	assert.Equal(t, "", auction.Winner, "should not set winner of unsold auction")
+	assert.Equal(t, "someowner", unsoldPHR.Owner, "should keep owner of unsold phr")
+	assert.Equal(t, []*PHREvent{{Version: EventVersion, Name: AuctionClosedEvent, Issuer: "someissuer", PHRNumber: "someunsoldphr", PreviousOwner: "someowner", NewOwner: "someowner", PreviousState: TRADING, NewState: TRADING, Timestamp: "2021-12-10T10:00:00Z"}}, getEvents(ctx), "should emit auction closed event")
+
+	auction, err = contract.CloseAuction(ctx, "someissuer", "somebrokephr")
+	assert.Nil(t, err, "should not error when no bidder has the funds")
+	assert.True(t, auction.IsClosed(), "should close auction when no bidder has the funds")
+	assert.Equal(t, "", auction.Winner, "should not set winner without the funds")
+	assert.Equal(t, "someowner", brokePHR.Owner, "should keep owner when no bidder has the funds")
+	getEvents(ctx)
+
+	auction, err = contract.CloseAuction(ctx, "someissuer", "somephr")
+	assert.Nil(t, err, "should not error on good close")
+	assert.True(t, auction.IsClosed(), "should close auction")
+	assert.Equal(t, "somehighbidder", auction.Winner, "should set highest funded revealed bidder as winner")
+	assert.Equal(t, 200, auction.WinningPrice, "should set winning price")
+	assert.Equal(t, "somehighbidder", wsPHR.Owner, "should transfer phr to winner")
+	assert.True(t, wsPHR.IsTrading(), "should move issued phr to trading")
+	mal.AssertCalled(t, "UpdateAuction", wsAuction)
+	mpl.AssertCalled(t, "SetPHROwnerOrg", "someissuer", "somephr", "Org1MSP")
+	mll.AssertCalled(t, "DeleteListing", "someissuer", "somephr")
+	assert.Equal(t, 300, winnerBalance.Amount, "should debit the winning price from the winner")
+	assert.Equal(t, 200, sellerBalance.Amount, "should credit the winning price to the seller")
+	assert.Equal(t, &Trade{Issuer: "someissuer", PHRNumber: "somephr", Seller: "someowner", Buyer: "somehighbidder", Price: 200, TxID: "sometx", Timestamp: "2021-12-10T10:00:00Z"}, sentTrade, "should record trade at winning price")
+	assert.Equal(t, []*PHREvent{{Version: EventVersion, Name: TradedEvent, Issuer: "someissuer", PHRNumber: "somephr", PreviousOwner: "someowner", NewOwner: "somehighbidder", Price: 200, PreviousState: ISSUED, NewState: TRADING, Timestamp: "2021-12-10T10:00:00Z"}}, getEvents(ctx), "should emit traded event")
+
+	consentPHR := &PHR{Issuer: "someissuer", PHRNumber: "someconsentphr", PatientHash: "somepatienthash"}
+	resetPHR(consentPHR)
+	consentAuction := &Auction{Issuer: "someissuer", PHRNumber: "someconsentphr", AuctionID: "someauction", Seller: "someowner", ReservePrice: 100, CommitDeadline: "2021-12-09T10:00:00Z", RevealDeadline: "2021-12-10T10:00:00Z", State: REVEALING}
+	marketingBid := &SealedBid{Bidder: "somehighbidder", Price: 300, Purpose: "marketing", RevealedDateTime: "2021-12-09T12:00:00Z"}
+	researchBid := &SealedBid{Bidder: "somelowbidder", Price: 150, Purpose: "research", RevealedDateTime: "2021-12-09T12:00:00Z"}
+	mpl.On("GetPHR", "someissuer", "someconsentphr").Return(consentPHR, nil)
+	mpl.On("UpdatePHR", consentPHR).Return(nil)
+	mpl.On("SetPHROwnerOrg", "someissuer", "someconsentphr", "Org1MSP").Return(nil)
+	mal.On("GetAuction", "someissuer", "someconsentphr").Return(consentAuction, nil)
+	mal.On("GetSealedBids", "someissuer", "someconsentphr", "someauction").Return([]*SealedBid{marketingBid, researchBid}, nil)
+	mll.On("DeleteListing", "someissuer", "someconsentphr").Return(nil)
+	ctx.consentList.On("GetConsent", "someissuer", "someconsentphr").Return(&Consent{Issuer: "someissuer", PHRNumber: "someconsentphr", Purposes: []string{"research"}, BuyerMSPs: []string{"Org1MSP"}, ValidUntil: "2022-12-10T10:00:00Z"}, nil)
+	ctx.royaltyList.On("GetRoyaltyRate").Return(&RoyaltyRate{}, nil)
+	mtkl.On("GetBalance", "somelowbidder").Return(&Balance{Owner: "somelowbidder", Amount: 500}, nil)
+
+	auction, err = contract.CloseAuction(ctx, "someissuer", "someconsentphr")
+	assert.Nil(t, err, "should not error when highest bid is not covered by consent")
+	assert.Equal(t, "somelowbidder", auction.Winner, "should pass over bid whose purpose consent does not cover")
+	assert.Equal(t, 150, auction.WinningPrice, "should set price of bid covered by consent")
+	assert.Equal(t, "somelowbidder", consentPHR.Owner, "should transfer phr to bidder covered by consent")
+}
+
+func TestGetAuction(t *testing.T) {
+	ctx := newMockTransactionContext("someowner")
+	mal := ctx.auctionList
+
+	contract := new(Contract)
+
+	wsAuction := &Auction{Issuer: "someissuer", PHRNumber: "somephr", AuctionID: "someauction"}
+	mal.On("GetAuction", "someissuer", "somephr").Return(wsAuction, nil)
+
+	auction, err := contract.GetAuction(ctx, "someissuer", "somephr")
+	assert.Nil(t, err, "should not error when GetAuction does not error")
+	assert.Equal(t, wsAuction, auction, "should return auction from auction list")
+}
+
+func TestGetSealedBids(t *testing.T) {
+	var bids []*SealedBid
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mal := ctx.auctionList
+
+	contract := new(Contract)
+
+	wsBids := []*SealedBid{{Bidder: "somebidder", Hash: "somehash"}}
+	var emptyAuction *Auction
+	mal.On("GetAuction", "someissuer", "somephr").Return(&Auction{AuctionID: "someauction"}, nil)
+	mal.On("GetAuction", "someotherissuer", "someotherphr").Return(emptyAuction, errors.New("GetAuction error"))
+	mal.On("GetSealedBids", "someissuer", "somephr", "someauction").Return(wsBids, nil)
+
+	bids, err = contract.GetSealedBids(ctx, "someotherissuer", "someotherphr")
+	assert.EqualError(t, err, "GetAuction error", "should error when GetAuction errors")
+	assert.Nil(t, bids, "should not return bids when GetAuction errors")
+
+	bids, err = contract.GetSealedBids(ctx, "someissuer", "somephr")
+	assert.Nil(t, err, "should not error when GetSealedBids does not error")
+	assert.Equal(t, wsBids, bids, "should return sealed bids of latest auction")
+}
@@ -0,0 +1,119 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+
+// AuctionListInterface defines functionality needed
+// to interact with the world state on behalf
+// of an auction and its sealed bids
+type AuctionListInterface interface {
+	GetAuction(string, string) (*Auction, error)
+	UpdateAuction(*Auction) error
+	GetSealedBid(string, string, string, string) (*SealedBid, error)
+	UpdateSealedBid(*SealedBid) error
+	GetSealedBids(string, string, string) ([]*SealedBid, error)
+}
+
+// auctionListName namespace of auctions in world state
+const auctionListName = "org.phrnet.auctionlist"
+
+// sealedBidListName namespace of sealed bids in world state
+const sealedBidListName = "org.phrnet.sealedbidlist"
+
+type auctionList struct {
+	stateList    ledgerapi.StateListInterface
+	bidStateList ledgerapi.StateListInterface
+}
+
+// GetAuction returns the latest auction of a phr. Returns a
+// ledgerapi.StateNotFoundError when the phr was never auctioned
+func (al *auctionList) GetAuction(issuer string, phrNumber string) (*Auction, error) {
+	auction := new(Auction)
+
+	err := al.stateList.GetState(CreatePHRKey(issuer, phrNumber), auction)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return auction, nil
+}
+
+// UpdateAuction puts the auction, replacing any
+// earlier auction of the phr
+func (al *auctionList) UpdateAuction(auction *Auction) error {
+	return al.stateList.UpdateState(auction)
+}
+
+// GetSealedBid returns the sealed bid of a bidder in an auction. Returns
+// a ledgerapi.StateNotFoundError when the bidder has not committed a bid
+func (al *auctionList) GetSealedBid(issuer string, phrNumber string, auctionID string, bidder string) (*SealedBid, error) {
+	bid := new(SealedBid)
+
+	err := al.bidStateList.GetState(ledgerapi.MakeKey(issuer, phrNumber, auctionID, bidder), bid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bid, nil
+}
+
+// UpdateSealedBid puts the sealed bid, replacing any earlier
+// commitment of the bidder in the auction
+func (al *auctionList) UpdateSealedBid(bid *SealedBid) error {
+	return al.bidStateList.UpdateState(bid)
+}
+
+// GetSealedBids returns the sealed bids committed in an auction
+func (al *auctionList) GetSealedBids(issuer string, phrNumber string, auctionID string) ([]*SealedBid, error) {
+	iterator, err := al.bidStateList.GetStatesByPartialKey(ledgerapi.MakeKey(issuer, phrNumber, auctionID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer iterator.Close()
+
+	bids := []*SealedBid{}
+
+	for iterator.HasNext() {
+		bid := new(SealedBid)
+
+		err := iterator.Next(bid)
+
+		if err != nil {
+			return nil, err
+		}
+
+		bids = append(bids, bid)
+	}
+
+	return bids, nil
+}
+
+// newAuctionList create a new auction list from context
+func newAuctionList(ctx TransactionContextInterface) *auctionList {
+	stateList := new(ledgerapi.StateList)
+	stateList.Ctx = ctx
+	stateList.Name = auctionListName
+	stateList.Deserialize = func(bytes []byte, state ledgerapi.StateInterface) error {
+		return DeserializeAuction(bytes, state.(*Auction))
+	}
+
+	bidStateList := new(ledgerapi.StateList)
+	bidStateList.Ctx = ctx
+	bidStateList.Name = sealedBidListName
+	bidStateList.Deserialize = func(bytes []byte, state ledgerapi.StateInterface) error {
+		return DeserializeSealedBid(bytes, state.(*SealedBid))
+	}
+
+	list := new(auctionList)
+	list.stateList = stateList
+	list.bidStateList = bidStateList
+
+	return list
+}
@@ -0,0 +1,162 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"errors"
+	"testing"
+
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// #########
+// HELPERS
+// #########
+
+type MockSealedBidIterator struct {
+	prices []int
+	err    error
+	closed bool
+}
+
+func (msbi *MockSealedBidIterator) HasNext() bool {
+	return len(msbi.prices) > 0
+}
+
+func (msbi *MockSealedBidIterator) Next(state ledgerapi.StateInterface) error {
+	if msbi.err != nil {
+		return msbi.err
+	}
+
+	state.(*SealedBid).Price = msbi.prices[0]
+	msbi.prices = msbi.prices[1:]
+
+	return nil
+}
+
+func (msbi *MockSealedBidIterator) Close() error {
+	msbi.closed = true
+
+	return nil
+}
+
+// #########
+// TESTS
+// #########
+
+func TestGetAuctionFromList(t *testing.T) {
+	var auction *Auction
+	var err error
+
+	list := new(auctionList)
+	msl := new(MockStateList)
+	msl.On("GetState", CreatePHRKey("someissuer", "somephr"), mock.MatchedBy(func(state ledgerapi.StateInterface) bool { _, ok := state.(*Auction); return ok })).Return(nil)
+	msl.On("GetState", CreatePHRKey("someotherissuer", "someotherphr"), mock.MatchedBy(func(state ledgerapi.StateInterface) bool { _, ok := state.(*Auction); return ok })).Return(errors.New("GetState error"))
+	list.stateList = msl
+
+	auction, err = list.GetAuction("someissuer", "somephr")
+	assert.Nil(t, err, "should not error when get state on state list does not error")
+	assert.Equal(t, "somephr", auction.PHRNumber, "should use state list GetState to fill auction")
+
+	auction, err = list.GetAuction("someotherissuer", "someotherphr")
+	assert.EqualError(t, err, "GetState error", "should return error when state list get state errors")
+	assert.Nil(t, auction, "should not return auction on error")
+}
+
+func TestUpdateAuction(t *testing.T) {
+	auction := new(Auction)
+
+	list := new(auctionList)
+	msl := new(MockStateList)
+	msl.On("UpdateState", auction).Return(errors.New("Called update state correctly"))
+	list.stateList = msl
+
+	err := list.UpdateAuction(auction)
+	assert.EqualError(t, err, "Called update state correctly", "should call state list update state with auction")
+}
+
+func TestGetSealedBidFromList(t *testing.T) {
+	var bid *SealedBid
+	var err error
+
+	list := new(auctionList)
+	msl := new(MockStateList)
+	msl.On("GetState", "someissuer:somephr:sometx:somebidder", mock.MatchedBy(func(state ledgerapi.StateInterface) bool { _, ok := state.(*SealedBid); return ok })).Return(nil)
+	msl.On("GetState", "someotherissuer:someotherphr:sometx:somebidder", mock.MatchedBy(func(state ledgerapi.StateInterface) bool { _, ok := state.(*SealedBid); return ok })).Return(errors.New("GetState error"))
+	list.bidStateList = msl
+
+	bid, err = list.GetSealedBid("someissuer", "somephr", "sometx", "somebidder")
+	assert.Nil(t, err, "should not error when get state on sealed bid state list does not error")
+	assert.Equal(t, "somephr", bid.PHRNumber, "should use sealed bid state list GetState to fill sealed bid")
+
+	bid, err = list.GetSealedBid("someotherissuer", "someotherphr", "sometx", "somebidder")
+	assert.EqualError(t, err, "GetState error", "should return error when sealed bid state list get state errors")
+	assert.Nil(t, bid, "should not return sealed bid on error")
+}
+
+func TestUpdateSealedBid(t *testing.T) {
+	bid := new(SealedBid)
+
+	list := new(auctionList)
+	msl := new(MockStateList)
+	msl.On("UpdateState", bid).Return(errors.New("Called update state correctly"))
+	list.bidStateList = msl
+
+	err := list.UpdateSealedBid(bid)
+	assert.EqualError(t, err, "Called update state correctly", "should call sealed bid state list update state with sealed bid")
+}
+
+func TestGetSealedBidsFromList(t *testing.T) {
+	var bids []*SealedBid
+	var err error
+
+	list := new(auctionList)
+	msl := new(MockStateList)
+	goodIterator := &MockSealedBidIterator{prices: []int{100, 200}}
+	badIterator := &MockSealedBidIterator{prices: []int{100}, err: errors.New("Next error")}
+	var emptyIterator *MockSealedBidIterator
+	msl.On("GetStatesByPartialKey", "someissuer:somephr:sometx").Return(goodIterator, nil)
+	msl.On("GetStatesByPartialKey", "someissuer:somebadphr:sometx").Return(badIterator, nil)
+	msl.On("GetStatesByPartialKey", "someotherissuer:someotherphr:sometx").Return(emptyIterator, errors.New("GetStatesByPartialKey error"))
+	list.bidStateList = msl
+
+	bids, err = list.GetSealedBids("someotherissuer", "someotherphr", "sometx")
+	assert.EqualError(t, err, "GetStatesByPartialKey error", "should return error when state list get states errors")
+	assert.Nil(t, bids, "should not return sealed bids on error")
+
+	bids, err = list.GetSealedBids("someissuer", "somebadphr", "sometx")
+	assert.EqualError(t, err, "Next error", "should return error when iterator errors")
+	assert.Nil(t, bids, "should not return sealed bids on iterator error")
+	assert.True(t, badIterator.closed, "should close iterator on error")
+
+	bids, err = list.GetSealedBids("someissuer", "somephr", "sometx")
+	assert.Nil(t, err, "should not error when state list get states does not error")
+	assert.Equal(t, []*SealedBid{{Price: 100}, {Price: 200}}, bids, "should read every sealed bid from iterator")
+	assert.True(t, goodIterator.closed, "should close iterator when done")
+}
+
+func TestNewAuctionList(t *testing.T) {
+	ctx := new(TransactionContext)
+	list := newAuctionList(ctx)
+	stateList, ok := list.stateList.(*ledgerapi.StateList)
+	bidStateList, bidOk := list.bidStateList.(*ledgerapi.StateList)
+
+	assert.True(t, ok, "should make statelist of type ledgerapi.StateList")
+	assert.True(t, bidOk, "should make sealed bid statelist of type ledgerapi.StateList")
+	assert.Equal(t, ctx, stateList.Ctx, "should set the context to passed context")
+	assert.Equal(t, ctx, bidStateList.Ctx, "should set the sealed bid context to passed context")
+	assert.Equal(t, "org.phrnet.auctionlist", stateList.Name, "should set the name for the list")
+	assert.Equal(t, "org.phrnet.sealedbidlist", bidStateList.Name, "should set the name for the sealed bid list")
+
+	expectedErr := DeserializeAuction([]byte("bad json"), new(Auction))
+	err := stateList.Deserialize([]byte("bad json"), new(Auction))
+	assert.EqualError(t, err, expectedErr.Error(), "should call DeserializeAuction when stateList.Deserialize called")
+
+	expectedErr = DeserializeSealedBid([]byte("bad json"), new(SealedBid))
+	err = bidStateList.Deserialize([]byte("bad json"), new(SealedBid))
+	assert.EqualError(t, err, expectedErr.Error(), "should call DeserializeSealedBid when sealed bid stateList.Deserialize called")
+}
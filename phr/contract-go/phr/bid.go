@@ -0,0 +1,102 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// BidState enum for bid state property
+type BidState uint
+
+const (
+	// OPEN state for when a bid can be accepted
+	OPEN BidState = iota + 1
+	// ACCEPTED state for when a bid has been accepted by the owner
+	ACCEPTED
+	// CANCELLED state for when a bid has been withdrawn by the bidder
+	CANCELLED
+)
+
+func (state BidState) String() string {
+	names := []string{"OPEN", "ACCEPTED", "CANCELLED"}
+
+	if state < OPEN || state > CANCELLED {
+		return "UNKNOWN"
+	}
+
+	return names[state-1]
+}
+
+// Bid defines a standing offer to buy a phr at a price.
+// A bidder has at most one bid per phr
+type Bid struct {
+	Issuer         string   `json:"issuer"`
+	PHRNumber      string   `json:"phrNumber"`
+	Bidder         string   `json:"bidder"`
+	Price          int      `json:"price"`
+	PlacedDateTime string   `json:"placedDateTime"`
+	ExpiresAt      string   `json:"expiresAt"`
+	Purpose        string   `json:"purpose,omitempty" metadata:"purpose,optional"`
+	State          BidState `json:"state"`
+}
+
+// IsOpen returns true if the bid has not been accepted or cancelled
+func (bid *Bid) IsOpen() bool {
+	return bid.State == OPEN
+}
+
+// IsActive returns true if the bid is open and
+// has not expired at the passed time
+func (bid *Bid) IsActive(t time.Time) bool {
+	return bid.IsOpen() && bid.ExpiresAt > FormatDateTime(t)
+}
+
+// GetSplitKey returns values which should be used to form key
+func (bid *Bid) GetSplitKey() []string {
+	return []string{bid.Issuer, bid.PHRNumber, bid.Bidder}
+}
+
+// Serialize formats the bid as JSON bytes
+func (bid *Bid) Serialize() ([]byte, error) {
+	return json.Marshal(bid)
+}
+
+// DeserializeBid formats the bid from JSON bytes
+func DeserializeBid(bytes []byte, bid *Bid) error {
+	err := json.Unmarshal(bytes, bid)
+
+	if err != nil {
+		return fmt.Errorf("Error deserializing bid. %s", err.Error())
+	}
+
+	return nil
+}
+
+// RankBids returns the active bids at the passed time, ignoring bids
+// by the owner, highest price first. The earliest placed bid ranks
+// first on a tie
+func RankBids(bids []*Bid, owner string, t time.Time) []*Bid {
+	ranked := []*Bid{}
+
+	for _, bid := range bids {
+		if bid.Bidder != owner && bid.IsActive(t) {
+			ranked = append(ranked, bid)
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Price != ranked[j].Price {
+			return ranked[i].Price > ranked[j].Price
+		}
+
+		return ranked[i].PlacedDateTime < ranked[j].PlacedDateTime
+	})
+
+	return ranked
+}
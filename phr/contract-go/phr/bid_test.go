@@ -0,0 +1,73 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBidStateString(t *testing.T) {
+	assert.Equal(t, "OPEN", OPEN.String(), "should return string for open")
+	assert.Equal(t, "ACCEPTED", ACCEPTED.String(), "should return string for accepted")
+	assert.Equal(t, "CANCELLED", CANCELLED.String(), "should return string for cancelled")
+	assert.Equal(t, "UNKNOWN", BidState(CANCELLED+1).String(), "should return unknown when not one of constants")
+}
+
+func TestBidIsActive(t *testing.T) {
+	bid := &Bid{State: OPEN, ExpiresAt: "2021-12-11T10:00:00Z"}
+
+	assert.True(t, bid.IsOpen(), "should be open when state is open")
+	assert.True(t, bid.IsActive(mockTxTime), "should be active when open before expiry")
+	assert.False(t, bid.IsActive(mockTxTime.Add(24*time.Hour)), "should not be active at expiry")
+
+	bid.State = CANCELLED
+	assert.False(t, bid.IsOpen(), "should not be open when cancelled")
+	assert.False(t, bid.IsActive(mockTxTime), "should not be active when cancelled")
+}
+
+func TestBidGetSplitKey(t *testing.T) {
+	bid := &Bid{Issuer: "someissuer", PHRNumber: "somephr", Bidder: "somebidder"}
+
+	assert.Equal(t, []string{"someissuer", "somephr", "somebidder"}, bid.GetSplitKey(), "should return issuer, phr number and bidder as split key")
+}
+
+func TestBidSerialize(t *testing.T) {
+	bid := &Bid{Issuer: "someissuer", PHRNumber: "somephr", Bidder: "somebidder", Price: 100, PlacedDateTime: "2021-12-10T10:00:00Z", ExpiresAt: "2021-12-11T10:00:00Z", State: OPEN}
+
+	bytes, err := bid.Serialize()
+	assert.Nil(t, err, "should not error on serialize")
+	assert.Equal(t, `{"issuer":"someissuer","phrNumber":"somephr","bidder":"somebidder","price":100,"placedDateTime":"2021-12-10T10:00:00Z","expiresAt":"2021-12-11T10:00:00Z","state":1}`, string(bytes), "should return JSON formatted value")
+}
+
+func TestDeserializeBid(t *testing.T) {
+	var bid *Bid
+	var err error
+
+	bid = new(Bid)
+	err = DeserializeBid([]byte(`{"issuer":"someissuer","phrNumber":"somephr","bidder":"somebidder","price":100,"state":2}`), bid)
+	assert.Nil(t, err, "should not return error for deserialize")
+	assert.Equal(t, &Bid{Issuer: "someissuer", PHRNumber: "somephr", Bidder: "somebidder", Price: 100, State: ACCEPTED}, bid, "should create expected bid")
+
+	bid = new(Bid)
+	err = DeserializeBid([]byte(`{"price":"NaN"}`), bid)
+	assert.EqualError(t, err, "Error deserializing bid. json: cannot unmarshal string into Go struct field Bid.price of type int", "should return error for bad data")
+}
+
+func TestRankBids(t *testing.T) {
+	early := &Bid{Bidder: "someearlybidder", Price: 200, PlacedDateTime: "2021-12-09T10:00:00Z", ExpiresAt: "2021-12-11T10:00:00Z", State: OPEN}
+	late := &Bid{Bidder: "somelatebidder", Price: 200, PlacedDateTime: "2021-12-10T09:00:00Z", ExpiresAt: "2021-12-11T10:00:00Z", State: OPEN}
+	low := &Bid{Bidder: "somelowbidder", Price: 100, PlacedDateTime: "2021-12-08T10:00:00Z", ExpiresAt: "2021-12-11T10:00:00Z", State: OPEN}
+	expired := &Bid{Bidder: "someexpiredbidder", Price: 500, ExpiresAt: "2021-12-10T10:00:00Z", State: OPEN}
+	cancelled := &Bid{Bidder: "somecancelledbidder", Price: 500, ExpiresAt: "2021-12-11T10:00:00Z", State: CANCELLED}
+	own := &Bid{Bidder: "someowner", Price: 500, ExpiresAt: "2021-12-11T10:00:00Z", State: OPEN}
+
+	assert.Empty(t, RankBids([]*Bid{}, "someowner", mockTxTime), "should return no bids when no bids")
+	assert.Empty(t, RankBids([]*Bid{expired, cancelled, own}, "someowner", mockTxTime), "should return no bids when no active bids by others")
+	assert.Equal(t, []*Bid{low}, RankBids([]*Bid{expired, low, own}, "someowner", mockTxTime), "should ignore inactive bids and bids by owner")
+	assert.Equal(t, []*Bid{early, late, low}, RankBids([]*Bid{low, late, early, cancelled}, "someowner", mockTxTime), "should rank highest price placed earliest first")
+}
@@ -0,0 +1,222 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+)
+
+// PlaceBid offers to buy a phr at the price until expiresAt. A bid on
+// a phr naming a patient states the purpose of use the same as Buy.
+// Replaces any earlier bid of the submitter on the phr
+func (c *Contract) PlaceBid(ctx TransactionContextInterface, issuer string, phrNumber string, price int, expiresAt string, purpose string) (*Bid, error) {
+	expiresAt, err := parseDateTime("expiresAt", expiresAt, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if price <= 0 {
+		return nil, &ValidationError{Field: "price", Value: fmt.Sprint(price), Reason: "Must be greater than zero"}
+	}
+
+	phr, err := ctx.GetPHRList().GetPHR(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := ctx.GetCaller()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if phr.Owner == caller {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s is already owned by submitter %s", issuer, phrNumber, caller)
+	}
+
+	if phr.NeedsConsent() && purpose == "" {
+		return nil, &ValidationError{Field: "purpose", Value: purpose, Reason: "Value is required to bid on a phr naming a patient"}
+	}
+
+	if phr.IsExpired() {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s is already expired", issuer, phrNumber)
+	}
+
+	txTime, err := getTxTime(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if phr.IsMatured(txTime) {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s has matured. Maturity date time = %s", issuer, phrNumber, phr.MaturityDateTime)
+	}
+
+	placedDateTime := FormatDateTime(txTime)
+
+	if expiresAt <= placedDateTime {
+		return nil, &ValidationError{Field: "expiresAt", Value: expiresAt, Reason: "Must be after the placed date time " + placedDateTime}
+	}
+
+	bid := Bid{Issuer: issuer, PHRNumber: phrNumber, Bidder: caller, Price: price, PlacedDateTime: placedDateTime, ExpiresAt: expiresAt, Purpose: purpose, State: OPEN}
+
+	err = ctx.GetBidList().UpdateBid(&bid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = setEvent(ctx, &PHREvent{Name: BidPlacedEvent, Issuer: issuer, PHRNumber: phrNumber, PreviousOwner: phr.Owner, NewOwner: caller, Price: price, PreviousState: phr.GetState(), NewState: phr.GetState()})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &bid, nil
+}
+
+// CancelBid withdraws the open bid of the submitter on a phr
+func (c *Contract) CancelBid(ctx TransactionContextInterface, issuer string, phrNumber string) (*Bid, error) {
+	caller, err := ctx.GetCaller()
+
+	if err != nil {
+		return nil, err
+	}
+
+	bid, err := ctx.GetBidList().GetBid(issuer, phrNumber, caller)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !bid.IsOpen() {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "Bid of %s on PHR %s:%s is not open. Current state = %s", caller, issuer, phrNumber, bid.State)
+	}
+
+	bid.State = CANCELLED
+
+	err = ctx.GetBidList().UpdateBid(bid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = setEvent(ctx, &PHREvent{Name: BidCancelledEvent, Issuer: issuer, PHRNumber: phrNumber, NewOwner: caller, Price: bid.Price})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bid, nil
+}
+
+// AcceptBid sells a phr to its best active bid, transferring ownership
+// the same way as Buy. Bids of bidders who cannot receive the phr or pay
+// the price are passed over. Submitted by the owner
+func (c *Contract) AcceptBid(ctx TransactionContextInterface, issuer string, phrNumber string) (*PHR, error) {
+	phr, err := ctx.GetPHRList().GetPHR(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := ctx.GetCaller()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if phr.Owner != caller {
+		return nil, phrerror.Errorf(phrerror.NotOwner, "PHR %s:%s is not owned by submitter %s", issuer, phrNumber, caller)
+	}
+
+	previousState := phr.GetState()
+
+	if phr.IsIssued() {
+		phr.SetTrading()
+	}
+
+	if !phr.IsTrading() {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s is not trading. Current state = %s", issuer, phrNumber, phr.GetState())
+	}
+
+	txTime, err := getTxTime(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if phr.IsMatured(txTime) {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s has matured. Maturity date time = %s", issuer, phrNumber, phr.MaturityDateTime)
+	}
+
+	bids, err := ctx.GetBidList().GetBids(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := RankBids(bids, phr.Owner, txTime)
+
+	if len(ranked) == 0 {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s has no active bids", issuer, phrNumber)
+	}
+
+	var bid *Bid
+
+	for _, candidate := range ranked {
+		err = checkBid(ctx, phr, candidate.Bidder, candidate.Price, candidate.Purpose, txTime)
+
+		if err == nil {
+			bid = candidate
+			break
+		} else if phrerror.CodeOf(err) == "" {
+			return nil, err
+		}
+	}
+
+	if bid == nil {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s has no active bids whose bidder can buy it and pay", issuer, phrNumber)
+	}
+
+	bid.State = ACCEPTED
+
+	err = ctx.GetBidList().UpdateBid(bid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = transfer(ctx, phr, bid.Bidder, bid.Price, "", previousState, txTime)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return phr, nil
+}
+
+// GetBids returns the bids on a phr in any state
+func (c *Contract) GetBids(ctx TransactionContextInterface, issuer string, phrNumber string) ([]*Bid, error) {
+	return ctx.GetBidList().GetBids(issuer, phrNumber)
+}
+
+// checkBid checks the bidder of a bid on a phr can receive the phr as
+// checkBuyer does and has the funds to pay the price. AcceptBid and
+// CloseAuction pass over bids failing with an error of the catalogue
+func checkBid(ctx TransactionContextInterface, phr *PHR, bidder string, price int, purpose string, txTime time.Time) error {
+	err := checkBuyer(ctx, phr, bidder, purpose, txTime)
+
+	if err != nil {
+		return err
+	}
+
+	return checkFunds(ctx, bidder, price)
+}
@@ -0,0 +1,268 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"errors"
+	"testing"
+
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPlaceBid(t *testing.T) {
+	var bid *Bid
+	var err error
+
+	ctx := newMockTransactionContext("somebidder")
+	mpl := ctx.phrList
+	mbl := ctx.bidList
+
+	contract := new(Contract)
+
+	wsPHR := new(PHR)
+	resetPHR(wsPHR)
+	wsPHR.MaturityDateTime = "2022-12-10T10:00:00Z"
+
+	var emptyPHR *PHR
+	var sentBid *Bid
+	shouldError := false
+
+	mpl.On("GetPHR", "someissuer", "somephr").Return(wsPHR, nil)
+	mpl.On("GetPHR", "someotherissuer", "someotherphr").Return(emptyPHR, errors.New("GetPHR error"))
+	mbl.On("UpdateBid", mock.MatchedBy(func(bid *Bid) bool { return shouldError })).Return(errors.New("UpdateBid error"))
+	mbl.On("UpdateBid", mock.MatchedBy(func(bid *Bid) bool { sentBid = bid; return !shouldError })).Return(nil)
+
+	bid, err = contract.PlaceBid(ctx, "someissuer", "somephr", 100, "2021-12-11", "")
+	assertCodedError(t, err, phrerror.Validation, `Invalid expiresAt "2021-12-11". Expected an RFC 3339 date time`, "should error when expires at does not parse")
+	assert.Nil(t, bid, "should not return bid when expires at does not parse")
+
+	bid, err = contract.PlaceBid(ctx, "someissuer", "somephr", -1, "2021-12-11T10:00:00Z", "")
+	assertCodedError(t, err, phrerror.Validation, `Invalid price "-1". Must be greater than zero`, "should error when price not positive")
+	assert.Nil(t, bid, "should not return bid when price not positive")
+
+	bid, err = contract.PlaceBid(ctx, "someotherissuer", "someotherphr", 100, "2021-12-11T10:00:00Z", "")
+	assert.EqualError(t, err, "GetPHR error", "should error when GetPHR errors")
+	assert.Nil(t, bid, "should not return bid when GetPHR errors")
+
+	ownerCtx := newMockTransactionContext("someowner")
+	ownerCtx.phrList = mpl
+	bid, err = contract.PlaceBid(ownerCtx, "someissuer", "somephr", 100, "2021-12-11T10:00:00Z", "")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:somephr is already owned by submitter someowner", "should error when owner bids")
+	assert.Nil(t, bid, "should not return bid when owner bids")
+
+	wsPHR.PatientHash = "somepatienthash"
+	bid, err = contract.PlaceBid(ctx, "someissuer", "somephr", 100, "2021-12-11T10:00:00Z", "")
+	assertCodedError(t, err, phrerror.Validation, `Invalid purpose "". Value is required to bid on a phr naming a patient`, "should error when purpose missing on phr naming a patient")
+	assert.Nil(t, bid, "should not return bid when purpose missing on phr naming a patient")
+	wsPHR.PatientHash = ""
+
+	wsPHR.SetExpired()
+	bid, err = contract.PlaceBid(ctx, "someissuer", "somephr", 100, "2021-12-11T10:00:00Z", "")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:somephr is already expired", "should error when phr expired")
+	assert.Nil(t, bid, "should not return bid when phr expired")
+	resetPHR(wsPHR)
+
+	wsPHR.MaturityDateTime = "2021-12-10T10:00:00Z"
+	bid, err = contract.PlaceBid(ctx, "someissuer", "somephr", 100, "2021-12-11T10:00:00Z", "")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:somephr has matured. Maturity date time = 2021-12-10T10:00:00Z", "should error when phr matured")
+	assert.Nil(t, bid, "should not return bid when phr matured")
+	wsPHR.MaturityDateTime = "2022-12-10T10:00:00Z"
+
+	bid, err = contract.PlaceBid(ctx, "someissuer", "somephr", 100, "2021-12-10T09:00:00Z", "")
+	assertCodedError(t, err, phrerror.Validation, `Invalid expiresAt "2021-12-10T09:00:00Z". Must be after the placed date time 2021-12-10T10:00:00Z`, "should error when expires at not after tx time")
+	assert.Nil(t, bid, "should not return bid when expires at not after tx time")
+
+	shouldError = true
+	bid, err = contract.PlaceBid(ctx, "someissuer", "somephr", 100, "2021-12-11T10:00:00Z", "")
+	assert.EqualError(t, err, "UpdateBid error", "should error when UpdateBid errors")
+	assert.Nil(t, bid, "should not return bid when UpdateBid errors")
+	shouldError = false
+
+	wsPHR.PatientHash = "somepatienthash"
+	wsPHR.PrivateDetailsHash = "somehash"
+	bid, err = contract.PlaceBid(ctx, "someissuer", "somephr", 100, "2021-12-11T10:00:00Z", "research")
+	assert.Nil(t, err, "should not error on good bid")
+	assert.Equal(t, &Bid{Issuer: "someissuer", PHRNumber: "somephr", Bidder: "somebidder", Price: 100, PlacedDateTime: "2021-12-10T10:00:00Z", ExpiresAt: "2021-12-11T10:00:00Z", Purpose: "research", State: OPEN}, bid, "should return open bid by submitter with its purpose")
+	assert.Equal(t, sentBid, bid, "should put same bid as it returns in the world state")
+	assert.Equal(t, []*PHREvent{{Version: EventVersion, Name: BidPlacedEvent, Issuer: "someissuer", PHRNumber: "somephr", PreviousOwner: "someowner", NewOwner: "somebidder", Price: 100, PreviousState: TRADING, NewState: TRADING, Timestamp: "2021-12-10T10:00:00Z"}}, getEvents(ctx), "should emit bid placed event")
+}
+
+func TestCancelBid(t *testing.T) {
+	var bid *Bid
+	var err error
+
+	ctx := newMockTransactionContext("somebidder")
+	mbl := ctx.bidList
+
+	contract := new(Contract)
+
+	wsBid := &Bid{Issuer: "someissuer", PHRNumber: "somephr", Bidder: "somebidder", Price: 100, State: OPEN}
+	badBid := &Bid{Issuer: "someissuer", PHRNumber: "somebadphr", Bidder: "somebidder", Price: 100, State: OPEN}
+	acceptedBid := &Bid{Issuer: "someissuer", PHRNumber: "someacceptedphr", Bidder: "somebidder", Price: 100, State: ACCEPTED}
+
+	var emptyBid *Bid
+	mbl.On("GetBid", "someissuer", "somephr", "somebidder").Return(wsBid, nil)
+	mbl.On("GetBid", "someissuer", "somebadphr", "somebidder").Return(badBid, nil)
+	mbl.On("GetBid", "someissuer", "someacceptedphr", "somebidder").Return(acceptedBid, nil)
+	mbl.On("GetBid", "someissuer", "someotherphr", "somebidder").Return(emptyBid, &ledgerapi.StateNotFoundError{Key: "someissuer:someotherphr:somebidder"})
+	mbl.On("UpdateBid", badBid).Return(errors.New("UpdateBid error"))
+	mbl.On("UpdateBid", wsBid).Return(nil)
+
+	bid, err = contract.CancelBid(ctx, "someissuer", "someotherphr")
+	assertCodedError(t, err, phrerror.NotFound, "No state found for someissuer:someotherphr:somebidder", "should error when submitter has no bid")
+	assert.Nil(t, bid, "should not return bid when submitter has no bid")
+
+	bid, err = contract.CancelBid(ctx, "someissuer", "someacceptedphr")
+	assertCodedError(t, err, phrerror.InvalidState, "Bid of somebidder on PHR someissuer:someacceptedphr is not open. Current state = ACCEPTED", "should error when bid not open")
+	assert.Nil(t, bid, "should not return bid when bid not open")
+
+	bid, err = contract.CancelBid(ctx, "someissuer", "somebadphr")
+	assert.EqualError(t, err, "UpdateBid error", "should error when UpdateBid errors")
+	assert.Nil(t, bid, "should not return bid when UpdateBid errors")
+
+	bid, err = contract.CancelBid(ctx, "someissuer", "somephr")
+	assert.Nil(t, err, "should not error on good cancel")
+	assert.Equal(t, CANCELLED, bid.State, "should cancel bid")
+	assert.Equal(t, wsBid, bid, "should update same bid as it returns in the world state")
+	assert.Equal(t, []*PHREvent{{Version: EventVersion, Name: BidCancelledEvent, Issuer: "someissuer", PHRNumber: "somephr", NewOwner: "somebidder", Price: 100, Timestamp: "2021-12-10T10:00:00Z"}}, getEvents(ctx), "should emit bid cancelled event")
+}
+
+func TestAcceptBid(t *testing.T) {
+	var phr *PHR
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mpl := ctx.phrList
+	mtl := ctx.tradeList
+	mll := ctx.listingList
+	mbl := ctx.bidList
+	mtkl := ctx.tokenList
+
+	contract := new(Contract)
+
+	wsPHR := &PHR{Issuer: "someissuer", PHRNumber: "somephr"}
+	resetPHR(wsPHR)
+	wsPHR.SetIssued()
+	unbidPHR := &PHR{Issuer: "someissuer", PHRNumber: "someunbidphr"}
+	resetPHR(unbidPHR)
+
+	lowBid := &Bid{Issuer: "someissuer", PHRNumber: "somephr", Bidder: "somelowbidder", Price: 100, ExpiresAt: "2021-12-11T10:00:00Z", State: OPEN}
+	highBid := &Bid{Issuer: "someissuer", PHRNumber: "somephr", Bidder: "somehighbidder", Price: 200, ExpiresAt: "2021-12-11T10:00:00Z", State: OPEN}
+	brokeBid := &Bid{Issuer: "someissuer", PHRNumber: "somephr", Bidder: "somebrokebidder", Price: 400, ExpiresAt: "2021-12-11T10:00:00Z", State: OPEN}
+	expiredBid := &Bid{Issuer: "someissuer", PHRNumber: "someunbidphr", Bidder: "somebidder", Price: 300, ExpiresAt: "2021-12-10T10:00:00Z", State: OPEN}
+
+	var emptyPHR *PHR
+	var emptyBids []*Bid
+	var sentTrade *Trade
+
+	mpl.On("GetPHR", "someissuer", "somephr").Return(wsPHR, nil)
+	mpl.On("GetPHR", "someissuer", "someunbidphr").Return(unbidPHR, nil)
+	mpl.On("GetPHR", "someotherissuer", "someotherphr").Return(emptyPHR, errors.New("GetPHR error"))
+	mpl.On("UpdatePHR", wsPHR).Return(nil)
+	mpl.On("SetPHROwnerOrg", "someissuer", "somephr", "Org1MSP").Return(nil)
+	mbl.On("GetBids", "someissuer", "somephr").Return([]*Bid{lowBid, brokeBid, highBid}, nil)
+	mbl.On("GetBids", "someissuer", "someunbidphr").Return([]*Bid{expiredBid}, nil)
+	mbl.On("UpdateBid", highBid).Return(nil)
+	mll.On("DeleteListing", "someissuer", "somephr").Return(nil)
+	mtl.On("AddTrade", mock.MatchedBy(func(trade *Trade) bool { sentTrade = trade; return true })).Return(nil)
+	sellerBalance := &Balance{Owner: "someowner"}
+	bidderBalance := &Balance{Owner: "somehighbidder", Amount: 500}
+	mtkl.On("GetBalance", "someowner").Return(sellerBalance, nil)
+	mtkl.On("GetBalance", "somehighbidder").Return(bidderBalance, nil)
+	mtkl.On("GetBalance", "somebrokebidder").Return(&Balance{Owner: "somebrokebidder", Amount: 100}, nil)
+	mtkl.On("UpdateBalance", mock.Anything).Return(nil)
+
+	phr, err = contract.AcceptBid(ctx, "someotherissuer", "someotherphr")
+	assert.EqualError(t, err, "GetPHR error", "should error when GetPHR errors")
+	assert.Nil(t, phr, "should not return phr when GetPHR errors")
+
+	otherCtx := newMockTransactionContext("someotherowner")
+	otherCtx.phrList = mpl
+	phr, err = contract.AcceptBid(otherCtx, "someissuer", "somephr")
+	assertCodedError(t, err, phrerror.NotOwner, "PHR someissuer:somephr is not owned by submitter someotherowner", "should error when submitter not owner")
+	assert.Nil(t, phr, "should not return phr when submitter not owner")
+
+	unbidPHR.SetExpired()
+	phr, err = contract.AcceptBid(ctx, "someissuer", "someunbidphr")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:someunbidphr is not trading. Current state = EXPIRED", "should error when phr not trading")
+	assert.Nil(t, phr, "should not return phr when not trading")
+	resetPHR(unbidPHR)
+
+	unbidPHR.MaturityDateTime = "2021-12-10T10:00:00Z"
+	phr, err = contract.AcceptBid(ctx, "someissuer", "someunbidphr")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:someunbidphr has matured. Maturity date time = 2021-12-10T10:00:00Z", "should error when phr matured")
+	assert.Nil(t, phr, "should not return phr when matured")
+	unbidPHR.MaturityDateTime = ""
+
+	phr, err = contract.AcceptBid(ctx, "someissuer", "someunbidphr")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:someunbidphr has no active bids", "should error when no active bids")
+	assert.Nil(t, phr, "should not return phr when no active bids")
+
+	errCtx := newMockTransactionContext("someowner")
+	errCtx.phrList = mpl
+	errCtx.bidList = new(MockBidList)
+	errCtx.bidList.On("GetBids", "someissuer", "somephr").Return(emptyBids, errors.New("GetBids error"))
+	phr, err = contract.AcceptBid(errCtx, "someissuer", "somephr")
+	assert.EqualError(t, err, "GetBids error", "should error when GetBids errors")
+	assert.Nil(t, phr, "should not return phr when GetBids errors")
+	wsPHR.SetIssued()
+
+	phr, err = contract.AcceptBid(ctx, "someissuer", "somephr")
+	assert.Nil(t, err, "should not error on good accept")
+	assert.Equal(t, "somehighbidder", phr.Owner, "should transfer phr to best bidder")
+	assert.True(t, phr.IsTrading(), "should move issued phr to trading")
+	assert.Equal(t, ACCEPTED, highBid.State, "should accept best bid")
+	assert.Equal(t, OPEN, lowBid.State, "should leave other bids open")
+	assert.Equal(t, OPEN, brokeBid.State, "should pass over bid of bidder without the funds")
+	mpl.AssertCalled(t, "SetPHROwnerOrg", "someissuer", "somephr", "Org1MSP")
+	mll.AssertCalled(t, "DeleteListing", "someissuer", "somephr")
+	assert.Equal(t, 300, bidderBalance.Amount, "should debit the bid price from the bidder")
+	assert.Equal(t, 200, sellerBalance.Amount, "should credit the bid price to the owner")
+	assert.Equal(t, &Trade{Issuer: "someissuer", PHRNumber: "somephr", Seller: "someowner", Buyer: "somehighbidder", Price: 200, TxID: "sometx", Timestamp: "2021-12-10T10:00:00Z"}, sentTrade, "should record trade at bid price")
+	assert.Equal(t, []*PHREvent{{Version: EventVersion, Name: TradedEvent, Issuer: "someissuer", PHRNumber: "somephr", PreviousOwner: "someowner", NewOwner: "somehighbidder", Price: 200, PreviousState: ISSUED, NewState: TRADING, Timestamp: "2021-12-10T10:00:00Z"}}, getEvents(ctx), "should emit traded event")
+
+	consentPHR := &PHR{Issuer: "someissuer", PHRNumber: "someconsentphr", PatientHash: "somepatienthash"}
+	resetPHR(consentPHR)
+	marketingBid := &Bid{Issuer: "someissuer", PHRNumber: "someconsentphr", Bidder: "somehighbidder", Price: 200, ExpiresAt: "2021-12-11T10:00:00Z", Purpose: "marketing", State: OPEN}
+	researchBid := &Bid{Issuer: "someissuer", PHRNumber: "someconsentphr", Bidder: "somelowbidder", Price: 100, ExpiresAt: "2021-12-11T10:00:00Z", Purpose: "research", State: OPEN}
+	mpl.On("GetPHR", "someissuer", "someconsentphr").Return(consentPHR, nil)
+	mpl.On("UpdatePHR", consentPHR).Return(nil)
+	mpl.On("SetPHROwnerOrg", "someissuer", "someconsentphr", "Org1MSP").Return(nil)
+	mbl.On("GetBids", "someissuer", "someconsentphr").Return([]*Bid{marketingBid}, nil).Once()
+	mbl.On("GetBids", "someissuer", "someconsentphr").Return([]*Bid{marketingBid, researchBid}, nil)
+	mbl.On("UpdateBid", researchBid).Return(nil)
+	mll.On("DeleteListing", "someissuer", "someconsentphr").Return(nil)
+	ctx.consentList.On("GetConsent", "someissuer", "someconsentphr").Return(&Consent{Issuer: "someissuer", PHRNumber: "someconsentphr", Purposes: []string{"research"}, BuyerMSPs: []string{"Org1MSP"}, ValidUntil: "2022-12-10T10:00:00Z"}, nil)
+	ctx.royaltyList.On("GetRoyaltyRate").Return(&RoyaltyRate{}, nil)
+	mtkl.On("GetBalance", "somelowbidder").Return(&Balance{Owner: "somelowbidder", Amount: 500}, nil)
+
+	phr, err = contract.AcceptBid(ctx, "someissuer", "someconsentphr")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:someconsentphr has no active bids whose bidder can buy it and pay", "should error when consent covers no active bid")
+	assert.Nil(t, phr, "should not return phr when consent covers no active bid")
+	assert.Equal(t, OPEN, marketingBid.State, "should leave bid not covered by consent open")
+
+	phr, err = contract.AcceptBid(ctx, "someissuer", "someconsentphr")
+	assert.Nil(t, err, "should not error when best bid is not covered by consent")
+	assert.Equal(t, "somelowbidder", phr.Owner, "should transfer phr to best bidder covered by consent")
+	assert.Equal(t, ACCEPTED, researchBid.State, "should accept best bid covered by consent")
+	assert.Equal(t, OPEN, marketingBid.State, "should pass over bid whose purpose consent does not cover")
+}
+
+func TestGetBids(t *testing.T) {
+	ctx := newMockTransactionContext("someowner")
+	mbl := ctx.bidList
+
+	contract := new(Contract)
+
+	wsBids := []*Bid{{Issuer: "someissuer", PHRNumber: "somephr", Bidder: "somebidder", Price: 100}}
+	mbl.On("GetBids", "someissuer", "somephr").Return(wsBids, nil)
+
+	bids, err := contract.GetBids(ctx, "someissuer", "somephr")
+	assert.Nil(t, err, "should not error when GetBids does not error")
+	assert.Equal(t, wsBids, bids, "should return bids from bid list")
+}
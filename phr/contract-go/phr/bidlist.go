@@ -0,0 +1,85 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+
+// BidListInterface defines functionality needed
+// to interact with the world state on behalf
+// of a bid
+type BidListInterface interface {
+	GetBid(string, string, string) (*Bid, error)
+	UpdateBid(*Bid) error
+	GetBids(string, string) ([]*Bid, error)
+}
+
+// bidListName namespace of bids in world state
+const bidListName = "org.phrnet.bidlist"
+
+type bidList struct {
+	stateList ledgerapi.StateListInterface
+}
+
+// GetBid returns the bid of a bidder on a phr. Returns a
+// ledgerapi.StateNotFoundError when the bidder has no bid
+func (bl *bidList) GetBid(issuer string, phrNumber string, bidder string) (*Bid, error) {
+	bid := new(Bid)
+
+	err := bl.stateList.GetState(ledgerapi.MakeKey(issuer, phrNumber, bidder), bid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bid, nil
+}
+
+// UpdateBid puts the bid, replacing any earlier
+// bid of the bidder on the phr
+func (bl *bidList) UpdateBid(bid *Bid) error {
+	return bl.stateList.UpdateState(bid)
+}
+
+// GetBids returns the bids on a phr in any state, ordered by bidder
+func (bl *bidList) GetBids(issuer string, phrNumber string) ([]*Bid, error) {
+	iterator, err := bl.stateList.GetStatesByPartialKey(CreatePHRKey(issuer, phrNumber))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer iterator.Close()
+
+	bids := []*Bid{}
+
+	for iterator.HasNext() {
+		bid := new(Bid)
+
+		err := iterator.Next(bid)
+
+		if err != nil {
+			return nil, err
+		}
+
+		bids = append(bids, bid)
+	}
+
+	return bids, nil
+}
+
+// newBidList create a new bid list from context
+func newBidList(ctx TransactionContextInterface) *bidList {
+	stateList := new(ledgerapi.StateList)
+	stateList.Ctx = ctx
+	stateList.Name = bidListName
+	stateList.Deserialize = func(bytes []byte, state ledgerapi.StateInterface) error {
+		return DeserializeBid(bytes, state.(*Bid))
+	}
+
+	list := new(bidList)
+	list.stateList = stateList
+
+	return list
+}
@@ -0,0 +1,123 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"errors"
+	"testing"
+
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// #########
+// HELPERS
+// #########
+
+type MockBidIterator struct {
+	prices []int
+	err    error
+	closed bool
+}
+
+func (mbi *MockBidIterator) HasNext() bool {
+	return len(mbi.prices) > 0
+}
+
+func (mbi *MockBidIterator) Next(state ledgerapi.StateInterface) error {
+	if mbi.err != nil {
+		return mbi.err
+	}
+
+	state.(*Bid).Price = mbi.prices[0]
+	mbi.prices = mbi.prices[1:]
+
+	return nil
+}
+
+func (mbi *MockBidIterator) Close() error {
+	mbi.closed = true
+
+	return nil
+}
+
+// #########
+// TESTS
+// #########
+
+func TestGetBidFromList(t *testing.T) {
+	var bid *Bid
+	var err error
+
+	list := new(bidList)
+	msl := new(MockStateList)
+	msl.On("GetState", "someissuer:somephr:somebidder", mock.MatchedBy(func(state ledgerapi.StateInterface) bool { _, ok := state.(*Bid); return ok })).Return(nil)
+	msl.On("GetState", "someotherissuer:someotherphr:somebidder", mock.MatchedBy(func(state ledgerapi.StateInterface) bool { _, ok := state.(*Bid); return ok })).Return(errors.New("GetState error"))
+	list.stateList = msl
+
+	bid, err = list.GetBid("someissuer", "somephr", "somebidder")
+	assert.Nil(t, err, "should not error when get state on state list does not error")
+	assert.Equal(t, "somephr", bid.PHRNumber, "should use state list GetState to fill bid")
+
+	bid, err = list.GetBid("someotherissuer", "someotherphr", "somebidder")
+	assert.EqualError(t, err, "GetState error", "should return error when state list get state errors")
+	assert.Nil(t, bid, "should not return bid on error")
+}
+
+func TestUpdateBid(t *testing.T) {
+	bid := new(Bid)
+
+	list := new(bidList)
+	msl := new(MockStateList)
+	msl.On("UpdateState", bid).Return(errors.New("Called update state correctly"))
+	list.stateList = msl
+
+	err := list.UpdateBid(bid)
+	assert.EqualError(t, err, "Called update state correctly", "should call state list update state with bid")
+}
+
+func TestGetBidsFromList(t *testing.T) {
+	var bids []*Bid
+	var err error
+
+	list := new(bidList)
+	msl := new(MockStateList)
+	goodIterator := &MockBidIterator{prices: []int{100, 200}}
+	badIterator := &MockBidIterator{prices: []int{100}, err: errors.New("Next error")}
+	var emptyIterator *MockBidIterator
+	msl.On("GetStatesByPartialKey", CreatePHRKey("someissuer", "somephr")).Return(goodIterator, nil)
+	msl.On("GetStatesByPartialKey", CreatePHRKey("someissuer", "somebadphr")).Return(badIterator, nil)
+	msl.On("GetStatesByPartialKey", CreatePHRKey("someotherissuer", "someotherphr")).Return(emptyIterator, errors.New("GetStatesByPartialKey error"))
+	list.stateList = msl
+
+	bids, err = list.GetBids("someotherissuer", "someotherphr")
+	assert.EqualError(t, err, "GetStatesByPartialKey error", "should return error when state list get states errors")
+	assert.Nil(t, bids, "should not return bids on error")
+
+	bids, err = list.GetBids("someissuer", "somebadphr")
+	assert.EqualError(t, err, "Next error", "should return error when iterator errors")
+	assert.Nil(t, bids, "should not return bids on iterator error")
+	assert.True(t, badIterator.closed, "should close iterator on error")
+
+	bids, err = list.GetBids("someissuer", "somephr")
+	assert.Nil(t, err, "should not error when state list get states does not error")
+	assert.Equal(t, []*Bid{{Price: 100}, {Price: 200}}, bids, "should read every bid from iterator")
+	assert.True(t, goodIterator.closed, "should close iterator when done")
+}
+
+func TestNewBidList(t *testing.T) {
+	ctx := new(TransactionContext)
+	list := newBidList(ctx)
+	stateList, ok := list.stateList.(*ledgerapi.StateList)
+
+	assert.True(t, ok, "should make statelist of type ledgerapi.StateList")
+	assert.Equal(t, ctx, stateList.Ctx, "should set the context to passed context")
+	assert.Equal(t, "org.phrnet.bidlist", stateList.Name, "should set the name for the list")
+
+	expectedErr := DeserializeBid([]byte("bad json"), new(Bid))
+	err := stateList.Deserialize([]byte("bad json"), new(Bid))
+	assert.EqualError(t, err, expectedErr.Error(), "should call DeserializeBid when stateList.Deserialize called")
+}
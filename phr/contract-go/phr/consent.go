@@ -0,0 +1,72 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Consent defines the permission of the patient of a phr
+// for buyers of the listed MSPs to acquire it for the
+// listed purposes of use until validUntil
+type Consent struct {
+	Issuer          string   `json:"issuer"`
+	PHRNumber       string   `json:"phrNumber"`
+	Purposes        []string `json:"purposes"`
+	BuyerMSPs       []string `json:"buyerMSPs"`
+	ValidUntil      string   `json:"validUntil"`
+	GrantedDateTime string   `json:"grantedDateTime"`
+	RevokedDateTime string   `json:"revokedDateTime,omitempty" metadata:"revokedDateTime,optional"`
+}
+
+// IsRevoked returns true if the consent has been revoked
+func (consent *Consent) IsRevoked() bool {
+	return consent.RevokedDateTime != ""
+}
+
+// IsActive returns true if the consent has not been
+// revoked and is still valid at the passed time
+func (consent *Consent) IsActive(t time.Time) bool {
+	return !consent.IsRevoked() && consent.ValidUntil > FormatDateTime(t)
+}
+
+// Covers returns true if the consent is active at the passed
+// time for a buyer of the MSP and the purpose of use
+func (consent *Consent) Covers(mspID string, purpose string, t time.Time) bool {
+	return consent.IsActive(t) && contains(consent.BuyerMSPs, mspID) && contains(consent.Purposes, purpose)
+}
+
+// GetSplitKey returns values which should be used to form key
+func (consent *Consent) GetSplitKey() []string {
+	return []string{consent.Issuer, consent.PHRNumber}
+}
+
+// Serialize formats the consent as JSON bytes
+func (consent *Consent) Serialize() ([]byte, error) {
+	return json.Marshal(consent)
+}
+
+// DeserializeConsent formats the consent from JSON bytes
+func DeserializeConsent(bytes []byte, consent *Consent) error {
+	err := json.Unmarshal(bytes, consent)
+
+	if err != nil {
+		return fmt.Errorf("Error deserializing consent. %s", err.Error())
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
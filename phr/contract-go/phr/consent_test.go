@@ -0,0 +1,62 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsentIsActive(t *testing.T) {
+	now := time.Date(2021, 12, 10, 10, 0, 0, 0, time.UTC)
+	consent := &Consent{ValidUntil: "2021-12-11T10:00:00Z"}
+
+	assert.True(t, consent.IsActive(now), "should be true before valid until")
+	assert.False(t, consent.IsActive(now.Add(24*time.Hour)), "should be false at valid until")
+
+	consent.RevokedDateTime = "2021-12-09T10:00:00Z"
+	assert.True(t, consent.IsRevoked(), "should be revoked when revoked date time set")
+	assert.False(t, consent.IsActive(now), "should be false when revoked")
+}
+
+func TestConsentCovers(t *testing.T) {
+	now := time.Date(2021, 12, 10, 10, 0, 0, 0, time.UTC)
+	consent := &Consent{Purposes: []string{"research", "treatment"}, BuyerMSPs: []string{"Org1MSP"}, ValidUntil: "2021-12-11T10:00:00Z"}
+
+	assert.True(t, consent.Covers("Org1MSP", "treatment", now), "should be true for listed MSP and purpose")
+	assert.False(t, consent.Covers("Org2MSP", "treatment", now), "should be false for unlisted MSP")
+	assert.False(t, consent.Covers("Org1MSP", "marketing", now), "should be false for unlisted purpose")
+	assert.False(t, consent.Covers("Org1MSP", "treatment", now.Add(24*time.Hour)), "should be false when no longer valid")
+}
+
+func TestConsentGetSplitKey(t *testing.T) {
+	consent := &Consent{Issuer: "someissuer", PHRNumber: "somephr"}
+
+	assert.Equal(t, []string{"someissuer", "somephr"}, consent.GetSplitKey(), "should return issuer and phr number as split key")
+}
+
+func TestConsentSerialize(t *testing.T) {
+	consent := &Consent{Issuer: "someissuer", PHRNumber: "somephr", Purposes: []string{"research"}, BuyerMSPs: []string{"Org1MSP"}, ValidUntil: "2021-12-11T10:00:00Z", GrantedDateTime: "2021-12-10T10:00:00Z"}
+
+	bytes, err := consent.Serialize()
+	assert.Nil(t, err, "should not error on serialize")
+	assert.Equal(t, `{"issuer":"someissuer","phrNumber":"somephr","purposes":["research"],"buyerMSPs":["Org1MSP"],"validUntil":"2021-12-11T10:00:00Z","grantedDateTime":"2021-12-10T10:00:00Z"}`, string(bytes), "should return JSON formatted value")
+}
+
+func TestDeserializeConsent(t *testing.T) {
+	var consent *Consent
+	var err error
+
+	consent = new(Consent)
+	err = DeserializeConsent([]byte(`{"issuer":"someissuer","phrNumber":"somephr","purposes":["research"],"buyerMSPs":["Org1MSP"],"validUntil":"2021-12-11T10:00:00Z","revokedDateTime":"2021-12-10T10:00:00Z"}`), consent)
+	assert.Nil(t, err, "should not return error for deserialize")
+	assert.Equal(t, &Consent{Issuer: "someissuer", PHRNumber: "somephr", Purposes: []string{"research"}, BuyerMSPs: []string{"Org1MSP"}, ValidUntil: "2021-12-11T10:00:00Z", RevokedDateTime: "2021-12-10T10:00:00Z"}, consent, "should create expected consent")
+
+	consent = new(Consent)
+	err = DeserializeConsent([]byte(`{"purposes":"research"}`), consent)
+	assert.EqualError(t, err, "Error deserializing consent. json: cannot unmarshal string into Go struct field Consent.purposes of type []string", "should return error for bad data")
+}
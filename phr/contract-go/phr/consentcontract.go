@@ -0,0 +1,200 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"strings"
+	"time"
+
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+)
+
+// GrantConsent records the consent of the patient of a phr for buyers
+// of buyerMSPs to acquire it for purposes until validUntil. Replaces
+// any earlier consent. Submitted by the patient only, so neither the
+// issuer nor an owner selling the phr can consent for the patient. The
+// patient passes the salt of the patient hash in the transient data
+// under PatientSaltTransientKey
+func (c *Contract) GrantConsent(ctx TransactionContextInterface, issuer string, phrNumber string, purposes []string, buyerMSPs []string, validUntil string) (*Consent, error) {
+	validUntil, err := parseDateTime("validUntil", validUntil, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(purposes) == 0 || contains(purposes, "") {
+		return nil, &ValidationError{Field: "purposes", Value: strings.Join(purposes, ","), Reason: "Must name at least one purpose and no empty ones"}
+	}
+
+	if len(buyerMSPs) == 0 || contains(buyerMSPs, "") {
+		return nil, &ValidationError{Field: "buyerMSPs", Value: strings.Join(buyerMSPs, ","), Reason: "Must name at least one MSP and no empty ones"}
+	}
+
+	phr, err := getConsentPHR(ctx, issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	txTime, err := getTxTime(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	grantedDateTime := FormatDateTime(txTime)
+
+	if validUntil <= grantedDateTime {
+		return nil, &ValidationError{Field: "validUntil", Value: validUntil, Reason: "Must be after the granted date time " + grantedDateTime}
+	}
+
+	consent := Consent{Issuer: issuer, PHRNumber: phrNumber, Purposes: purposes, BuyerMSPs: buyerMSPs, ValidUntil: validUntil, GrantedDateTime: grantedDateTime}
+
+	err = ctx.GetConsentList().UpdateConsent(&consent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = setEvent(ctx, &PHREvent{Name: ConsentGrantedEvent, Issuer: issuer, PHRNumber: phrNumber, PreviousOwner: phr.Owner, NewOwner: phr.Owner, PreviousState: phr.GetState(), NewState: phr.GetState()})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &consent, nil
+}
+
+// RevokeConsent revokes the consent of the patient of a phr.
+// Submitted by the patient only, passing the salt as GrantConsent does
+func (c *Contract) RevokeConsent(ctx TransactionContextInterface, issuer string, phrNumber string) (*Consent, error) {
+	phr, err := getConsentPHR(ctx, issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	consent, err := ctx.GetConsentList().GetConsent(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if consent.IsRevoked() {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "Consent on PHR %s:%s is already revoked", issuer, phrNumber)
+	}
+
+	txTime, err := getTxTime(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	consent.RevokedDateTime = FormatDateTime(txTime)
+
+	err = ctx.GetConsentList().UpdateConsent(consent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = setEvent(ctx, &PHREvent{Name: ConsentRevokedEvent, Issuer: issuer, PHRNumber: phrNumber, PreviousOwner: phr.Owner, NewOwner: phr.Owner, PreviousState: phr.GetState(), NewState: phr.GetState()})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return consent, nil
+}
+
+// GetConsent returns the consent of the patient of a phr
+func (c *Contract) GetConsent(ctx TransactionContextInterface, issuer string, phrNumber string) (*Consent, error) {
+	return ctx.GetConsentList().GetConsent(issuer, phrNumber)
+}
+
+// getConsentPHR returns the phr after checking the submitting client
+// identity is its patient. The patient named by the client identity
+// and the salt in the transient data must hash to the patient hash
+func getConsentPHR(ctx TransactionContextInterface, issuer string, phrNumber string) (*PHR, error) {
+	phr, err := ctx.GetPHRList().GetPHR(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !phr.NeedsConsent() {
+		return nil, phrerror.Errorf(phrerror.Validation, "PHR %s:%s names no patient", issuer, phrNumber)
+	}
+
+	salt, err := getTransientSalt(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := isPatient(ctx, phr, salt)
+
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, phrerror.Errorf(phrerror.Forbidden, "Consent on PHR %s:%s can only be changed by its patient", issuer, phrNumber)
+	}
+
+	return phr, nil
+}
+
+// getTransientSalt returns the salt of the patient
+// hash passed under PatientSaltTransientKey
+func getTransientSalt(ctx TransactionContextInterface) (string, error) {
+	transient, err := ctx.GetStub().GetTransient()
+
+	if err != nil {
+		return "", err
+	}
+
+	salt, ok := transient[PatientSaltTransientKey]
+
+	if !ok || len(salt) == 0 {
+		return "", &ValidationError{Field: PatientSaltTransientKey, Value: "", Reason: "Value is required in the transient data"}
+	}
+
+	return string(salt), nil
+}
+
+// isPatient reports whether the submitting client is the patient
+// of a phr: the patient its certificate names, salted with salt,
+// hashes to the patient hash of the phr
+func isPatient(ctx TransactionContextInterface, phr *PHR, salt string) (bool, error) {
+	patient, err := getPatient(ctx.GetClientIdentity())
+
+	if err != nil {
+		return false, err
+	}
+
+	return phr.PatientHash != "" && HashPatient(patient, salt) == phr.PatientHash, nil
+}
+
+// checkConsent checks an active consent of the patient of a phr
+// covers the MSP of the new owner and the purpose of use
+func checkConsent(ctx TransactionContextInterface, phr *PHR, mspID string, purpose string, txTime time.Time) error {
+	if purpose == "" {
+		return &ValidationError{Field: "purpose", Value: purpose, Reason: "Value is required to buy a phr naming a patient"}
+	}
+
+	consent, err := ctx.GetConsentList().GetConsent(phr.Issuer, phr.PHRNumber)
+
+	if _, ok := err.(*ledgerapi.StateNotFoundError); ok {
+		return phrerror.Errorf(phrerror.Forbidden, "PHR %s:%s has no consent of its patient", phr.Issuer, phr.PHRNumber)
+	} else if err != nil {
+		return err
+	}
+
+	if !consent.Covers(mspID, purpose, txTime) {
+		return phrerror.Errorf(phrerror.Forbidden, "Consent on PHR %s:%s does not cover MSP %s for purpose %q", phr.Issuer, phr.PHRNumber, mspID, purpose)
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"errors"
+	"testing"
+
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// #########
+// HELPERS
+// #########
+
+// newMockPatientContext returns a mock transaction context whose client
+// identity names patient in its patient attribute, passing salt in the
+// transient data when not empty
+func newMockPatientContext(patient string, salt string) *MockTransactionContext {
+	ctx := newMockTransactionContext(patient)
+	ctx.GetClientIdentity().(*MockClientIdentity).On("GetAttributeValue", PatientAttribute).Return(patient, patient != "", nil)
+
+	if salt != "" {
+		setTransient(ctx, map[string][]byte{PatientSaltTransientKey: []byte(salt)})
+	}
+
+	return ctx
+}
+
+// #########
+// TESTS
+// #########
+
+func TestGrantConsent(t *testing.T) {
+	var consent *Consent
+	var err error
+
+	ctx := newMockPatientContext("somepatient", "somesaltsomesalt")
+	mpl := ctx.phrList
+	mcl := ctx.consentList
+
+	contract := new(Contract)
+
+	wsPHR := &PHR{Issuer: "someissuer", PHRNumber: "somephr", Owner: "someowner", PatientHash: HashPatient("somepatient", "somesaltsomesalt")}
+	wsPHR.SetTrading()
+	var emptyPHR *PHR
+	var sentConsent *Consent
+	shouldError := false
+
+	mpl.On("GetPHR", "someissuer", "somephr").Return(wsPHR, nil)
+	mpl.On("GetPHR", "someissuer", "somenopatientphr").Return(&PHR{Issuer: "someissuer", PHRNumber: "somenopatientphr"}, nil)
+	mpl.On("GetPHR", "someotherissuer", "someotherphr").Return(emptyPHR, errors.New("GetPHR error"))
+	mcl.On("UpdateConsent", mock.MatchedBy(func(consent *Consent) bool { return shouldError })).Return(errors.New("UpdateConsent error"))
+	mcl.On("UpdateConsent", mock.MatchedBy(func(consent *Consent) bool { sentConsent = consent; return !shouldError })).Return(nil)
+
+	purposes := []string{"research"}
+	msps := []string{"Org1MSP"}
+
+	consent, err = contract.GrantConsent(ctx, "someissuer", "somephr", purposes, msps, "2022-12-10")
+	assertCodedError(t, err, phrerror.Validation, `Invalid validUntil "2022-12-10". Expected an RFC 3339 date time`, "should error when valid until does not parse")
+	assert.Nil(t, consent, "should not return consent when valid until does not parse")
+
+	consent, err = contract.GrantConsent(ctx, "someissuer", "somephr", []string{}, msps, "2022-12-10T10:00:00Z")
+	assertCodedError(t, err, phrerror.Validation, `Invalid purposes "". Must name at least one purpose and no empty ones`, "should error when no purposes")
+	assert.Nil(t, consent, "should not return consent when no purposes")
+
+	consent, err = contract.GrantConsent(ctx, "someissuer", "somephr", purposes, []string{"Org1MSP", ""}, "2022-12-10T10:00:00Z")
+	assertCodedError(t, err, phrerror.Validation, `Invalid buyerMSPs "Org1MSP,". Must name at least one MSP and no empty ones`, "should error when empty MSP")
+	assert.Nil(t, consent, "should not return consent when empty MSP")
+
+	consent, err = contract.GrantConsent(ctx, "someotherissuer", "someotherphr", purposes, msps, "2022-12-10T10:00:00Z")
+	assert.EqualError(t, err, "GetPHR error", "should error when GetPHR errors")
+	assert.Nil(t, consent, "should not return consent when GetPHR errors")
+
+	consent, err = contract.GrantConsent(ctx, "someissuer", "somenopatientphr", purposes, msps, "2022-12-10T10:00:00Z")
+	assertCodedError(t, err, phrerror.Validation, "PHR someissuer:somenopatientphr names no patient", "should error when phr names no patient")
+	assert.Nil(t, consent, "should not return consent when phr names no patient")
+
+	otherCtx := newMockPatientContext("someotherpatient", "somesaltsomesalt")
+	otherCtx.phrList = mpl
+	consent, err = contract.GrantConsent(otherCtx, "someissuer", "somephr", purposes, msps, "2022-12-10T10:00:00Z")
+	assertCodedError(t, err, phrerror.Forbidden, "Consent on PHR someissuer:somephr can only be changed by its patient", "should error when submitter another patient")
+	assert.Nil(t, consent, "should not return consent when submitter another patient")
+
+	guessCtx := newMockPatientContext("somepatient", "someguessedsalt")
+	guessCtx.phrList = mpl
+	consent, err = contract.GrantConsent(guessCtx, "someissuer", "somephr", purposes, msps, "2022-12-10T10:00:00Z")
+	assertCodedError(t, err, phrerror.Forbidden, "Consent on PHR someissuer:somephr can only be changed by its patient", "should error when salt does not match")
+	assert.Nil(t, consent, "should not return consent when salt does not match")
+
+	saltlessCtx := newMockPatientContext("somepatient", "")
+	saltlessCtx.phrList = mpl
+	consent, err = contract.GrantConsent(saltlessCtx, "someissuer", "somephr", purposes, msps, "2022-12-10T10:00:00Z")
+	assertCodedError(t, err, phrerror.Validation, `Invalid patientSalt "". Value is required in the transient data`, "should error when salt missing")
+	assert.Nil(t, consent, "should not return consent when salt missing")
+
+	issuerCtx := newMockPatientContext("", "somesaltsomesalt")
+	issuerCtx.phrList = mpl
+	consent, err = contract.GrantConsent(issuerCtx, "someissuer", "somephr", purposes, msps, "2022-12-10T10:00:00Z")
+	assertCodedError(t, err, phrerror.Forbidden, "Client identity names no patient in attribute phr.patient", "should error when issuer grants consent on behalf of patient")
+	assert.Nil(t, consent, "should not return consent when issuer grants")
+
+	consent, err = contract.GrantConsent(ctx, "someissuer", "somephr", purposes, msps, "2021-12-10T10:00:00Z")
+	assertCodedError(t, err, phrerror.Validation, `Invalid validUntil "2021-12-10T10:00:00Z". Must be after the granted date time 2021-12-10T10:00:00Z`, "should error when consent not valid after grant")
+	assert.Nil(t, consent, "should not return consent when not valid after grant")
+
+	shouldError = true
+	consent, err = contract.GrantConsent(ctx, "someissuer", "somephr", purposes, msps, "2022-12-10T10:00:00Z")
+	assert.EqualError(t, err, "UpdateConsent error", "should error when update consent fails")
+	assert.Nil(t, consent, "should not return consent when update consent fails")
+	assert.Empty(t, getEvents(ctx), "should not emit event when update consent fails")
+	shouldError = false
+
+	consent, err = contract.GrantConsent(ctx, "someissuer", "somephr", purposes, msps, "2022-12-10T10:00:00Z")
+	assert.Nil(t, err, "should not error when patient grants consent")
+	assert.Equal(t, &Consent{Issuer: "someissuer", PHRNumber: "somephr", Purposes: purposes, BuyerMSPs: msps, ValidUntil: "2022-12-10T10:00:00Z", GrantedDateTime: "2021-12-10T10:00:00Z"}, consent, "should return granted consent")
+	assert.Equal(t, sentConsent, consent, "should put same consent as it returns in the world state")
+	assert.Equal(t, []*PHREvent{{Version: EventVersion, Name: ConsentGrantedEvent, Issuer: "someissuer", PHRNumber: "somephr", PreviousOwner: "someowner", NewOwner: "someowner", PreviousState: TRADING, NewState: TRADING, Timestamp: "2021-12-10T10:00:00Z"}}, getEvents(ctx), "should emit consent granted event")
+}
+
+func TestRevokeConsent(t *testing.T) {
+	var consent *Consent
+	var err error
+
+	ctx := newMockPatientContext("somepatient", "somesaltsomesalt")
+	mpl := ctx.phrList
+	mcl := ctx.consentList
+
+	contract := new(Contract)
+
+	patientHash := HashPatient("somepatient", "somesaltsomesalt")
+	wsPHR := &PHR{Issuer: "someissuer", PHRNumber: "somephr", Owner: "someowner", PatientHash: patientHash}
+	unconsentedPHR := &PHR{Issuer: "someissuer", PHRNumber: "someunconsentedphr", Owner: "someowner", PatientHash: patientHash}
+	wsConsent := &Consent{Issuer: "someissuer", PHRNumber: "somephr", ValidUntil: "2022-12-10T10:00:00Z"}
+	var emptyConsent *Consent
+
+	mpl.On("GetPHR", "someissuer", "somephr").Return(wsPHR, nil)
+	mpl.On("GetPHR", "someissuer", "someunconsentedphr").Return(unconsentedPHR, nil)
+	mpl.On("GetPHR", "someissuer", "somenopatientphr").Return(&PHR{Issuer: "someissuer", PHRNumber: "somenopatientphr"}, nil)
+	mcl.On("GetConsent", "someissuer", "somephr").Return(wsConsent, nil)
+	mcl.On("GetConsent", "someissuer", "someunconsentedphr").Return(emptyConsent, &ledgerapi.StateNotFoundError{Key: "someissuer:someunconsentedphr"})
+	mcl.On("UpdateConsent", wsConsent).Return(nil)
+
+	consent, err = contract.RevokeConsent(ctx, "someissuer", "somenopatientphr")
+	assertCodedError(t, err, phrerror.Validation, "PHR someissuer:somenopatientphr names no patient", "should error when phr names no patient")
+	assert.Nil(t, consent, "should not return consent when phr names no patient")
+
+	issuerCtx := newMockPatientContext("", "somesaltsomesalt")
+	issuerCtx.phrList = mpl
+	consent, err = contract.RevokeConsent(issuerCtx, "someissuer", "somephr")
+	assertCodedError(t, err, phrerror.Forbidden, "Client identity names no patient in attribute phr.patient", "should error when issuer revokes consent on behalf of patient")
+	assert.Nil(t, consent, "should not return consent when issuer revokes")
+
+	otherCtx := newMockPatientContext("someotherpatient", "somesaltsomesalt")
+	otherCtx.phrList = mpl
+	consent, err = contract.RevokeConsent(otherCtx, "someissuer", "somephr")
+	assertCodedError(t, err, phrerror.Forbidden, "Consent on PHR someissuer:somephr can only be changed by its patient", "should error when submitter another patient")
+	assert.Nil(t, consent, "should not return consent when submitter another patient")
+
+	consent, err = contract.RevokeConsent(ctx, "someissuer", "someunconsentedphr")
+	assertCodedError(t, err, phrerror.NotFound, "No state found for someissuer:someunconsentedphr", "should error when consent never granted")
+	assert.Nil(t, consent, "should not return consent when never granted")
+
+	consent, err = contract.RevokeConsent(ctx, "someissuer", "somephr")
+	assert.Nil(t, err, "should not error when patient revokes consent")
+	assert.Equal(t, "2021-12-10T10:00:00Z", consent.RevokedDateTime, "should set revoked date time")
+	assert.Equal(t, wsConsent, consent, "should update consent in the world state")
+	assert.Equal(t, []*PHREvent{{Version: EventVersion, Name: ConsentRevokedEvent, Issuer: "someissuer", PHRNumber: "somephr", PreviousOwner: "someowner", NewOwner: "someowner", Timestamp: "2021-12-10T10:00:00Z"}}, getEvents(ctx), "should emit consent revoked event")
+
+	consent, err = contract.RevokeConsent(ctx, "someissuer", "somephr")
+	assertCodedError(t, err, phrerror.InvalidState, "Consent on PHR someissuer:somephr is already revoked", "should error when consent already revoked")
+	assert.Nil(t, consent, "should not return consent when already revoked")
+}
+
+func TestGetConsent(t *testing.T) {
+	ctx := newMockTransactionContext("someowner")
+	mcl := ctx.consentList
+
+	contract := new(Contract)
+
+	wsConsent := &Consent{Issuer: "someissuer", PHRNumber: "somephr"}
+	mcl.On("GetConsent", "someissuer", "somephr").Return(wsConsent, nil)
+
+	consent, err := contract.GetConsent(ctx, "someissuer", "somephr")
+	assert.Nil(t, err, "should not error when GetConsent does not error")
+	assert.Equal(t, wsConsent, consent, "should return consent from consent list")
+}
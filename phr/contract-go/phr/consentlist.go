@@ -0,0 +1,57 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+
+// ConsentListInterface defines functionality needed
+// to interact with the world state on behalf
+// of a consent
+type ConsentListInterface interface {
+	GetConsent(string, string) (*Consent, error)
+	UpdateConsent(*Consent) error
+}
+
+// consentListName namespace of consents in world state
+const consentListName = "org.phrnet.consentlist"
+
+type consentList struct {
+	stateList ledgerapi.StateListInterface
+}
+
+// GetConsent returns the consent of the patient of a phr. Returns
+// a ledgerapi.StateNotFoundError when consent was never granted
+func (cl *consentList) GetConsent(issuer string, phrNumber string) (*Consent, error) {
+	consent := new(Consent)
+
+	err := cl.stateList.GetState(CreatePHRKey(issuer, phrNumber), consent)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return consent, nil
+}
+
+// UpdateConsent puts the consent of the patient of a
+// phr, replacing any existing consent
+func (cl *consentList) UpdateConsent(consent *Consent) error {
+	return cl.stateList.UpdateState(consent)
+}
+
+// newConsentList create a new consent list from context
+func newConsentList(ctx TransactionContextInterface) *consentList {
+	stateList := new(ledgerapi.StateList)
+	stateList.Ctx = ctx
+	stateList.Name = consentListName
+	stateList.Deserialize = func(bytes []byte, state ledgerapi.StateInterface) error {
+		return DeserializeConsent(bytes, state.(*Consent))
+	}
+
+	list := new(consentList)
+	list.stateList = stateList
+
+	return list
+}
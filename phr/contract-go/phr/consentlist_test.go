@@ -0,0 +1,59 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"errors"
+	"testing"
+
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetConsentFromList(t *testing.T) {
+	var consent *Consent
+	var err error
+
+	list := new(consentList)
+	msl := new(MockStateList)
+	msl.On("GetState", CreatePHRKey("someissuer", "somephr"), mock.MatchedBy(func(state ledgerapi.StateInterface) bool { _, ok := state.(*Consent); return ok })).Return(nil)
+	msl.On("GetState", CreatePHRKey("someotherissuer", "someotherphr"), mock.MatchedBy(func(state ledgerapi.StateInterface) bool { _, ok := state.(*Consent); return ok })).Return(errors.New("GetState error"))
+	list.stateList = msl
+
+	consent, err = list.GetConsent("someissuer", "somephr")
+	assert.Nil(t, err, "should not error when get state on state list does not error")
+	assert.Equal(t, "somephr", consent.PHRNumber, "should use state list GetState to fill consent")
+
+	consent, err = list.GetConsent("someotherissuer", "someotherphr")
+	assert.EqualError(t, err, "GetState error", "should return error when state list get state errors")
+	assert.Nil(t, consent, "should not return consent on error")
+}
+
+func TestUpdateConsent(t *testing.T) {
+	consent := new(Consent)
+
+	list := new(consentList)
+	msl := new(MockStateList)
+	msl.On("UpdateState", consent).Return(errors.New("Called update state correctly"))
+	list.stateList = msl
+
+	err := list.UpdateConsent(consent)
+	assert.EqualError(t, err, "Called update state correctly", "should call state list update state with consent")
+}
+
+func TestNewConsentList(t *testing.T) {
+	ctx := new(TransactionContext)
+	list := newConsentList(ctx)
+	stateList, ok := list.stateList.(*ledgerapi.StateList)
+
+	assert.True(t, ok, "should make statelist of type ledgerapi.StateList")
+	assert.Equal(t, ctx, stateList.Ctx, "should set the context to passed context")
+	assert.Equal(t, "org.phrnet.consentlist", stateList.Name, "should set the name for the list")
+
+	expectedErr := DeserializeConsent([]byte("bad json"), new(Consent))
+	err := stateList.Deserialize([]byte("bad json"), new(Consent))
+	assert.EqualError(t, err, expectedErr.Error(), "should call DeserializeConsent when stateList.Deserialize called")
+}
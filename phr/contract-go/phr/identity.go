@@ -0,0 +1,129 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
+)
+
+// OwnerAttribute certificate attribute which, when present,
+// names the owner a client identity acts as
+const OwnerAttribute = "phr.owner"
+
+// PatientAttribute certificate attribute naming the
+// patient a client identity of the patient role is
+const PatientAttribute = "phr.patient"
+
+// IdentityMapperInterface maps the identity submitting a
+// transaction to the owner value stored on a phr
+type IdentityMapperInterface interface {
+	GetOwner(cid.ClientIdentity) (string, error)
+	GetMSPID(string) (string, error)
+}
+
+// IdentityMapper implementation of IdentityMapperInterface.
+// Looks up the client MSP ID in Owners. A client may instead act
+// as another owner of its MSP, listed in Members, by naming it in
+// the OwnerAttribute of its certificate
+type IdentityMapper struct {
+	Owners  map[string]string
+	Members map[string][]string
+}
+
+// GetOwner returns the owner the client identity acts as
+func (im *IdentityMapper) GetOwner(ci cid.ClientIdentity) (string, error) {
+	if ci == nil {
+		return "", fmt.Errorf("No client identity for transaction")
+	}
+
+	mspID, err := ci.GetMSPID()
+
+	if err != nil {
+		return "", err
+	}
+
+	owner, found, err := ci.GetAttributeValue(OwnerAttribute)
+
+	if err != nil {
+		return "", err
+	} else if found && owner != "" {
+		if !im.isMember(mspID, owner) {
+			return "", phrerror.Errorf(phrerror.Forbidden, "Owner %s is not a member of MSP %s", owner, mspID)
+		}
+
+		return owner, nil
+	}
+
+	owner, ok := im.Owners[mspID]
+
+	if !ok {
+		return "", phrerror.Errorf(phrerror.Forbidden, "No owner mapped for MSP %s", mspID)
+	}
+
+	return owner, nil
+}
+
+// GetMSPID returns the MSP ID an owner is mapped to in Owners
+// or is a member of in Members. MSP IDs are checked in order so
+// the result is the same on every endorsing peer
+func (im *IdentityMapper) GetMSPID(owner string) (string, error) {
+	mspIDs := []string{}
+
+	for mspID := range im.Owners {
+		mspIDs = append(mspIDs, mspID)
+	}
+
+	for mspID := range im.Members {
+		if _, ok := im.Owners[mspID]; !ok {
+			mspIDs = append(mspIDs, mspID)
+		}
+	}
+
+	sort.Strings(mspIDs)
+
+	for _, mspID := range mspIDs {
+		if im.isMember(mspID, owner) {
+			return mspID, nil
+		}
+	}
+
+	return "", phrerror.Errorf(phrerror.Forbidden, "No MSP mapped for owner %s", owner)
+}
+
+func (im *IdentityMapper) isMember(mspID string, owner string) bool {
+	if defaultOwner, ok := im.Owners[mspID]; ok && defaultOwner == owner {
+		return true
+	}
+
+	for _, member := range im.Members[mspID] {
+		if member == owner {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getPatient returns the patient the client identity is, as named
+// in the PatientAttribute of its certificate
+func getPatient(ci cid.ClientIdentity) (string, error) {
+	if ci == nil {
+		return "", fmt.Errorf("No client identity for transaction")
+	}
+
+	patient, found, err := ci.GetAttributeValue(PatientAttribute)
+
+	if err != nil {
+		return "", err
+	} else if !found || patient == "" {
+		return "", phrerror.Errorf(phrerror.Forbidden, "Client identity names no patient in attribute %s", PatientAttribute)
+	}
+
+	return patient, nil
+}
@@ -0,0 +1,169 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// #########
+// HELPERS
+// #########
+
+type MockClientIdentity struct {
+	mock.Mock
+}
+
+func (mci *MockClientIdentity) GetID() (string, error) {
+	args := mci.Called()
+
+	return args.String(0), args.Error(1)
+}
+
+func (mci *MockClientIdentity) GetMSPID() (string, error) {
+	args := mci.Called()
+
+	return args.String(0), args.Error(1)
+}
+
+func (mci *MockClientIdentity) GetAttributeValue(attrName string) (string, bool, error) {
+	args := mci.Called(attrName)
+
+	return args.String(0), args.Bool(1), args.Error(2)
+}
+
+func (mci *MockClientIdentity) AssertAttributeValue(attrName string, attrValue string) error {
+	args := mci.Called(attrName, attrValue)
+
+	return args.Error(0)
+}
+
+func (mci *MockClientIdentity) GetX509Certificate() (*x509.Certificate, error) {
+	args := mci.Called()
+
+	return args.Get(0).(*x509.Certificate), args.Error(1)
+}
+
+func newMockClientIdentity(mspID string, owner string) *MockClientIdentity {
+	mci := new(MockClientIdentity)
+	mci.On("GetMSPID").Return(mspID, nil)
+	mci.On("GetAttributeValue", OwnerAttribute).Return(owner, owner != "", nil)
+	mci.On("GetAttributeValue", RoleAttribute).Return("", false, nil)
+
+	return mci
+}
+
+// #########
+// TESTS
+// #########
+
+func TestGetOwner(t *testing.T) {
+	var owner string
+	var err error
+
+	im := DefaultSettings().NewIdentityMapper()
+
+	owner, err = im.GetOwner(nil)
+	assert.EqualError(t, err, "No client identity for transaction", "should error when no client identity")
+	assert.Equal(t, "", owner, "should not return owner when no client identity")
+
+	mci := new(MockClientIdentity)
+	mci.On("GetMSPID").Return("", errors.New("GetMSPID error"))
+	owner, err = im.GetOwner(mci)
+	assert.EqualError(t, err, "GetMSPID error", "should error when GetMSPID errors")
+	assert.Equal(t, "", owner, "should not return owner when GetMSPID errors")
+
+	mci = new(MockClientIdentity)
+	mci.On("GetMSPID").Return("Org2MSP", nil)
+	mci.On("GetAttributeValue", OwnerAttribute).Return("", false, errors.New("GetAttributeValue error"))
+	owner, err = im.GetOwner(mci)
+	assert.EqualError(t, err, "GetAttributeValue error", "should error when GetAttributeValue errors")
+	assert.Equal(t, "", owner, "should not return owner when GetAttributeValue errors")
+
+	owner, err = im.GetOwner(newMockClientIdentity("Org2MSP", "somehospital"))
+	assertCodedError(t, err, phrerror.Forbidden, "Owner somehospital is not a member of MSP Org2MSP", "should error when owner attribute not a member of MSP")
+	assert.Equal(t, "", owner, "should not return owner when owner attribute not a member of MSP")
+
+	owner, err = im.GetOwner(newMockClientIdentity("Org1MSP", "hospital"))
+	assertCodedError(t, err, phrerror.Forbidden, "Owner hospital is not a member of MSP Org1MSP", "should error when owner attribute names owner of another MSP")
+	assert.Equal(t, "", owner, "should not return owner of another MSP")
+
+	im.Members["Org2MSP"] = []string{"somehospital"}
+
+	owner, err = im.GetOwner(newMockClientIdentity("Org2MSP", "somehospital"))
+	assert.Nil(t, err, "should not error when owner attribute a member of MSP")
+	assert.Equal(t, "somehospital", owner, "should use owner attribute when a member of MSP")
+
+	owner, err = im.GetOwner(newMockClientIdentity("Org2MSP", "hospital"))
+	assert.Nil(t, err, "should not error when owner attribute the owner of MSP")
+	assert.Equal(t, "hospital", owner, "should use owner attribute when the owner of MSP")
+
+	owner, err = im.GetOwner(newMockClientIdentity("Org1MSP", "somehospital"))
+	assertCodedError(t, err, phrerror.Forbidden, "Owner somehospital is not a member of MSP Org1MSP", "should error when owner attribute names member of another MSP")
+	assert.Equal(t, "", owner, "should not return member of another MSP")
+
+	owner, err = im.GetOwner(newMockClientIdentity("Org2MSP", ""))
+	assert.Nil(t, err, "should not error when MSP mapped")
+	assert.Equal(t, "hospital", owner, "should map MSP ID when owner attribute not set")
+
+	owner, err = im.GetOwner(newMockClientIdentity("Org1MSP", ""))
+	assert.Nil(t, err, "should not error when MSP mapped")
+	assert.Equal(t, "institute", owner, "should map MSP ID when owner attribute not set")
+
+	owner, err = im.GetOwner(newMockClientIdentity("Org3MSP", ""))
+	assertCodedError(t, err, phrerror.Forbidden, "No owner mapped for MSP Org3MSP", "should error when MSP not mapped")
+	assert.Equal(t, "", owner, "should not return owner when MSP not mapped")
+}
+
+func TestGetMSPID(t *testing.T) {
+	var mspID string
+	var err error
+
+	im := DefaultSettings().NewIdentityMapper()
+
+	mspID, err = im.GetMSPID("hospital")
+	assert.Nil(t, err, "should not error when owner mapped")
+	assert.Equal(t, "Org2MSP", mspID, "should return MSP ID mapped to owner")
+
+	mspID, err = im.GetMSPID("somehospital")
+	assertCodedError(t, err, phrerror.Forbidden, "No MSP mapped for owner somehospital", "should error when owner not mapped")
+	assert.Equal(t, "", mspID, "should not return MSP ID when owner not mapped")
+
+	im.Owners["Org3MSP"] = "hospital"
+	mspID, _ = im.GetMSPID("hospital")
+	assert.Equal(t, "Org2MSP", mspID, "should return first MSP ID in order when owner mapped to several")
+
+	im.Members["Org4MSP"] = []string{"somehospital"}
+	mspID, err = im.GetMSPID("somehospital")
+	assert.Nil(t, err, "should not error when owner a member")
+	assert.Equal(t, "Org4MSP", mspID, "should return MSP ID owner is a member of")
+}
+
+func TestGetPatient(t *testing.T) {
+	var patient string
+	var err error
+
+	patient, err = getPatient(nil)
+	assert.EqualError(t, err, "No client identity for transaction", "should error when no client identity")
+	assert.Equal(t, "", patient, "should not return patient when no client identity")
+
+	patient, err = getPatient(&FakeClientIdentity{MSPID: "Org2MSP", Err: errors.New("client identity error")})
+	assert.EqualError(t, err, "client identity error", "should error when client identity errors")
+	assert.Equal(t, "", patient, "should not return patient when client identity errors")
+
+	patient, err = getPatient(&FakeClientIdentity{MSPID: "Org2MSP", Attributes: map[string]string{PatientAttribute: ""}})
+	assertCodedError(t, err, phrerror.Forbidden, "Client identity names no patient in attribute phr.patient", "should error when patient attribute empty")
+	assert.Equal(t, "", patient, "should not return patient when patient attribute empty")
+
+	patient, err = getPatient(&FakeClientIdentity{MSPID: "Org2MSP", Attributes: map[string]string{PatientAttribute: "somepatient"}})
+	assert.Nil(t, err, "should not error when patient attribute set")
+	assert.Equal(t, "somepatient", patient, "should return patient attribute")
+}
@@ -0,0 +1,49 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Listing defines an offer by the owner of
+// a phr to sell it at an asking price
+type Listing struct {
+	Issuer         string `json:"issuer"`
+	PHRNumber      string `json:"phrNumber"`
+	Seller         string `json:"seller"`
+	AskPrice       int    `json:"askPrice"`
+	ListedDateTime string `json:"listedDateTime"`
+	ExpiresAt      string `json:"expiresAt"`
+}
+
+// IsActive returns true if the listing is by the passed
+// owner and has not expired at the passed time
+func (listing *Listing) IsActive(owner string, t time.Time) bool {
+	return listing.Seller == owner && listing.ExpiresAt > FormatDateTime(t)
+}
+
+// GetSplitKey returns values which should be used to form key
+func (listing *Listing) GetSplitKey() []string {
+	return []string{listing.Issuer, listing.PHRNumber}
+}
+
+// Serialize formats the listing as JSON bytes
+func (listing *Listing) Serialize() ([]byte, error) {
+	return json.Marshal(listing)
+}
+
+// DeserializeListing formats the listing from JSON bytes
+func DeserializeListing(bytes []byte, listing *Listing) error {
+	err := json.Unmarshal(bytes, listing)
+
+	if err != nil {
+		return fmt.Errorf("Error deserializing listing. %s", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListingIsActive(t *testing.T) {
+	listing := &Listing{Seller: "someowner", ExpiresAt: "2021-12-11T10:00:00Z"}
+
+	assert.True(t, listing.IsActive("someowner", mockTxTime), "should be active for seller before expiry")
+	assert.False(t, listing.IsActive("someotherowner", mockTxTime), "should not be active when seller no longer owns phr")
+	assert.False(t, listing.IsActive("someowner", mockTxTime.Add(24*time.Hour)), "should not be active at expiry")
+	assert.False(t, listing.IsActive("someowner", mockTxTime.Add(48*time.Hour)), "should not be active after expiry")
+}
+
+func TestListingGetSplitKey(t *testing.T) {
+	listing := &Listing{Issuer: "someissuer", PHRNumber: "somephr"}
+
+	assert.Equal(t, []string{"someissuer", "somephr"}, listing.GetSplitKey(), "should return issuer and phr number as split key")
+}
+
+func TestListingSerialize(t *testing.T) {
+	listing := &Listing{Issuer: "someissuer", PHRNumber: "somephr", Seller: "someowner", AskPrice: 100, ListedDateTime: "2021-12-10T10:00:00Z", ExpiresAt: "2021-12-11T10:00:00Z"}
+
+	bytes, err := listing.Serialize()
+	assert.Nil(t, err, "should not error on serialize")
+	assert.Equal(t, `{"issuer":"someissuer","phrNumber":"somephr","seller":"someowner","askPrice":100,"listedDateTime":"2021-12-10T10:00:00Z","expiresAt":"2021-12-11T10:00:00Z"}`, string(bytes), "should return JSON formatted value")
+}
+
+func TestDeserializeListing(t *testing.T) {
+	var listing *Listing
+	var err error
+
+	listing = new(Listing)
+	err = DeserializeListing([]byte(`{"issuer":"someissuer","phrNumber":"somephr","seller":"someowner","askPrice":100}`), listing)
+	assert.Nil(t, err, "should not return error for deserialize")
+	assert.Equal(t, &Listing{Issuer: "someissuer", PHRNumber: "somephr", Seller: "someowner", AskPrice: 100}, listing, "should create expected listing")
+
+	listing = new(Listing)
+	err = DeserializeListing([]byte(`{"askPrice":"NaN"}`), listing)
+	assert.EqualError(t, err, "Error deserializing listing. json: cannot unmarshal string into Go struct field Listing.askPrice of type int", "should return error for bad data")
+}
@@ -0,0 +1,115 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"fmt"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+)
+
+// List offers a phr for sale at the asking price until expiresAt.
+// Replaces any existing listing of the phr. Submitted by the owner
+func (c *Contract) List(ctx TransactionContextInterface, issuer string, phrNumber string, askPrice int, expiresAt string) (*Listing, error) {
+	expiresAt, err := parseDateTime("expiresAt", expiresAt, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if askPrice <= 0 {
+		return nil, &ValidationError{Field: "askPrice", Value: fmt.Sprint(askPrice), Reason: "Must be greater than zero"}
+	}
+
+	phr, err := ctx.GetPHRList().GetPHR(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := ctx.GetCaller()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if phr.Owner != caller {
+		return nil, phrerror.Errorf(phrerror.NotOwner, "PHR %s:%s is not owned by submitter %s", issuer, phrNumber, caller)
+	}
+
+	if phr.IsExpired() {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s is already expired", issuer, phrNumber)
+	}
+
+	txTime, err := getTxTime(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if phr.IsMatured(txTime) {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s has matured. Maturity date time = %s", issuer, phrNumber, phr.MaturityDateTime)
+	}
+
+	listedDateTime := FormatDateTime(txTime)
+
+	if expiresAt <= listedDateTime {
+		return nil, &ValidationError{Field: "expiresAt", Value: expiresAt, Reason: "Must be after the listed date time " + listedDateTime}
+	}
+
+	listing := Listing{Issuer: issuer, PHRNumber: phrNumber, Seller: phr.Owner, AskPrice: askPrice, ListedDateTime: listedDateTime, ExpiresAt: expiresAt}
+
+	err = ctx.GetListingList().UpdateListing(&listing)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = setEvent(ctx, &PHREvent{Name: ListedEvent, Issuer: issuer, PHRNumber: phrNumber, PreviousOwner: phr.Owner, NewOwner: phr.Owner, Price: askPrice, PreviousState: phr.GetState(), NewState: phr.GetState()})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &listing, nil
+}
+
+// Delist withdraws the listing of a phr. Submitted by the seller
+func (c *Contract) Delist(ctx TransactionContextInterface, issuer string, phrNumber string) (*Listing, error) {
+	listing, err := ctx.GetListingList().GetListing(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := ctx.GetCaller()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if listing.Seller != caller {
+		return nil, phrerror.Errorf(phrerror.NotOwner, "Listing of PHR %s:%s is not by submitter %s", issuer, phrNumber, caller)
+	}
+
+	err = ctx.GetListingList().DeleteListing(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = setEvent(ctx, &PHREvent{Name: DelistedEvent, Issuer: issuer, PHRNumber: phrNumber, PreviousOwner: listing.Seller, NewOwner: listing.Seller, Price: listing.AskPrice})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return listing, nil
+}
+
+// GetListing returns the listing of a phr
+func (c *Contract) GetListing(ctx TransactionContextInterface, issuer string, phrNumber string) (*Listing, error) {
+	return ctx.GetListingList().GetListing(issuer, phrNumber)
+}
@@ -0,0 +1,139 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"errors"
+	"testing"
+
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestList(t *testing.T) {
+	var listing *Listing
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mpl := ctx.phrList
+	mll := ctx.listingList
+
+	contract := new(Contract)
+
+	wsPHR := new(PHR)
+	resetPHR(wsPHR)
+	wsPHR.MaturityDateTime = "2022-12-10T10:00:00Z"
+
+	var emptyPHR *PHR
+	var sentListing *Listing
+	shouldError := false
+
+	mpl.On("GetPHR", "someissuer", "somephr").Return(wsPHR, nil)
+	mpl.On("GetPHR", "someotherissuer", "someotherphr").Return(emptyPHR, errors.New("GetPHR error"))
+	mll.On("UpdateListing", mock.MatchedBy(func(listing *Listing) bool { return shouldError })).Return(errors.New("UpdateListing error"))
+	mll.On("UpdateListing", mock.MatchedBy(func(listing *Listing) bool { sentListing = listing; return !shouldError })).Return(nil)
+
+	listing, err = contract.List(ctx, "someissuer", "somephr", 100, "2021-12-11")
+	assertCodedError(t, err, phrerror.Validation, `Invalid expiresAt "2021-12-11". Expected an RFC 3339 date time`, "should error when expires at does not parse")
+	assert.Nil(t, listing, "should not return listing when expires at does not parse")
+
+	listing, err = contract.List(ctx, "someissuer", "somephr", 0, "2021-12-11T10:00:00Z")
+	assertCodedError(t, err, phrerror.Validation, `Invalid askPrice "0". Must be greater than zero`, "should error when ask price not positive")
+	assert.Nil(t, listing, "should not return listing when ask price not positive")
+
+	listing, err = contract.List(ctx, "someotherissuer", "someotherphr", 100, "2021-12-11T10:00:00Z")
+	assert.EqualError(t, err, "GetPHR error", "should error when GetPHR errors")
+	assert.Nil(t, listing, "should not return listing when GetPHR errors")
+
+	otherCtx := newMockTransactionContext("someotherowner")
+	otherCtx.phrList = mpl
+	listing, err = contract.List(otherCtx, "someissuer", "somephr", 100, "2021-12-11T10:00:00Z")
+	assertCodedError(t, err, phrerror.NotOwner, "PHR someissuer:somephr is not owned by submitter someotherowner", "should error when submitter not owner")
+	assert.Nil(t, listing, "should not return listing when submitter not owner")
+
+	wsPHR.SetExpired()
+	listing, err = contract.List(ctx, "someissuer", "somephr", 100, "2021-12-11T10:00:00Z")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:somephr is already expired", "should error when phr expired")
+	assert.Nil(t, listing, "should not return listing when phr expired")
+	resetPHR(wsPHR)
+
+	wsPHR.MaturityDateTime = "2021-12-10T10:00:00Z"
+	listing, err = contract.List(ctx, "someissuer", "somephr", 100, "2021-12-11T10:00:00Z")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:somephr has matured. Maturity date time = 2021-12-10T10:00:00Z", "should error when phr matured")
+	assert.Nil(t, listing, "should not return listing when phr matured")
+	wsPHR.MaturityDateTime = "2022-12-10T10:00:00Z"
+
+	listing, err = contract.List(ctx, "someissuer", "somephr", 100, "2021-12-10T10:00:00Z")
+	assertCodedError(t, err, phrerror.Validation, `Invalid expiresAt "2021-12-10T10:00:00Z". Must be after the listed date time 2021-12-10T10:00:00Z`, "should error when expires at not after tx time")
+	assert.Nil(t, listing, "should not return listing when expires at not after tx time")
+
+	shouldError = true
+	listing, err = contract.List(ctx, "someissuer", "somephr", 100, "2021-12-11T10:00:00Z")
+	assert.EqualError(t, err, "UpdateListing error", "should error when UpdateListing errors")
+	assert.Nil(t, listing, "should not return listing when UpdateListing errors")
+	shouldError = false
+
+	listing, err = contract.List(ctx, "someissuer", "somephr", 100, "2021-12-11T10:00:00Z")
+	assert.Nil(t, err, "should not error on good list")
+	assert.Equal(t, &Listing{Issuer: "someissuer", PHRNumber: "somephr", Seller: "someowner", AskPrice: 100, ListedDateTime: "2021-12-10T10:00:00Z", ExpiresAt: "2021-12-11T10:00:00Z"}, listing, "should return listing by owner")
+	assert.Equal(t, sentListing, listing, "should put same listing as it returns in the world state")
+	assert.Equal(t, []*PHREvent{{Version: EventVersion, Name: ListedEvent, Issuer: "someissuer", PHRNumber: "somephr", PreviousOwner: "someowner", NewOwner: "someowner", Price: 100, PreviousState: TRADING, NewState: TRADING, Timestamp: "2021-12-10T10:00:00Z"}}, getEvents(ctx), "should emit listed event")
+}
+
+func TestDelist(t *testing.T) {
+	var listing *Listing
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mll := ctx.listingList
+
+	contract := new(Contract)
+
+	wsListing := &Listing{Issuer: "someissuer", PHRNumber: "somephr", Seller: "someowner", AskPrice: 100}
+	badListing := &Listing{Issuer: "someissuer", PHRNumber: "somebadphr", Seller: "someowner", AskPrice: 100}
+
+	var emptyListing *Listing
+	mll.On("GetListing", "someissuer", "somephr").Return(wsListing, nil)
+	mll.On("GetListing", "someissuer", "somebadphr").Return(badListing, nil)
+	mll.On("GetListing", "someissuer", "someunlistedphr").Return(emptyListing, &ledgerapi.StateNotFoundError{Key: "someissuer:someunlistedphr"})
+	mll.On("DeleteListing", "someissuer", "somephr").Return(nil)
+	mll.On("DeleteListing", "someissuer", "somebadphr").Return(errors.New("DeleteListing error"))
+
+	listing, err = contract.Delist(ctx, "someissuer", "someunlistedphr")
+	assertCodedError(t, err, phrerror.NotFound, "No state found for someissuer:someunlistedphr", "should error when phr not listed")
+	assert.Nil(t, listing, "should not return listing when phr not listed")
+
+	otherCtx := newMockTransactionContext("someotherowner")
+	otherCtx.listingList = mll
+	listing, err = contract.Delist(otherCtx, "someissuer", "somephr")
+	assertCodedError(t, err, phrerror.NotOwner, "Listing of PHR someissuer:somephr is not by submitter someotherowner", "should error when submitter not seller")
+	assert.Nil(t, listing, "should not return listing when submitter not seller")
+
+	listing, err = contract.Delist(ctx, "someissuer", "somebadphr")
+	assert.EqualError(t, err, "DeleteListing error", "should error when DeleteListing errors")
+	assert.Nil(t, listing, "should not return listing when DeleteListing errors")
+
+	listing, err = contract.Delist(ctx, "someissuer", "somephr")
+	assert.Nil(t, err, "should not error on good delist")
+	assert.Equal(t, wsListing, listing, "should return withdrawn listing")
+	mll.AssertCalled(t, "DeleteListing", "someissuer", "somephr")
+	assert.Equal(t, []*PHREvent{{Version: EventVersion, Name: DelistedEvent, Issuer: "someissuer", PHRNumber: "somephr", PreviousOwner: "someowner", NewOwner: "someowner", Price: 100, Timestamp: "2021-12-10T10:00:00Z"}}, getEvents(ctx), "should emit delisted event")
+}
+
+func TestGetListing(t *testing.T) {
+	ctx := newMockTransactionContext("someowner")
+	mll := ctx.listingList
+
+	contract := new(Contract)
+
+	wsListing := &Listing{Issuer: "someissuer", PHRNumber: "somephr", Seller: "someowner", AskPrice: 100}
+	mll.On("GetListing", "someissuer", "somephr").Return(wsListing, nil)
+
+	listing, err := contract.GetListing(ctx, "someissuer", "somephr")
+	assert.Nil(t, err, "should not error when GetListing does not error")
+	assert.Equal(t, wsListing, listing, "should return listing from listing list")
+}
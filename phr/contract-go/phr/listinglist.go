@@ -0,0 +1,63 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+
+// ListingListInterface defines functionality needed
+// to interact with the world state on behalf
+// of a listing
+type ListingListInterface interface {
+	GetListing(string, string) (*Listing, error)
+	UpdateListing(*Listing) error
+	DeleteListing(string, string) error
+}
+
+// listingListName namespace of listings in world state
+const listingListName = "org.phrnet.listinglist"
+
+type listingList struct {
+	stateList ledgerapi.StateListInterface
+}
+
+// GetListing returns the listing of a phr. Returns a
+// ledgerapi.StateNotFoundError when the phr is not listed
+func (ll *listingList) GetListing(issuer string, phrNumber string) (*Listing, error) {
+	listing := new(Listing)
+
+	err := ll.stateList.GetState(CreatePHRKey(issuer, phrNumber), listing)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return listing, nil
+}
+
+// UpdateListing puts the listing of a phr, replacing
+// any existing listing
+func (ll *listingList) UpdateListing(listing *Listing) error {
+	return ll.stateList.UpdateState(listing)
+}
+
+// DeleteListing removes the listing of a phr
+func (ll *listingList) DeleteListing(issuer string, phrNumber string) error {
+	return ll.stateList.DeleteState(CreatePHRKey(issuer, phrNumber))
+}
+
+// newListingList create a new listing list from context
+func newListingList(ctx TransactionContextInterface) *listingList {
+	stateList := new(ledgerapi.StateList)
+	stateList.Ctx = ctx
+	stateList.Name = listingListName
+	stateList.Deserialize = func(bytes []byte, state ledgerapi.StateInterface) error {
+		return DeserializeListing(bytes, state.(*Listing))
+	}
+
+	list := new(listingList)
+	list.stateList = stateList
+
+	return list
+}
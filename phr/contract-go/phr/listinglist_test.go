@@ -0,0 +1,69 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"errors"
+	"testing"
+
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetListingFromList(t *testing.T) {
+	var listing *Listing
+	var err error
+
+	list := new(listingList)
+	msl := new(MockStateList)
+	msl.On("GetState", CreatePHRKey("someissuer", "somephr"), mock.MatchedBy(func(state ledgerapi.StateInterface) bool { _, ok := state.(*Listing); return ok })).Return(nil)
+	msl.On("GetState", CreatePHRKey("someotherissuer", "someotherphr"), mock.MatchedBy(func(state ledgerapi.StateInterface) bool { _, ok := state.(*Listing); return ok })).Return(errors.New("GetState error"))
+	list.stateList = msl
+
+	listing, err = list.GetListing("someissuer", "somephr")
+	assert.Nil(t, err, "should not error when get state on state list does not error")
+	assert.Equal(t, "somephr", listing.PHRNumber, "should use state list GetState to fill listing")
+
+	listing, err = list.GetListing("someotherissuer", "someotherphr")
+	assert.EqualError(t, err, "GetState error", "should return error when state list get state errors")
+	assert.Nil(t, listing, "should not return listing on error")
+}
+
+func TestUpdateListing(t *testing.T) {
+	listing := new(Listing)
+
+	list := new(listingList)
+	msl := new(MockStateList)
+	msl.On("UpdateState", listing).Return(errors.New("Called update state correctly"))
+	list.stateList = msl
+
+	err := list.UpdateListing(listing)
+	assert.EqualError(t, err, "Called update state correctly", "should call state list update state with listing")
+}
+
+func TestDeleteListing(t *testing.T) {
+	list := new(listingList)
+	msl := new(MockStateList)
+	msl.On("DeleteState", CreatePHRKey("someissuer", "somephr")).Return(errors.New("Called delete state correctly"))
+	list.stateList = msl
+
+	err := list.DeleteListing("someissuer", "somephr")
+	assert.EqualError(t, err, "Called delete state correctly", "should call state list delete state with phr key")
+}
+
+func TestNewListingList(t *testing.T) {
+	ctx := new(TransactionContext)
+	list := newListingList(ctx)
+	stateList, ok := list.stateList.(*ledgerapi.StateList)
+
+	assert.True(t, ok, "should make statelist of type ledgerapi.StateList")
+	assert.Equal(t, ctx, stateList.Ctx, "should set the context to passed context")
+	assert.Equal(t, "org.phrnet.listinglist", stateList.Name, "should set the name for the list")
+
+	expectedErr := DeserializeListing([]byte("bad json"), new(Listing))
+	err := stateList.Deserialize([]byte("bad json"), new(Listing))
+	assert.EqualError(t, err, expectedErr.Error(), "should call DeserializeListing when stateList.Deserialize called")
+}
@@ -0,0 +1,371 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"unicode"
+
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+)
+
+// Invocation describes the transaction middleware runs for. Values
+// holds state a middleware keeps from Before to After
+type Invocation struct {
+	Name   string
+	Args   []string
+	Result interface{}
+	Values map[string]interface{}
+}
+
+// Middleware a cross-cutting policy run by the transaction hooks of
+// the contract for the named transactions, or for all transactions
+// when none are named. Before runs before the transaction, After once
+// it succeeded and Unknown for a transaction the contract does not
+// define. Any of them may be nil
+type Middleware struct {
+	Name         string
+	Transactions []string
+	Before       func(TransactionContextInterface, *Invocation) error
+	After        func(TransactionContextInterface, *Invocation) error
+	Unknown      func(TransactionContextInterface, *Invocation) error
+}
+
+func (m *Middleware) appliesTo(name string) bool {
+	if len(m.Transactions) == 0 {
+		return true
+	}
+
+	for _, transaction := range m.Transactions {
+		if transaction == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Chain ordered middleware. Before and Unknown run in the order the
+// middleware was added and After in reverse order, so each middleware
+// wraps the middleware added after it. The first error stops the chain
+// and fails the transaction
+type Chain struct {
+	middleware []*Middleware
+}
+
+// NewChain create a new chain of the passed middleware
+func NewChain(middleware ...*Middleware) *Chain {
+	return new(Chain).Use(middleware...)
+}
+
+// Use adds middleware to the end of the chain and returns the chain
+func (c *Chain) Use(middleware ...*Middleware) *Chain {
+	c.middleware = append(c.middleware, middleware...)
+
+	return c
+}
+
+// Before is the before transaction hook of the contract. Records the
+// invocation of the transaction on the context and runs Before of
+// the middleware
+func (c *Chain) Before(ctx TransactionContextInterface) error {
+	fn, args := ctx.GetStub().GetFunctionAndParameters()
+	inv := &Invocation{Name: transactionName(fn), Args: args, Values: map[string]interface{}{}}
+	ctx.SetInvocation(inv)
+
+	for _, m := range c.middleware {
+		if m.Before != nil && m.appliesTo(inv.Name) {
+			err := m.Before(ctx, inv)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// After is the after transaction hook of the contract. Sets the
+// result of the transaction on its invocation and runs After of the
+// middleware in reverse order
+func (c *Chain) After(ctx TransactionContextInterface, result interface{}) error {
+	inv, err := getInvocation(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	inv.Result = result
+
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		m := c.middleware[i]
+
+		if m.After != nil && m.appliesTo(inv.Name) {
+			err := m.After(ctx, inv)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Unknown is the unknown transaction hook of the contract. Runs
+// Unknown of the middleware then fails the transaction
+func (c *Chain) Unknown(ctx TransactionContextInterface) error {
+	inv, err := getInvocation(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	for _, m := range c.middleware {
+		if m.Unknown != nil && m.appliesTo(inv.Name) {
+			err := m.Unknown(ctx, inv)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return phrerror.Errorf(phrerror.NotFound, "Unknown transaction %s", inv.Name)
+}
+
+func getInvocation(ctx TransactionContextInterface) (*Invocation, error) {
+	inv := ctx.GetInvocation()
+
+	if inv == nil {
+		return nil, fmt.Errorf("No invocation for transaction. The before transaction hook did not run")
+	}
+
+	return inv, nil
+}
+
+// transactionName returns the name of the transaction called, without
+// the contract namespace and capitalised as the contract API does
+func transactionName(fn string) string {
+	name := []rune(fn[strings.LastIndex(fn, ":")+1:])
+
+	if len(name) > 0 {
+		name[0] = unicode.ToUpper(name[0])
+	}
+
+	return string(name)
+}
+
+// Authorization middleware requiring the submitting client
+// to hold one of the roles declared for the transaction
+func Authorization(roles map[string][]Role) *Middleware {
+	transactions := []string{}
+
+	for name := range roles {
+		transactions = append(transactions, name)
+	}
+
+	sort.Strings(transactions)
+
+	return &Middleware{
+		Name:         "authorization",
+		Transactions: transactions,
+		Before: func(ctx TransactionContextInterface, inv *Invocation) error {
+			return ctx.Authorize(roles[inv.Name]...)
+		},
+	}
+}
+
+// Validation middleware requiring the leading arguments
+// named for the transaction to be set
+func Validation(required map[string][]string) *Middleware {
+	transactions := []string{}
+
+	for name := range required {
+		transactions = append(transactions, name)
+	}
+
+	sort.Strings(transactions)
+
+	return &Middleware{
+		Name:         "validation",
+		Transactions: transactions,
+		Before: func(ctx TransactionContextInterface, inv *Invocation) error {
+			for i, field := range required[inv.Name] {
+				if i >= len(inv.Args) || inv.Args[i] == "" {
+					return &ValidationError{Field: field, Value: "", Reason: "Value is required"}
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// AuditLog middleware logging the identity submitting each
+// transaction and the transactions that succeed. Entries go to the
+// logger of each endorsing peer, not the ledger, so DefaultChain
+// leaves it out
+func AuditLog(logger *log.Logger) *Middleware {
+	return &Middleware{
+		Name: "audit",
+		Before: func(ctx TransactionContextInterface, inv *Invocation) error {
+			ci := ctx.GetClientIdentity()
+
+			if ci == nil {
+				return fmt.Errorf("No client identity for transaction")
+			}
+
+			id, err := ci.GetID()
+
+			if err != nil {
+				return err
+			}
+
+			mspID, err := ci.GetMSPID()
+
+			if err != nil {
+				return err
+			}
+
+			logger.Printf("%s %s submitted by %s of %s", ctx.GetStub().GetTxID(), inv.Name, id, mspID)
+
+			return nil
+		},
+		After: func(ctx TransactionContextInterface, inv *Invocation) error {
+			logger.Printf("%s %s succeeded", ctx.GetStub().GetTxID(), inv.Name)
+
+			return nil
+		},
+		Unknown: func(ctx TransactionContextInterface, inv *Invocation) error {
+			logger.Printf("%s %s is unknown", ctx.GetStub().GetTxID(), inv.Name)
+
+			return nil
+		},
+	}
+}
+
+// PHREvents middleware emitting the event named for the transaction
+// once it returns the phr it changed. The phr keyed by the issuer and
+// phr number arguments is read first for its previous owner and state
+func PHREvents(events map[string]string) *Middleware {
+	transactions := []string{}
+
+	for name := range events {
+		transactions = append(transactions, name)
+	}
+
+	sort.Strings(transactions)
+
+	return &Middleware{
+		Name:         "events",
+		Transactions: transactions,
+		Before: func(ctx TransactionContextInterface, inv *Invocation) error {
+			if len(inv.Args) < 2 {
+				return fmt.Errorf("Transaction %s does not name a phr", inv.Name)
+			}
+
+			previous, err := ctx.GetPHRList().GetPHR(inv.Args[0], inv.Args[1])
+
+			if _, ok := err.(*ledgerapi.StateNotFoundError); ok {
+				return nil
+			} else if err != nil {
+				return err
+			}
+
+			inv.Values["previousPHR"] = previous
+
+			return nil
+		},
+		After: func(ctx TransactionContextInterface, inv *Invocation) error {
+			phr, ok := inv.Result.(*PHR)
+
+			if !ok || phr == nil {
+				return fmt.Errorf("Transaction %s did not return a phr", inv.Name)
+			}
+
+			event := &PHREvent{Name: events[inv.Name], Issuer: phr.Issuer, PHRNumber: phr.PHRNumber, NewOwner: phr.Owner, NewState: phr.GetState()}
+
+			if previous, ok := inv.Values["previousPHR"].(*PHR); ok {
+				event.PreviousOwner = previous.Owner
+				event.PreviousState = previous.GetState()
+			}
+
+			return setEvent(ctx, event)
+		},
+	}
+}
+
+// DefaultChain returns the middleware the contract runs when none
+// is set. It authorizes every transaction of the contract by role, so
+// the transactions themselves do not call Authorize, validates the
+// phr key and owner arguments of Issue, Buy, Expire and ExpireMatured,
+// and emits the issued and expired events
+func DefaultChain() *Chain {
+	return NewChain(
+		Authorization(map[string][]Role{
+			"AcceptBid":                   {IssuerRole, BuyerRole},
+			"Approve":                     {IssuerRole, BuyerRole},
+			"BalanceOf":                   {IssuerRole, BuyerRole, AuditorRole},
+			"Buy":                         {BuyerRole},
+			"CancelBid":                   {BuyerRole},
+			"CloseAuction":                {IssuerRole, BuyerRole},
+			"ClaimRoyalties":              {PatientRole},
+			"CommitBid":                   {BuyerRole},
+			"Delist":                      {IssuerRole, BuyerRole},
+			"Expire":                      {IssuerRole, BuyerRole},
+			"ExpireMatured":               {IssuerRole, BuyerRole},
+			"GetAllowance":                {IssuerRole, BuyerRole, AuditorRole},
+			"GetAuction":                  {IssuerRole, BuyerRole, AuditorRole},
+			"GetBids":                     {IssuerRole, BuyerRole, AuditorRole},
+			"GetConsent":                  {IssuerRole, BuyerRole, AuditorRole, PatientRole},
+			"GetListing":                  {IssuerRole, BuyerRole, AuditorRole},
+			"GetMaturedPHRs":              {IssuerRole, BuyerRole, AuditorRole},
+			"GetPatientRoyalties":         {PatientRole},
+			"GetPHRHistory":               {IssuerRole, BuyerRole, AuditorRole},
+			"GetPHRPrivateDetails":        {IssuerRole, BuyerRole},
+			"GetRoyalties":                {IssuerRole, BuyerRole, AuditorRole, PatientRole},
+			"GetRoyaltyRate":              {IssuerRole, BuyerRole, AuditorRole},
+			"GetSealedBids":               {IssuerRole, BuyerRole, AuditorRole},
+			"GetTradeHistory":             {IssuerRole, BuyerRole, AuditorRole},
+			"GetTradeStats":               {IssuerRole, BuyerRole, AuditorRole},
+			"GrantConsent":                {PatientRole},
+			"Issue":                       {IssuerRole},
+			"List":                        {IssuerRole, BuyerRole},
+			"Mint":                        {TokenAdminRole},
+			"OfferPrivateDetails":         {IssuerRole, BuyerRole},
+			"PlaceBid":                    {BuyerRole},
+			"QueryByIssuer":               {IssuerRole, BuyerRole, AuditorRole},
+			"QueryByIssuerWithPagination": {IssuerRole, BuyerRole, AuditorRole},
+			"QueryByOwnerWithPagination":  {IssuerRole, BuyerRole, AuditorRole},
+			"QueryByStateWithPagination":  {IssuerRole, BuyerRole, AuditorRole},
+			"QueryPHRs":                   {IssuerRole, BuyerRole, AuditorRole},
+			"QueryPHRsWithPagination":     {IssuerRole, BuyerRole, AuditorRole},
+			"RevealBid":                   {BuyerRole},
+			"RevokeConsent":               {PatientRole},
+			"SetRoyaltyRate":              {TokenAdminRole},
+			"StartAuction":                {IssuerRole, BuyerRole},
+			"Transfer":                    {IssuerRole, BuyerRole},
+			"TransferFrom":                {IssuerRole, BuyerRole},
+			"VerifyPayload":               {IssuerRole, BuyerRole, AuditorRole},
+		}),
+		Validation(map[string][]string{
+			"Buy":           {"issuer", "phrNumber", "currentOwner", "newOwner"},
+			"Expire":        {"issuer", "phrNumber", "expiringOwner"},
+			"ExpireMatured": {"issuer", "phrNumber"},
+			"Issue":         {"issuer", "phrNumber"},
+		}),
+		PHREvents(map[string]string{
+			"Expire":        ExpiredEvent,
+			"ExpireMatured": ExpiredEvent,
+			"Issue":         IssuedEvent,
+		}),
+	)
+}
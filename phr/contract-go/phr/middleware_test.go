@@ -0,0 +1,410 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"log"
+	"math/big"
+	"testing"
+	"time"
+
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+// #########
+// HELPERS
+// #########
+
+// MockInvocationStub mock stub invoked with a function and parameters
+type MockInvocationStub struct {
+	*shimtest.MockStub
+	function string
+	params   []string
+}
+
+func (mis *MockInvocationStub) GetFunctionAndParameters() (string, []string) {
+	return mis.function, mis.params
+}
+
+// newInvokedContext returns a mock transaction context
+// for the passed function called with the passed parameters
+func newInvokedContext(function string, params ...string) *MockTransactionContext {
+	ctx := newMockTransactionContext("someowner")
+	ctx.SetStub(&MockInvocationStub{MockStub: ctx.GetStub().(*shimtest.MockStub), function: function, params: params})
+
+	return ctx
+}
+
+// recordingMiddleware returns middleware recording the
+// hooks it runs to calls and failing with the passed error
+func recordingMiddleware(name string, calls *[]string, err error, transactions ...string) *Middleware {
+	return &Middleware{
+		Name:         name,
+		Transactions: transactions,
+		Before: func(ctx TransactionContextInterface, inv *Invocation) error {
+			*calls = append(*calls, name+" before "+inv.Name)
+			return err
+		},
+		After: func(ctx TransactionContextInterface, inv *Invocation) error {
+			*calls = append(*calls, name+" after "+inv.Name)
+			return err
+		},
+		Unknown: func(ctx TransactionContextInterface, inv *Invocation) error {
+			*calls = append(*calls, name+" unknown "+inv.Name)
+			return err
+		},
+	}
+}
+
+// newCreator returns the serialized identity of a client of
+// the MSP, as a peer passes it to the chaincode
+func newCreator(t *testing.T, mspID string) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err, "should generate key")
+
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "someuser"}, NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour)}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err, "should create certificate")
+
+	creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: mspID, IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert})})
+	assert.Nil(t, err, "should serialize identity")
+
+	return creator
+}
+
+// newContractStub returns a mock stub running the contract
+// through the contract API, invoked by a client of the MSP
+func newContractStub(t *testing.T, contract *Contract, mspID string) *shimtest.MockStub {
+	t.Helper()
+
+	contract.TransactionContextHandler = new(TransactionContext)
+	chaincode, err := contractapi.NewChaincode(contract)
+	assert.Nil(t, err, "should create chaincode")
+
+	stub := shimtest.NewMockStub("phr", chaincode)
+	stub.Creator = newCreator(t, mspID)
+
+	return stub
+}
+
+// invoke invokes the function of the stub with the passed parameters
+func invoke(stub *shimtest.MockStub, function string, params ...string) (int32, string) {
+	args := [][]byte{[]byte(function)}
+
+	for _, param := range params {
+		args = append(args, []byte(param))
+	}
+
+	response := stub.MockInvoke("sometx", args)
+
+	if response.Status != 200 {
+		return response.Status, response.Message
+	}
+
+	return response.Status, string(response.Payload)
+}
+
+// #########
+// TESTS
+// #########
+
+func TestTransactionName(t *testing.T) {
+	assert.Equal(t, "Issue", transactionName("Issue"), "should keep transaction name")
+	assert.Equal(t, "Issue", transactionName("issue"), "should capitalise transaction name")
+	assert.Equal(t, "Issue", transactionName("org.phrnet.phr:Issue"), "should strip contract namespace")
+	assert.Equal(t, "", transactionName(""), "should handle empty function")
+}
+
+func TestChainBefore(t *testing.T) {
+	var calls []string
+
+	chain := NewChain(recordingMiddleware("first", &calls, nil), recordingMiddleware("second", &calls, nil, "Buy"))
+	chain.Use(recordingMiddleware("third", &calls, nil))
+
+	ctx := newInvokedContext("org.phrnet.phr:Issue", "someissuer", "somephr")
+	err := chain.Before(ctx)
+	assert.Nil(t, err, "should not error when middleware does not")
+	assert.Equal(t, []string{"first before Issue", "third before Issue"}, calls, "should run middleware for the transaction in order")
+	assert.Equal(t, &Invocation{Name: "Issue", Args: []string{"someissuer", "somephr"}, Values: map[string]interface{}{}}, ctx.GetInvocation(), "should set invocation on context")
+
+	calls = nil
+	chain = NewChain(recordingMiddleware("first", &calls, errors.New("first error")), recordingMiddleware("second", &calls, nil))
+	err = chain.Before(newInvokedContext("Issue"))
+	assert.EqualError(t, err, "first error", "should error when middleware errors")
+	assert.Equal(t, []string{"first before Issue"}, calls, "should stop chain when middleware errors")
+}
+
+func TestChainAfter(t *testing.T) {
+	var calls []string
+
+	chain := NewChain(recordingMiddleware("first", &calls, nil), recordingMiddleware("second", &calls, nil, "Buy"), recordingMiddleware("third", &calls, nil))
+
+	ctx := newInvokedContext("Buy")
+	err := chain.After(ctx, "someresult")
+	assert.EqualError(t, err, "No invocation for transaction. The before transaction hook did not run", "should error when before hook did not run")
+	assert.Empty(t, calls, "should not run middleware when before hook did not run")
+
+	chain.Before(ctx)
+	calls = nil
+
+	err = chain.After(ctx, "someresult")
+	assert.Nil(t, err, "should not error when middleware does not")
+	assert.Equal(t, []string{"third after Buy", "second after Buy", "first after Buy"}, calls, "should run middleware in reverse order")
+	assert.Equal(t, "someresult", ctx.GetInvocation().Result, "should set result on invocation")
+
+	calls = nil
+	chain = NewChain(recordingMiddleware("first", &calls, nil), recordingMiddleware("second", &calls, errors.New("second error")))
+	ctx.SetInvocation(&Invocation{Name: "Buy"})
+	err = chain.After(ctx, nil)
+	assert.EqualError(t, err, "second error", "should error when middleware errors")
+	assert.Equal(t, []string{"second after Buy"}, calls, "should stop chain when middleware errors")
+}
+
+func TestChainUnknown(t *testing.T) {
+	var calls []string
+
+	chain := NewChain(recordingMiddleware("first", &calls, nil), recordingMiddleware("second", &calls, nil))
+
+	ctx := newInvokedContext("Somefunction")
+	err := chain.Unknown(ctx)
+	assert.EqualError(t, err, "No invocation for transaction. The before transaction hook did not run", "should error when before hook did not run")
+
+	ctx.SetInvocation(&Invocation{Name: "Somefunction"})
+	err = chain.Unknown(ctx)
+	assertCodedError(t, err, phrerror.NotFound, "Unknown transaction Somefunction", "should error for unknown transaction")
+	assert.Equal(t, []string{"first unknown Somefunction", "second unknown Somefunction"}, calls, "should run middleware in order")
+
+	calls = nil
+	chain = NewChain(recordingMiddleware("first", &calls, errors.New("first error")), recordingMiddleware("second", &calls, nil))
+	err = chain.Unknown(ctx)
+	assert.EqualError(t, err, "first error", "should error when middleware errors")
+	assert.Equal(t, []string{"first unknown Somefunction"}, calls, "should stop chain when middleware errors")
+}
+
+func TestAuthorization(t *testing.T) {
+	m := Authorization(map[string][]Role{"Issue": {IssuerRole}, "Buy": {BuyerRole}})
+	assert.Equal(t, []string{"Buy", "Issue"}, m.Transactions, "should apply to transactions with roles")
+
+	ctx := newMockTransactionContext("someowner")
+	ctx.role = BuyerRole
+
+	err := m.Before(ctx, &Invocation{Name: "Issue"})
+	assert.Equal(t, &PermissionError{Role: BuyerRole, Required: []Role{IssuerRole}}, err, "should error when role not allowed")
+
+	err = m.Before(ctx, &Invocation{Name: "Buy"})
+	assert.Nil(t, err, "should not error when role allowed")
+}
+
+func TestValidation(t *testing.T) {
+	m := Validation(map[string][]string{"Issue": {"issuer", "phrNumber"}})
+	assert.Equal(t, []string{"Issue"}, m.Transactions, "should apply to transactions with required arguments")
+
+	ctx := newMockTransactionContext("someowner")
+
+	err := m.Before(ctx, &Invocation{Name: "Issue", Args: []string{"someissuer"}})
+	assert.Equal(t, &ValidationError{Field: "phrNumber", Value: "", Reason: "Value is required"}, err, "should error when required argument missing")
+
+	err = m.Before(ctx, &Invocation{Name: "Issue", Args: []string{"", "somephr"}})
+	assertCodedError(t, err, phrerror.Validation, `Invalid issuer "". Value is required`, "should error when required argument empty")
+
+	err = m.Before(ctx, &Invocation{Name: "Issue", Args: []string{"someissuer", "somephr", ""}})
+	assert.Nil(t, err, "should not error when required arguments set")
+}
+
+func TestAuditLog(t *testing.T) {
+	var buf bytes.Buffer
+
+	m := AuditLog(log.New(&buf, "", 0))
+	assert.Empty(t, m.Transactions, "should apply to all transactions")
+
+	ctx := newMockTransactionContext("someowner")
+	ctx.SetClientIdentity(nil)
+	err := m.Before(ctx, &Invocation{Name: "Issue"})
+	assert.EqualError(t, err, "No client identity for transaction", "should error when no client identity")
+
+	ctx.SetClientIdentity(&FakeClientIdentity{MSPID: "Org2MSP", Err: errors.New("client identity error")})
+	err = m.Before(ctx, &Invocation{Name: "Issue"})
+	assert.EqualError(t, err, "client identity error", "should error when client identity errors")
+
+	ctx.SetClientIdentity(&FakeClientIdentity{MSPID: "Org2MSP"})
+	buf.Reset()
+	err = m.Before(ctx, &Invocation{Name: "Issue"})
+	assert.Nil(t, err, "should not error when client identity set")
+	assert.Equal(t, "sometx Issue submitted by x509::CN=someuser::CN=someca of Org2MSP\n", buf.String(), "should log submitting identity")
+
+	buf.Reset()
+	m.After(ctx, &Invocation{Name: "Issue"})
+	assert.Equal(t, "sometx Issue succeeded\n", buf.String(), "should log success")
+
+	buf.Reset()
+	m.Unknown(ctx, &Invocation{Name: "Somefunction"})
+	assert.Equal(t, "sometx Somefunction is unknown\n", buf.String(), "should log unknown transaction")
+}
+
+func TestPHREvents(t *testing.T) {
+	var err error
+
+	m := PHREvents(map[string]string{"Issue": IssuedEvent, "Expire": ExpiredEvent})
+	assert.Equal(t, []string{"Expire", "Issue"}, m.Transactions, "should apply to transactions with events")
+
+	ctx := newMockTransactionContext("someowner")
+	mpl := ctx.phrList
+
+	previousPHR := new(PHR)
+	previousPHR.Issuer = "someissuer"
+	previousPHR.PHRNumber = "somephr"
+	previousPHR.Owner = "someowner"
+	previousPHR.SetTrading()
+
+	mpl.On("GetPHR", "someissuer", "somephr").Return(previousPHR, nil)
+	mpl.On("GetPHR", "someissuer", "somenewphr").Return((*PHR)(nil), &ledgerapi.StateNotFoundError{Key: "someissuer:somenewphr"})
+	mpl.On("GetPHR", "someissuer", "someerrorphr").Return((*PHR)(nil), errors.New("GetPHR error"))
+
+	err = m.Before(ctx, &Invocation{Name: "Issue", Args: []string{"someissuer"}})
+	assert.EqualError(t, err, "Transaction Issue does not name a phr", "should error when phr not named")
+
+	err = m.Before(ctx, &Invocation{Name: "Issue", Args: []string{"someissuer", "someerrorphr"}, Values: map[string]interface{}{}})
+	assert.EqualError(t, err, "GetPHR error", "should error when get phr fails")
+
+	inv := &Invocation{Name: "Issue", Args: []string{"someissuer", "somenewphr"}, Values: map[string]interface{}{}}
+	err = m.Before(ctx, inv)
+	assert.Nil(t, err, "should not error when phr not yet issued")
+	assert.Empty(t, inv.Values, "should not keep previous phr when phr not yet issued")
+
+	err = m.After(ctx, inv)
+	assert.EqualError(t, err, "Transaction Issue did not return a phr", "should error when transaction returns no phr")
+	assert.Empty(t, getEvents(ctx), "should not emit event when transaction returns no phr")
+
+	issuedPHR := new(PHR)
+	issuedPHR.Issuer = "someissuer"
+	issuedPHR.PHRNumber = "somenewphr"
+	issuedPHR.Owner = "someissuer"
+	issuedPHR.SetIssued()
+	inv.Result = issuedPHR
+
+	err = m.After(ctx, inv)
+	assert.Nil(t, err, "should not error when transaction returns phr")
+	assert.Equal(t, []*PHREvent{{Version: EventVersion, Name: IssuedEvent, Issuer: "someissuer", PHRNumber: "somenewphr", NewOwner: "someissuer", NewState: ISSUED, Timestamp: "2021-12-10T10:00:00Z"}}, getEvents(ctx), "should emit issued event")
+
+	inv = &Invocation{Name: "Expire", Args: []string{"someissuer", "somephr", "someowner"}, Values: map[string]interface{}{}}
+	err = m.Before(ctx, inv)
+	assert.Nil(t, err, "should not error when phr exists")
+	assert.Equal(t, previousPHR, inv.Values["previousPHR"], "should keep previous phr")
+
+	expiredPHR := new(PHR)
+	expiredPHR.Issuer = "someissuer"
+	expiredPHR.PHRNumber = "somephr"
+	expiredPHR.SetExpired()
+	inv.Result = expiredPHR
+
+	err = m.After(ctx, inv)
+	assert.Nil(t, err, "should not error when transaction returns phr")
+	assert.Equal(t, []*PHREvent{{Version: EventVersion, Name: ExpiredEvent, Issuer: "someissuer", PHRNumber: "somephr", PreviousOwner: "someowner", NewOwner: "", PreviousState: TRADING, NewState: EXPIRED, Timestamp: "2021-12-10T10:00:00Z"}}, getEvents(ctx), "should emit expired event")
+}
+
+func TestContractHooks(t *testing.T) {
+	var calls []string
+
+	contract := new(Contract)
+	contract.GetAfterTransaction()
+	assert.NotNil(t, contract.Middleware, "should default middleware")
+	assert.Len(t, contract.Middleware.middleware, len(DefaultChain().middleware), "should default to default chain")
+
+	contract = new(Contract)
+	contract.Middleware = NewChain(recordingMiddleware("first", &calls, nil))
+	contract.Settings = &Settings{TokenAdminMSP: "someadminMSP"}
+
+	ctx := newInvokedContext("Issue")
+
+	before, ok := contract.GetBeforeTransaction().(func(TransactionContextInterface) error)
+	assert.True(t, ok, "should return before hook taking context")
+	after, ok := contract.GetAfterTransaction().(func(TransactionContextInterface, interface{}) error)
+	assert.True(t, ok, "should return after hook taking context and result")
+	unknown, ok := contract.GetUnknownTransaction().(func(TransactionContextInterface) error)
+	assert.True(t, ok, "should return unknown hook taking context")
+
+	before(ctx)
+	assert.Equal(t, contract.Settings, ctx.GetSettings(), "should set settings of contract on context before transaction")
+	after(ctx, nil)
+	unknown(ctx)
+	assert.Equal(t, []string{"first before Issue", "first after Issue", "first unknown Issue"}, calls, "should run hooks of middleware")
+}
+
+func TestContractChaincode(t *testing.T) {
+	var calls []string
+	var invocations []*Invocation
+
+	capture := &Middleware{
+		Name: "capture",
+		After: func(ctx TransactionContextInterface, inv *Invocation) error {
+			invocations = append(invocations, inv)
+			return nil
+		},
+	}
+
+	contract := new(Contract)
+	contract.Middleware = NewChain(recordingMiddleware("first", &calls, nil), Authorization(map[string][]Role{"Issue": {IssuerRole}}), recordingMiddleware("second", &calls, nil))
+	stub := newContractStub(t, contract, "Org1MSP")
+
+	status, message := invoke(stub, "somefunction", "somearg")
+	assert.Equal(t, int32(500), status, "should fail unknown transaction")
+	assertCodedError(t, errors.New(message), phrerror.NotFound, "Unknown transaction Somefunction", "should fail unknown transaction as not found")
+	assert.Equal(t, []string{"first before Somefunction", "second before Somefunction", "first unknown Somefunction", "second unknown Somefunction"}, calls, "should run before hooks ahead of unknown hooks and no after hooks")
+
+	calls = nil
+	status, message = invoke(stub, "issue", "hospital", "00001", "", "2099-01-01T00:00:00Z", "100")
+	assert.Equal(t, int32(500), status, "should fail transaction denied by middleware")
+	assert.Contains(t, message, "Permission denied for role buyer. Requires one of issuer", "should fail with error of middleware")
+	assert.Equal(t, []string{"first before Issue"}, calls, "should stop before hooks at error and run no transaction or after hooks")
+
+	contract = new(Contract)
+	contract.Middleware = DefaultChain().Use(capture)
+	stub = newContractStub(t, contract, "Org2MSP")
+
+	status, message = invoke(stub, "Issue", "hospital", "00001", "", "2099-01-01T00:00:00Z", "100", "", "", "", "")
+	assert.Equal(t, int32(200), status, "should issue phr "+message)
+	if assert.Len(t, invocations, 1, "should run after hooks once transaction succeeded") {
+		assert.Equal(t, []string{"hospital", "00001", "", "2099-01-01T00:00:00Z", "100", "", "", "", ""}, invocations[0].Args, "should pass arguments of transaction as sent")
+		phr, ok := invocations[0].Result.(*PHR)
+		assert.True(t, ok, "should pass phr returned by transaction as result")
+		assert.Equal(t, "00001", phr.PHRNumber, "should pass issued phr as result")
+	}
+
+	select {
+	case event := <-stub.ChaincodeEventsChannel:
+		assert.Equal(t, IssuedEvent, event.EventName, "should emit event in after hook from result")
+	default:
+		assert.Fail(t, "should emit event in after hook from result")
+	}
+
+	invocations = nil
+	status, message = invoke(stub, "GetRoyaltyRate")
+	assert.Equal(t, int32(200), status, "should get royalty rate "+message)
+	if assert.Len(t, invocations, 1, "should run after hooks for transaction without arguments") {
+		assert.Equal(t, []string{}, invocations[0].Args, "should pass no arguments")
+		assert.IsType(t, new(RoyaltyRate), invocations[0].Result, "should pass value returned by transaction as result")
+	}
+
+	invocations = nil
+	status, _ = invoke(stub, "OfferPrivateDetails", "hospital", "00001", "")
+	assert.Equal(t, int32(500), status, "should fail transaction returning error")
+	assert.Len(t, invocations, 0, "should not run after hooks when transaction fails")
+}
@@ -0,0 +1,73 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"mime"
+	"net/url"
+)
+
+// HashAlgorithmSHA256 the hash algorithm of anchored payloads
+const HashAlgorithmSHA256 = "SHA-256"
+
+// MediaTypeFHIR the media type of FHIR JSON payloads, the
+// only payloads which can carry a FHIR summary
+const MediaTypeFHIR = "application/fhir+json"
+
+// Payload defines the off-chain health data a phr is
+// about, anchored on the ledger by its content hash
+type Payload struct {
+	ContentHash   string `json:"contentHash"`
+	HashAlgorithm string `json:"hashAlgorithm"`
+	MediaType     string `json:"mediaType"`
+	StorageURI    string `json:"storageURI"`
+}
+
+// IsFHIR returns true if the media type of the payload is MediaTypeFHIR
+func (payload *Payload) IsFHIR() bool {
+	baseMediaType, _, err := mime.ParseMediaType(payload.MediaType)
+
+	return err == nil && baseMediaType == MediaTypeFHIR
+}
+
+// Matches returns true if the hex encoded hash, in
+// either case, is the anchored content hash
+func (payload *Payload) Matches(hash string) bool {
+	h, err := parseSHA256("hash", hash)
+
+	return err == nil && h == payload.ContentHash
+}
+
+// parsePayload validates the off-chain payload arguments of Issue. The
+// payload is optional but once any argument is given all are required
+func parsePayload(contentHash string, hashAlgorithm string, mediaType string, storageURI string) (*Payload, error) {
+	if contentHash == "" && hashAlgorithm == "" && mediaType == "" && storageURI == "" {
+		return nil, nil
+	}
+
+	if hashAlgorithm != HashAlgorithmSHA256 {
+		return nil, &ValidationError{Field: "hashAlgorithm", Value: hashAlgorithm, Reason: "Expected " + HashAlgorithmSHA256}
+	}
+
+	contentHash, err := parseSHA256("contentHash", contentHash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, err = mime.ParseMediaType(mediaType)
+
+	if err != nil {
+		return nil, &ValidationError{Field: "mediaType", Value: mediaType, Reason: "Expected a media type such as " + MediaTypeFHIR}
+	}
+
+	uri, err := url.Parse(storageURI)
+
+	if err != nil || uri.Scheme == "" {
+		return nil, &ValidationError{Field: "storageURI", Value: storageURI, Reason: "Expected an absolute URI"}
+	}
+
+	return &Payload{ContentHash: contentHash, HashAlgorithm: hashAlgorithm, MediaType: mediaType, StorageURI: storageURI}, nil
+}
@@ -0,0 +1,60 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayloadMatches(t *testing.T) {
+	someHash := "25c1db4edf964a5af1ef18f2efe118bfa24c2d419ee7e81e605a93aeaca2d78a"
+	payload := &Payload{ContentHash: someHash, HashAlgorithm: HashAlgorithmSHA256}
+
+	assert.True(t, payload.Matches(someHash), "should be true for the content hash")
+	assert.True(t, payload.Matches(strings.ToUpper(someHash)), "should be true for the content hash in upper case")
+	assert.False(t, payload.Matches(strings.Repeat("0", 64)), "should be false for another hash")
+	assert.False(t, payload.Matches("somehash"), "should be false for a value which is not a hash")
+}
+
+func TestPayloadIsFHIR(t *testing.T) {
+	assert.True(t, (&Payload{MediaType: "application/fhir+json"}).IsFHIR(), "should be true for FHIR media type")
+	assert.True(t, (&Payload{MediaType: "application/fhir+json; fhirVersion=4.0"}).IsFHIR(), "should be true for FHIR media type with parameters")
+	assert.False(t, (&Payload{MediaType: "application/pdf"}).IsFHIR(), "should be false for other media type")
+}
+
+func TestParsePayload(t *testing.T) {
+	var payload *Payload
+	var err error
+
+	someHash := "25c1db4edf964a5af1ef18f2efe118bfa24c2d419ee7e81e605a93aeaca2d78a"
+
+	payload, err = parsePayload("", "", "", "")
+	assert.Nil(t, err, "should not error when no payload given")
+	assert.Nil(t, payload, "should return no payload when none given")
+
+	payload, err = parsePayload("", "", "", "s3://somebucket/somephr.json")
+	assertCodedError(t, err, phrerror.Validation, `Invalid hashAlgorithm "". Expected SHA-256`, "should error when only part of payload given")
+	assert.Nil(t, payload, "should not return payload when only part given")
+
+	payload, err = parsePayload(someHash, "MD5", "application/fhir+json", "s3://somebucket/somephr.json")
+	assertCodedError(t, err, phrerror.Validation, `Invalid hashAlgorithm "MD5". Expected SHA-256`, "should error when hash algorithm not supported")
+	assert.Nil(t, payload, "should not return payload when hash algorithm not supported")
+
+	payload, err = parsePayload(someHash, "SHA-256", "fhir json", "s3://somebucket/somephr.json")
+	assertCodedError(t, err, phrerror.Validation, `Invalid mediaType "fhir json". Expected a media type such as application/fhir+json`, "should error when media type does not parse")
+	assert.Nil(t, payload, "should not return payload when media type does not parse")
+
+	payload, err = parsePayload(someHash, "SHA-256", "application/fhir+json", "://somephr.json")
+	assertCodedError(t, err, phrerror.Validation, `Invalid storageURI "://somephr.json". Expected an absolute URI`, "should error when storage URI does not parse")
+	assert.Nil(t, payload, "should not return payload when storage URI does not parse")
+
+	payload, err = parsePayload(strings.ToUpper(someHash), "SHA-256", "application/fhir+json", "https://somehost/somephr.json")
+	assert.Nil(t, err, "should not error for good payload")
+	assert.Equal(t, &Payload{ContentHash: someHash, HashAlgorithm: "SHA-256", MediaType: "application/fhir+json", StorageURI: "https://somehost/somephr.json"}, payload, "should return payload with hash in lower case")
+}
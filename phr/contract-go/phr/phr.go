@@ -7,8 +7,10 @@ package phr
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
-	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/organization/institute/contract-go/ledger-api"
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
 )
 
 // State enum for phr state property
@@ -33,6 +35,17 @@ func (state State) String() string {
 	return names[state-1]
 }
 
+// ParseState returns the state with the passed name
+func ParseState(name string) (State, error) {
+	for state := ISSUED; state <= EXPIRED; state++ {
+		if state.String() == name {
+			return state, nil
+		}
+	}
+
+	return 0, phrerror.Errorf(phrerror.Validation, "Unknown phr state %s", name)
+}
+
 // CreatePHRKey creates a key for phrs
 func CreatePHRKey(issuer string, phrNumber string) string {
 	return ledgerapi.MakeKey(issuer, phrNumber)
@@ -49,15 +62,29 @@ type jsonPHR struct {
 
 // PHR defines a phr
 type PHR struct {
-	PHRNumber      string `json:"phrNumber"`
+	PHRNumber        string `json:"phrNumber"`
 	Issuer           string `json:"issuer"`
 	IssueDateTime    string `json:"issueDateTime"`
 	FaceValue        int    `json:"faceValue"`
 	MaturityDateTime string `json:"maturityDateTime"`
 	Owner            string `json:"owner"`
-	state            State  `metadata:"currentState"`
-	class            string `metadata:"class"`
-	key              string `metadata:"key"`
+	ExpireDateTime   string `json:"expireDateTime,omitempty" metadata:"expireDateTime,optional"`
+	// HashPatient of the patient the record is about and the salt
+	// of the private details. The patient stays private, and the
+	// hash is the account receiving a royalty on every trade
+	PatientHash string `json:"patientHash,omitempty" metadata:"patientHash,optional"`
+	// Off-chain health data the phr is about, anchored at issue
+	Payload *Payload `json:"payload,omitempty" metadata:"payload,optional"`
+	// Hex SHA-256 hash of the salted private details of the phr kept
+	// in a private data collection, checked when they change hands
+	PrivateDetailsHash string `json:"privateDetailsHash,omitempty" metadata:"privateDetailsHash,optional"`
+	// Date times reported by the client. Business metadata only,
+	// the ledger date times above come from the transaction
+	ClientIssueDateTime  string `json:"clientIssueDateTime,omitempty" metadata:"clientIssueDateTime,optional"`
+	ClientExpireDateTime string `json:"clientExpireDateTime,omitempty" metadata:"clientExpireDateTime,optional"`
+	state                State  `metadata:"currentState"`
+	class                string `metadata:"class"`
+	key                  string `metadata:"key"`
 }
 
 // UnmarshalJSON special handler for managing JSON marshalling
@@ -77,7 +104,7 @@ func (phr *PHR) UnmarshalJSON(data []byte) error {
 
 // MarshalJSON special handler for managing JSON marshalling
 func (phr PHR) MarshalJSON() ([]byte, error) {
-	jphr := jsonPHR{PHRAlias: (*PHRAlias)(&phr), State: phr.state, Class: "org.phrnet.phrlist", Key: ledgerapi.MakeKey(phr.Issuer, phr.PHRNumber)}
+	jphr := jsonPHR{PHRAlias: (*PHRAlias)(&phr), State: phr.state, Class: listName, Key: ledgerapi.MakeKey(phr.Issuer, phr.PHRNumber)}
 
 	return json.Marshal(&jphr)
 }
@@ -117,6 +144,31 @@ func (phr *PHR) IsExpired() bool {
 	return phr.state == EXPIRED
 }
 
+// IsMatured returns true if the maturity date time is at or
+// before the passed time. Maturity date times which do not
+// parse as RFC 3339 never mature
+func (phr *PHR) IsMatured(t time.Time) bool {
+	maturity, err := time.Parse(time.RFC3339, phr.MaturityDateTime)
+
+	if err != nil {
+		return false
+	}
+
+	return !maturity.After(t)
+}
+
+// NeedsConsent returns true if the phr names a patient, whose
+// consent is then required for every sale of the phr
+func (phr *PHR) NeedsConsent() bool {
+	return phr.PatientHash != ""
+}
+
+// HasPrivateDetails returns true if private details were
+// stored for the phr at issue
+func (phr *PHR) HasPrivateDetails() bool {
+	return phr.PrivateDetailsHash != ""
+}
+
 // GetSplitKey returns values which should be used to form key
 func (phr *PHR) GetSplitKey() []string {
 	return []string{phr.Issuer, phr.PHRNumber}
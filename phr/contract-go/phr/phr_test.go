@@ -6,8 +6,10 @@ package phr
 
 import (
 	"testing"
+	"time"
 
-	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/organization/hospital/contract-go/ledger-api"
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,6 +20,19 @@ func TestString(t *testing.T) {
 	assert.Equal(t, "UNKNOWN", State(EXPIRED+1).String(), "should return unknown when not one of constants")
 }
 
+func TestParseState(t *testing.T) {
+	var state State
+	var err error
+
+	state, err = ParseState("TRADING")
+	assert.Nil(t, err, "should not error for known state")
+	assert.Equal(t, TRADING, state, "should return state with the name")
+
+	state, err = ParseState("UNKNOWN")
+	assertCodedError(t, err, phrerror.Validation, "Unknown phr state UNKNOWN", "should error for unknown state")
+	assert.Equal(t, State(0), state, "should return zero state for unknown state")
+}
+
 func TestCreatePHRKey(t *testing.T) {
 	assert.Equal(t, ledgerapi.MakeKey("someissuer", "somephr"), CreatePHRKey("someissuer", "somephr"), "should return key comprised of passed values")
 }
@@ -77,6 +92,39 @@ func TestIsExpired(t *testing.T) {
 	assert.False(t, phr.IsExpired(), "should be false when status not set to expired")
 }
 
+func TestIsMatured(t *testing.T) {
+	phr := new(PHR)
+	now := time.Date(2021, 12, 10, 10, 0, 0, 0, time.UTC)
+
+	phr.MaturityDateTime = "2021-12-10T11:00:00Z"
+	assert.False(t, phr.IsMatured(now), "should be false before maturity")
+
+	phr.MaturityDateTime = "2021-12-10T10:00:00Z"
+	assert.True(t, phr.IsMatured(now), "should be true at maturity")
+
+	phr.MaturityDateTime = "2021-12-10T18:00:00+09:00"
+	assert.True(t, phr.IsMatured(now), "should be true after maturity")
+
+	phr.MaturityDateTime = "somematuritydate"
+	assert.False(t, phr.IsMatured(now), "should be false when maturity does not parse")
+}
+
+func TestNeedsConsent(t *testing.T) {
+	phr := new(PHR)
+	assert.False(t, phr.NeedsConsent(), "should be false when phr names no patient")
+
+	phr.PatientHash = "somepatienthash"
+	assert.True(t, phr.NeedsConsent(), "should be true when phr names a patient")
+}
+
+func TestHasPrivateDetails(t *testing.T) {
+	phr := new(PHR)
+	assert.False(t, phr.HasPrivateDetails(), "should be false when phr has no private details hash")
+
+	phr.PrivateDetailsHash = "somehash"
+	assert.True(t, phr.HasPrivateDetails(), "should be true when phr has a private details hash")
+}
+
 func TestGetSplitKey(t *testing.T) {
 	phr := new(PHR)
 	phr.PHRNumber = "somephr"
@@ -0,0 +1,205 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// TransactionContextInterface an interface to
+// describe the minimum required functions for
+// a transaction context in the phr
+type TransactionContextInterface interface {
+	contractapi.TransactionContextInterface
+	GetPHRList() ListInterface
+	GetTradeList() TradeListInterface
+	GetListingList() ListingListInterface
+	GetBidList() BidListInterface
+	GetAuctionList() AuctionListInterface
+	GetTokenList() TokenListInterface
+	GetRoyaltyList() RoyaltyListInterface
+	GetConsentList() ConsentListInterface
+	GetCaller() (string, error)
+	GetOwnerMSPID(string) (string, error)
+	Authorize(...Role) error
+	GetInvocation() *Invocation
+	SetInvocation(*Invocation)
+	GetSettings() *Settings
+	SetSettings(*Settings)
+}
+
+// TransactionContext implementation of
+// TransactionContextInterface for use with
+// phr contract
+type TransactionContext struct {
+	contractapi.TransactionContext
+	IdentityMapper IdentityMapperInterface
+	RoleMapper     RoleMapperInterface
+	phrList        *list
+	tradeList      *tradeList
+	listingList    *listingList
+	bidList        *bidList
+	auctionList    *auctionList
+	tokenList      *tokenList
+	royaltyList    *royaltyList
+	consentList    *consentList
+	invocation     *Invocation
+	settings       *Settings
+}
+
+// GetPHRList return phr list
+func (tc *TransactionContext) GetPHRList() ListInterface {
+	if tc.phrList == nil {
+		tc.phrList = newList(tc)
+	}
+
+	return tc.phrList
+}
+
+// GetTradeList return trade list
+func (tc *TransactionContext) GetTradeList() TradeListInterface {
+	if tc.tradeList == nil {
+		tc.tradeList = newTradeList(tc)
+	}
+
+	return tc.tradeList
+}
+
+// GetListingList return listing list
+func (tc *TransactionContext) GetListingList() ListingListInterface {
+	if tc.listingList == nil {
+		tc.listingList = newListingList(tc)
+	}
+
+	return tc.listingList
+}
+
+// GetBidList return bid list
+func (tc *TransactionContext) GetBidList() BidListInterface {
+	if tc.bidList == nil {
+		tc.bidList = newBidList(tc)
+	}
+
+	return tc.bidList
+}
+
+// GetAuctionList return auction list
+func (tc *TransactionContext) GetAuctionList() AuctionListInterface {
+	if tc.auctionList == nil {
+		tc.auctionList = newAuctionList(tc)
+	}
+
+	return tc.auctionList
+}
+
+// GetTokenList return token list
+func (tc *TransactionContext) GetTokenList() TokenListInterface {
+	if tc.tokenList == nil {
+		tc.tokenList = newTokenList(tc)
+	}
+
+	return tc.tokenList
+}
+
+// GetRoyaltyList return royalty list
+func (tc *TransactionContext) GetRoyaltyList() RoyaltyListInterface {
+	if tc.royaltyList == nil {
+		tc.royaltyList = newRoyaltyList(tc)
+	}
+
+	return tc.royaltyList
+}
+
+// GetConsentList return consent list
+func (tc *TransactionContext) GetConsentList() ConsentListInterface {
+	if tc.consentList == nil {
+		tc.consentList = newConsentList(tc)
+	}
+
+	return tc.consentList
+}
+
+// GetCaller returns the owner the submitting client acts as.
+// Uses the identity mapper of the settings when none is set
+func (tc *TransactionContext) GetCaller() (string, error) {
+	if tc.IdentityMapper == nil {
+		tc.IdentityMapper = tc.GetSettings().NewIdentityMapper()
+	}
+
+	return tc.IdentityMapper.GetOwner(tc.GetClientIdentity())
+}
+
+// GetOwnerMSPID returns the MSP ID of the org an owner belongs to.
+// The owner the submitting client acts as belongs to the client MSP,
+// other owners are looked up with the identity mapper
+func (tc *TransactionContext) GetOwnerMSPID(owner string) (string, error) {
+	caller, err := tc.GetCaller()
+
+	if err != nil {
+		return "", err
+	}
+
+	if owner == caller {
+		return tc.GetClientIdentity().GetMSPID()
+	}
+
+	return tc.IdentityMapper.GetMSPID(owner)
+}
+
+// Authorize returns a PermissionError unless the submitting client
+// holds one of the passed roles. Uses the role mapper of the settings
+// when none is set
+func (tc *TransactionContext) Authorize(roles ...Role) error {
+	if tc.RoleMapper == nil {
+		tc.RoleMapper = tc.GetSettings().NewRoleMapper()
+	}
+
+	role, err := tc.RoleMapper.GetRole(tc.GetClientIdentity())
+
+	if err != nil {
+		return err
+	}
+
+	return checkRole(role, roles)
+}
+
+// GetInvocation returns the invocation of the transaction
+// recorded by the middleware chain, or nil if none is
+func (tc *TransactionContext) GetInvocation() *Invocation {
+	return tc.invocation
+}
+
+// SetInvocation records the invocation of the transaction
+func (tc *TransactionContext) SetInvocation(inv *Invocation) {
+	tc.invocation = inv
+}
+
+// GetSettings returns the settings of the contract,
+// or the default settings if none are set
+func (tc *TransactionContext) GetSettings() *Settings {
+	if tc.settings == nil {
+		tc.settings = DefaultSettings()
+	}
+
+	return tc.settings
+}
+
+// SetSettings sets the settings of the contract
+func (tc *TransactionContext) SetSettings(settings *Settings) {
+	tc.settings = settings
+}
+
+// getTxTime returns the timestamp of the transaction in UTC
+func getTxTime(ctx contractapi.TransactionContextInterface) (time.Time, error) {
+	txTimestamp, err := ctx.GetStub().GetTxTimestamp()
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(txTimestamp.Seconds, int64(txTimestamp.Nanos)).UTC(), nil
+}
@@ -0,0 +1,257 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"errors"
+	"testing"
+
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetPHRList(t *testing.T) {
+	var tc *TransactionContext
+	var expectedPHRList *list
+
+	tc = new(TransactionContext)
+	expectedPHRList = newList(tc)
+	actualList := tc.GetPHRList().(*list)
+	assert.Equal(t, expectedPHRList.stateList.(*ledgerapi.StateList).Name, actualList.stateList.(*ledgerapi.StateList).Name, "should configure phr list when one not already configured")
+
+	tc = new(TransactionContext)
+	expectedPHRList = new(list)
+	expectedStateList := new(ledgerapi.StateList)
+	expectedStateList.Ctx = tc
+	expectedStateList.Name = "existing phr list"
+	expectedPHRList.stateList = expectedStateList
+	tc.phrList = expectedPHRList
+	assert.Equal(t, expectedPHRList, tc.GetPHRList(), "should return set phr list when already set")
+}
+
+func TestGetTradeList(t *testing.T) {
+	var tc *TransactionContext
+
+	tc = new(TransactionContext)
+	actualList := tc.GetTradeList().(*tradeList)
+	assert.Equal(t, tradeListName, actualList.stateList.(*ledgerapi.StateList).Name, "should configure trade list when one not already configured")
+
+	tc = new(TransactionContext)
+	expectedTradeList := new(tradeList)
+	tc.tradeList = expectedTradeList
+	assert.Equal(t, expectedTradeList, tc.GetTradeList(), "should return set trade list when already set")
+}
+
+func TestGetListingList(t *testing.T) {
+	var tc *TransactionContext
+
+	tc = new(TransactionContext)
+	actualList := tc.GetListingList().(*listingList)
+	assert.Equal(t, listingListName, actualList.stateList.(*ledgerapi.StateList).Name, "should configure listing list when one not already configured")
+
+	tc = new(TransactionContext)
+	expectedListingList := new(listingList)
+	tc.listingList = expectedListingList
+	assert.Equal(t, expectedListingList, tc.GetListingList(), "should return set listing list when already set")
+}
+
+func TestGetBidList(t *testing.T) {
+	var tc *TransactionContext
+
+	tc = new(TransactionContext)
+	actualList := tc.GetBidList().(*bidList)
+	assert.Equal(t, bidListName, actualList.stateList.(*ledgerapi.StateList).Name, "should configure bid list when one not already configured")
+
+	tc = new(TransactionContext)
+	expectedBidList := new(bidList)
+	tc.bidList = expectedBidList
+	assert.Equal(t, expectedBidList, tc.GetBidList(), "should return set bid list when already set")
+}
+
+func TestGetAuctionList(t *testing.T) {
+	var tc *TransactionContext
+
+	tc = new(TransactionContext)
+	actualList := tc.GetAuctionList().(*auctionList)
+	assert.Equal(t, auctionListName, actualList.stateList.(*ledgerapi.StateList).Name, "should configure auction list when one not already configured")
+	assert.Equal(t, sealedBidListName, actualList.bidStateList.(*ledgerapi.StateList).Name, "should configure sealed bid list when one not already configured")
+
+	tc = new(TransactionContext)
+	expectedAuctionList := new(auctionList)
+	tc.auctionList = expectedAuctionList
+	assert.Equal(t, expectedAuctionList, tc.GetAuctionList(), "should return set auction list when already set")
+}
+
+func TestGetTokenList(t *testing.T) {
+	var tc *TransactionContext
+
+	tc = new(TransactionContext)
+	actualList := tc.GetTokenList().(*tokenList)
+	assert.Equal(t, balanceListName, actualList.stateList.(*ledgerapi.StateList).Name, "should configure balance list when one not already configured")
+	assert.Equal(t, allowanceListName, actualList.allowanceStateList.(*ledgerapi.StateList).Name, "should configure allowance list when one not already configured")
+
+	tc = new(TransactionContext)
+	expectedTokenList := new(tokenList)
+	tc.tokenList = expectedTokenList
+	assert.Equal(t, expectedTokenList, tc.GetTokenList(), "should return set token list when already set")
+}
+
+func TestGetRoyaltyList(t *testing.T) {
+	var tc *TransactionContext
+
+	tc = new(TransactionContext)
+	actualList := tc.GetRoyaltyList().(*royaltyList)
+	assert.Equal(t, royaltyRateListName, actualList.rateStateList.(*ledgerapi.StateList).Name, "should configure royalty rate list when one not already configured")
+	assert.Equal(t, royaltyListName, actualList.stateList.(*ledgerapi.StateList).Name, "should configure royalty list when one not already configured")
+
+	tc = new(TransactionContext)
+	expectedRoyaltyList := new(royaltyList)
+	tc.royaltyList = expectedRoyaltyList
+	assert.Equal(t, expectedRoyaltyList, tc.GetRoyaltyList(), "should return set royalty list when already set")
+}
+
+func TestGetConsentList(t *testing.T) {
+	var tc *TransactionContext
+
+	tc = new(TransactionContext)
+	actualList := tc.GetConsentList().(*consentList)
+	assert.Equal(t, consentListName, actualList.stateList.(*ledgerapi.StateList).Name, "should configure consent list when one not already configured")
+
+	tc = new(TransactionContext)
+	expectedConsentList := new(consentList)
+	tc.consentList = expectedConsentList
+	assert.Equal(t, expectedConsentList, tc.GetConsentList(), "should return set consent list when already set")
+}
+
+type MockIdentityMapper struct {
+	mock.Mock
+}
+
+func (mim *MockIdentityMapper) GetOwner(ci cid.ClientIdentity) (string, error) {
+	args := mim.Called(ci)
+
+	return args.String(0), args.Error(1)
+}
+
+func (mim *MockIdentityMapper) GetMSPID(owner string) (string, error) {
+	args := mim.Called(owner)
+
+	return args.String(0), args.Error(1)
+}
+
+func TestGetCaller(t *testing.T) {
+	var caller string
+	var err error
+
+	tc := new(TransactionContext)
+	tc.SetClientIdentity(newMockClientIdentity("Org2MSP", ""))
+	caller, err = tc.GetCaller()
+	assert.Nil(t, err, "should not error when default identity mapper maps client")
+	assert.Equal(t, "hospital", caller, "should use default identity mapper when one not already configured")
+	assert.IsType(t, new(IdentityMapper), tc.IdentityMapper, "should configure default identity mapper")
+
+	mci := newMockClientIdentity("Org2MSP", "")
+	mim := new(MockIdentityMapper)
+	mim.On("GetOwner", mci).Return("someowner", nil)
+	tc = new(TransactionContext)
+	tc.SetClientIdentity(mci)
+	tc.IdentityMapper = mim
+	caller, err = tc.GetCaller()
+	assert.Nil(t, err, "should not error when set identity mapper does not error")
+	assert.Equal(t, "someowner", caller, "should use set identity mapper when already set")
+}
+
+func TestGetOwnerMSPID(t *testing.T) {
+	var mspID string
+	var err error
+
+	mci := newMockClientIdentity("Org2MSP", "")
+	mim := new(MockIdentityMapper)
+	mim.On("GetOwner", mci).Return("someowner", nil)
+	mim.On("GetMSPID", "someotherowner").Return("Org1MSP", nil)
+	mim.On("GetMSPID", "someunmappedowner").Return("", errors.New("GetMSPID error"))
+	tc := new(TransactionContext)
+	tc.SetClientIdentity(mci)
+	tc.IdentityMapper = mim
+
+	mspID, err = tc.GetOwnerMSPID("someowner")
+	assert.Nil(t, err, "should not error when owner is caller")
+	assert.Equal(t, "Org2MSP", mspID, "should return client MSP ID when owner is caller")
+
+	mspID, err = tc.GetOwnerMSPID("someotherowner")
+	assert.Nil(t, err, "should not error when identity mapper maps owner")
+	assert.Equal(t, "Org1MSP", mspID, "should use identity mapper when owner is not caller")
+
+	mspID, err = tc.GetOwnerMSPID("someunmappedowner")
+	assert.EqualError(t, err, "GetMSPID error", "should error when identity mapper errors")
+	assert.Equal(t, "", mspID, "should not return MSP ID when identity mapper errors")
+
+	mim = new(MockIdentityMapper)
+	mim.On("GetOwner", mci).Return("", errors.New("GetOwner error"))
+	tc.IdentityMapper = mim
+
+	mspID, err = tc.GetOwnerMSPID("someowner")
+	assert.EqualError(t, err, "GetOwner error", "should error when caller errors")
+	assert.Equal(t, "", mspID, "should not return MSP ID when caller errors")
+}
+
+type MockRoleMapper struct {
+	mock.Mock
+}
+
+func (mrm *MockRoleMapper) GetRole(ci cid.ClientIdentity) (Role, error) {
+	args := mrm.Called(ci)
+
+	return args.Get(0).(Role), args.Error(1)
+}
+
+func TestAuthorize(t *testing.T) {
+	var err error
+
+	tc := new(TransactionContext)
+	tc.SetClientIdentity(&FakeClientIdentity{MSPID: "Org2MSP"})
+	err = tc.Authorize(IssuerRole)
+	assert.Nil(t, err, "should allow role mapped by default role mapper")
+	assert.IsType(t, new(RoleMapper), tc.RoleMapper, "should configure default role mapper")
+
+	err = tc.Authorize(BuyerRole)
+	assertCodedError(t, err, phrerror.Forbidden, "Permission denied for role issuer. Requires one of buyer", "should deny role not required")
+	assert.IsType(t, new(PermissionError), err, "should return permission error when role not required")
+
+	tc = new(TransactionContext)
+	tc.SetClientIdentity(&FakeClientIdentity{MSPID: "Org1MSP", Attributes: map[string]string{RoleAttribute: "auditor"}})
+	err = tc.Authorize(IssuerRole, BuyerRole, AuditorRole)
+	assert.Nil(t, err, "should allow auditor to read")
+
+	err = tc.Authorize(IssuerRole, BuyerRole)
+	assert.IsType(t, new(PermissionError), err, "should deny auditor to write")
+
+	tc = new(TransactionContext)
+	tc.SetClientIdentity(&FakeClientIdentity{MSPID: "someadminMSP"})
+	tc.SetSettings(&Settings{TokenAdminMSP: "someadminMSP"})
+	err = tc.Authorize(TokenAdminRole)
+	assert.Nil(t, err, "should allow token admin role to clients of the token admin MSP of the settings")
+
+	fci := &FakeClientIdentity{MSPID: "Org2MSP"}
+	mrm := new(MockRoleMapper)
+	mrm.On("GetRole", fci).Return(Role(""), errors.New("GetRole error"))
+	tc = new(TransactionContext)
+	tc.SetClientIdentity(fci)
+	tc.RoleMapper = mrm
+	err = tc.Authorize(IssuerRole)
+	assert.EqualError(t, err, "GetRole error", "should error when set role mapper errors")
+}
+
+func TestGetSettings(t *testing.T) {
+	tc := new(TransactionContext)
+	assert.Equal(t, DefaultSettings(), tc.GetSettings(), "should default settings")
+
+	settings := &Settings{TokenAdminMSP: "someadminMSP"}
+	tc.SetSettings(settings)
+	assert.Equal(t, settings, tc.GetSettings(), "should return settings set")
+}
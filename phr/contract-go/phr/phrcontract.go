@@ -0,0 +1,691 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"fmt"
+	"time"
+
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Contract chaincode that defines
+// the business logic for managing phr.
+// Middleware runs around its transactions,
+// DefaultChain when not set
+type Contract struct {
+	contractapi.Contract
+	Middleware *Chain
+	Settings   *Settings
+}
+
+func (c *Contract) getMiddleware() *Chain {
+	if c.Middleware == nil {
+		c.Middleware = DefaultChain()
+	}
+
+	return c.Middleware
+}
+
+func (c *Contract) getSettings() *Settings {
+	if c.Settings == nil {
+		c.Settings = DefaultSettings()
+	}
+
+	return c.Settings
+}
+
+// GetBeforeTransaction returns the before transaction hook of
+// the middleware. Sets the settings of the contract on the
+// context first, as the context is created for each transaction
+func (c *Contract) GetBeforeTransaction() interface{} {
+	return func(ctx TransactionContextInterface) error {
+		ctx.SetSettings(c.getSettings())
+
+		return c.getMiddleware().Before(ctx)
+	}
+}
+
+// GetAfterTransaction returns the after
+// transaction hook of the middleware
+func (c *Contract) GetAfterTransaction() interface{} {
+	return c.getMiddleware().After
+}
+
+// GetUnknownTransaction returns the unknown
+// transaction hook of the middleware
+func (c *Contract) GetUnknownTransaction() interface{} {
+	return c.getMiddleware().Unknown
+}
+
+// GetEvaluateTransactions returns the transactions which
+// only read from the world state
+func (c *Contract) GetEvaluateTransactions() []string {
+	return []string{"BalanceOf", "GetAllowance", "GetAuction", "GetBids", "GetConsent", "GetListing", "GetMaturedPHRs", "GetPatientRoyalties", "GetPHRHistory", "GetPHRPrivateDetails", "GetRoyalties", "GetRoyaltyRate", "GetSealedBids", "GetTradeHistory", "GetTradeStats", "QueryByIssuer", "QueryByIssuerWithPagination", "QueryByOwnerWithPagination", "QueryByStateWithPagination", "QueryPHRs", "QueryPHRsWithPagination", "VerifyPayload"}
+}
+
+// Instantiate does nothing
+func (c *Contract) Instantiate() {
+	fmt.Println("Instantiated")
+}
+
+// Issue creates a new phr and stores it in the world state. Private
+// details passed in the transient data go to the PHRPrivateCollection
+func (c *Contract) Issue(ctx TransactionContextInterface, issuer string, phrNumber string, issueDateTime string, maturityDateTime string, faceValue int, contentHash string, hashAlgorithm string, mediaType string, storageURI string) (*PHR, error) {
+	caller, err := ctx.GetCaller()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if issuer != caller {
+		return nil, phrerror.Errorf(phrerror.Forbidden, "PHR %s:%s can only be issued by issuer %s. Submitter = %s", issuer, phrNumber, issuer, caller)
+	}
+
+	clientIssueDateTime, err := parseDateTime("issueDateTime", issueDateTime, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := parsePayload(contentHash, hashAlgorithm, mediaType, storageURI)
+
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := getTransientPrivateDetails(ctx, issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	privateDetailsHash := ""
+	patientHash := ""
+
+	if details != nil {
+		err = details.validate(payload)
+
+		if err != nil {
+			return nil, err
+		}
+
+		privateDetailsHash, err = details.Hash()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if details.Patient != "" {
+			patientHash = HashPatient(details.Patient, details.Salt)
+		}
+	}
+
+	maturityDateTime, err = parseDateTime("maturityDateTime", maturityDateTime, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	txTime, err := getTxTime(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	issuedDateTime := FormatDateTime(txTime)
+
+	if maturityDateTime <= issuedDateTime {
+		return nil, &ValidationError{Field: "maturityDateTime", Value: maturityDateTime, Reason: "Must be after the issue date time " + issuedDateTime}
+	}
+
+	phr := PHR{PHRNumber: phrNumber, Issuer: issuer, IssueDateTime: issuedDateTime, FaceValue: faceValue, MaturityDateTime: maturityDateTime, Owner: issuer, PatientHash: patientHash, Payload: payload, PrivateDetailsHash: privateDetailsHash, ClientIssueDateTime: clientIssueDateTime}
+	phr.SetIssued()
+
+	ownerMSPID, err := ctx.GetOwnerMSPID(phr.Owner)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = ctx.GetPHRList().AddPHR(&phr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = ctx.GetPHRList().SetPHROwnerOrg(issuer, phrNumber, ownerMSPID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if details != nil {
+		err = ctx.GetPHRList().AddPHRPrivateDetails(details)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &phr, nil
+}
+
+// Buy updates a phr to be in trading status and sets the new owner
+// at the price of an active listing. Submitted by either owner
+func (c *Contract) Buy(ctx TransactionContextInterface, issuer string, phrNumber string, currentOwner string, newOwner string, price int, purchaseDateTime string, purpose string) (*PHR, error) {
+	purchaseDateTime, err := parseDateTime("purchaseDateTime", purchaseDateTime, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	phr, err := ctx.GetPHRList().GetPHR(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if phr.Owner != currentOwner {
+		return nil, phrerror.Errorf(phrerror.NotOwner, "PHR %s:%s is not owned by %s", issuer, phrNumber, currentOwner)
+	}
+
+	if newOwner == phr.Owner {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s is already owned by %s", issuer, phrNumber, newOwner)
+	}
+
+	caller, err := ctx.GetCaller()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if phr.Owner != caller && newOwner != caller {
+		return nil, phrerror.Errorf(phrerror.NotOwner, "PHR %s:%s is not owned or being bought by submitter %s", issuer, phrNumber, caller)
+	}
+
+	previousState := phr.GetState()
+
+	if phr.IsIssued() {
+		phr.SetTrading()
+	}
+
+	if !phr.IsTrading() {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s is not trading. Current state = %s", issuer, phrNumber, phr.GetState())
+	}
+
+	txTime, err := getTxTime(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if phr.IsMatured(txTime) {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s has matured. Maturity date time = %s", issuer, phrNumber, phr.MaturityDateTime)
+	}
+
+	listing, err := ctx.GetListingList().GetListing(issuer, phrNumber)
+
+	if _, ok := err.(*ledgerapi.StateNotFoundError); ok || (err == nil && !listing.IsActive(phr.Owner, txTime)) {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s is not listed for sale", issuer, phrNumber)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if price < listing.AskPrice {
+		return nil, phrerror.Errorf(phrerror.Validation, "Price %d is below the ask price %d of PHR %s:%s", price, listing.AskPrice, issuer, phrNumber)
+	}
+
+	if phr.NeedsConsent() && caller != newOwner {
+		return nil, phrerror.Errorf(phrerror.Forbidden, "PHR %s:%s needs consent of its patient so must be bought by the new owner %s. Submitter = %s", issuer, phrNumber, newOwner, caller)
+	}
+
+	if phr.HasPrivateDetails() && caller != newOwner {
+		return nil, phrerror.Errorf(phrerror.Forbidden, "PHR %s:%s has private details so must be bought by the new owner %s. Submitter = %s", issuer, phrNumber, newOwner, caller)
+	}
+
+	err = checkBuyer(ctx, phr, newOwner, purpose, txTime)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the new owner pays, so an owner submitting spends an allowance
+	// the new owner approved for them
+	if caller != newOwner {
+		_, err = spendAllowance(ctx, newOwner, caller, price)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = transfer(ctx, phr, newOwner, price, purchaseDateTime, previousState, txTime)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return phr, nil
+}
+
+// checkBuyer checks the org of the new owner of a phr may receive it.
+// A phr naming a patient needs a consent covering the org and purpose,
+// and a phr with private details needs them offered to the org. Used by
+// Buy, AcceptBid and CloseAuction
+func checkBuyer(ctx TransactionContextInterface, phr *PHR, newOwner string, purpose string, txTime time.Time) error {
+	if !phr.NeedsConsent() && !phr.HasPrivateDetails() {
+		return nil
+	}
+
+	mspID, err := ctx.GetOwnerMSPID(newOwner)
+
+	if err != nil {
+		return err
+	}
+
+	if phr.NeedsConsent() {
+		err = checkConsent(ctx, phr, mspID, purpose, txTime)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if phr.HasPrivateDetails() {
+		return checkPrivateTransfer(ctx, phr, mspID)
+	}
+
+	return nil
+}
+
+// transfer pays the price from the new owner to the owner of a trading
+// phr less the royalty paid to its patient hash, moves the phr to the new owner,
+// requires the org of the new owner to endorse its changes, clears its
+// listing, records the trade and royalty and emits the traded event.
+// Callers make sure the new owner agreed to pay the price, by submitting
+// Buy, approving an allowance or placing the bid AcceptBid or CloseAuction
+// accept
+func transfer(ctx TransactionContextInterface, phr *PHR, newOwner string, price int, purchaseDateTime string, previousState State, txTime time.Time) error {
+	previousOwner := phr.Owner
+	royalty := 0
+
+	newOwnerMSPID, err := ctx.GetOwnerMSPID(newOwner)
+
+	if err != nil {
+		return err
+	}
+
+	if phr.NeedsConsent() {
+		rate, err := ctx.GetRoyaltyList().GetRoyaltyRate()
+
+		if err != nil {
+			return err
+		}
+
+		royalty = rate.Calculate(price)
+	}
+
+	err = settle(ctx, newOwner, payment{to: previousOwner, amount: price - royalty}, payment{to: phr.PatientHash, amount: royalty})
+
+	if err != nil {
+		return err
+	}
+
+	phr.Owner = newOwner
+
+	err = ctx.GetPHRList().UpdatePHR(phr)
+
+	if err != nil {
+		return err
+	}
+
+	err = ctx.GetPHRList().SetPHROwnerOrg(phr.Issuer, phr.PHRNumber, newOwnerMSPID)
+
+	if err != nil {
+		return err
+	}
+
+	err = ctx.GetListingList().DeleteListing(phr.Issuer, phr.PHRNumber)
+
+	if err != nil {
+		return err
+	}
+
+	trade := Trade{Issuer: phr.Issuer, PHRNumber: phr.PHRNumber, Seller: previousOwner, Buyer: newOwner, Price: price, PurchaseDateTime: purchaseDateTime, TxID: ctx.GetStub().GetTxID(), Timestamp: txTime.Format(time.RFC3339Nano)}
+
+	err = ctx.GetTradeList().AddTrade(&trade)
+
+	if err != nil {
+		return err
+	}
+
+	if royalty > 0 {
+		err = ctx.GetRoyaltyList().AddRoyalty(&Royalty{PatientHash: phr.PatientHash, Issuer: phr.Issuer, PHRNumber: phr.PHRNumber, Sequence: trade.Sequence, Price: price, Amount: royalty, TxID: trade.TxID, Timestamp: trade.Timestamp})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return setEvent(ctx, &PHREvent{Name: TradedEvent, Issuer: phr.Issuer, PHRNumber: phr.PHRNumber, PreviousOwner: previousOwner, NewOwner: newOwner, Price: price, PreviousState: previousState, NewState: phr.GetState()})
+}
+
+// GetMaturedPHRs returns the phrs in a page of phrs of the issuer, or
+// of the state when issuer is empty, whose maturity date time has
+// passed and which are not expired. Query again with the returned
+// bookmark while it is not empty, and expire each phr with ExpireMatured
+func (c *Contract) GetMaturedPHRs(ctx TransactionContextInterface, issuer string, state string, pageSize int32, bookmark string) (*PHRPage, error) {
+	var page *PHRPage
+	var err error
+
+	if issuer != "" {
+		page, err = ctx.GetPHRList().GetPHRsByIssuerWithPagination(issuer, pageSize, bookmark)
+	} else {
+		var phrState State
+
+		phrState, err = ParseState(state)
+
+		if err != nil {
+			return nil, err
+		}
+
+		page, err = ctx.GetPHRList().GetPHRsByStateWithPagination(phrState, pageSize, bookmark)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	txTime, err := getTxTime(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	matured := &PHRPage{Records: []*PHR{}, FetchedRecordsCount: page.FetchedRecordsCount, Bookmark: page.Bookmark}
+
+	for _, phr := range page.Records {
+		if !phr.IsExpired() && phr.IsMatured(txTime) {
+			matured.Records = append(matured.Records, phr)
+		}
+	}
+
+	return matured, nil
+}
+
+// ExpireMatured expires a phr whose maturity
+// date time has passed, whoever owns it
+func (c *Contract) ExpireMatured(ctx TransactionContextInterface, issuer string, phrNumber string) (*PHR, error) {
+	phr, err := ctx.GetPHRList().GetPHR(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if phr.IsExpired() {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s is already expired", issuer, phrNumber)
+	}
+
+	txTime, err := getTxTime(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !phr.IsMatured(txTime) {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s has not matured. Maturity date time = %s", issuer, phrNumber, phr.MaturityDateTime)
+	}
+
+	err = expire(ctx, phr, "", txTime)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return phr, nil
+}
+
+// Expire updates a phr status to be expired
+// and returns it to its issuer
+func (c *Contract) Expire(ctx TransactionContextInterface, issuer string, phrNumber string, expiringOwner string, expireDateTime string) (*PHR, error) {
+	clientExpireDateTime, err := parseDateTime("expireDateTime", expireDateTime, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	phr, err := ctx.GetPHRList().GetPHR(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if phr.Owner != expiringOwner {
+		return nil, phrerror.Errorf(phrerror.NotOwner, "PHR %s:%s is not owned by %s", issuer, phrNumber, expiringOwner)
+	}
+
+	caller, err := ctx.GetCaller()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if phr.Owner != caller {
+		return nil, phrerror.Errorf(phrerror.NotOwner, "PHR %s:%s is not owned by submitter %s", issuer, phrNumber, caller)
+	}
+
+	if phr.IsExpired() {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s is already expired", issuer, phrNumber)
+	}
+
+	txTime, err := getTxTime(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = expire(ctx, phr, clientExpireDateTime, txTime)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return phr, nil
+}
+
+// expire sets a phr expired at the transaction time and returns it to
+// its issuer, requires the org of the issuer to endorse its changes
+// and clears its listing. Used by Expire and ExpireMatured
+func expire(ctx TransactionContextInterface, phr *PHR, clientExpireDateTime string, txTime time.Time) error {
+	issuerMSPID, err := ctx.GetOwnerMSPID(phr.Issuer)
+
+	if err != nil {
+		return err
+	}
+
+	phr.Owner = phr.Issuer
+	phr.ExpireDateTime = FormatDateTime(txTime)
+	phr.ClientExpireDateTime = clientExpireDateTime
+	phr.SetExpired()
+
+	err = ctx.GetPHRList().UpdatePHR(phr)
+
+	if err != nil {
+		return err
+	}
+
+	err = ctx.GetPHRList().SetPHROwnerOrg(phr.Issuer, phr.PHRNumber, issuerMSPID)
+
+	if err != nil {
+		return err
+	}
+
+	return ctx.GetListingList().DeleteListing(phr.Issuer, phr.PHRNumber)
+}
+
+// QueryByIssuer returns all phrs issued by the issuer
+func (c *Contract) QueryByIssuer(ctx TransactionContextInterface, issuer string) ([]*PHR, error) {
+	return ctx.GetPHRList().GetPHRsByIssuer(issuer)
+}
+
+// QueryByIssuerWithPagination returns a page of phrs issued by the issuer
+func (c *Contract) QueryByIssuerWithPagination(ctx TransactionContextInterface, issuer string, pageSize int32, bookmark string) (*PHRPage, error) {
+	return ctx.GetPHRList().GetPHRsByIssuerWithPagination(issuer, pageSize, bookmark)
+}
+
+// QueryByOwnerWithPagination returns a page of phrs owned by the owner
+func (c *Contract) QueryByOwnerWithPagination(ctx TransactionContextInterface, owner string, pageSize int32, bookmark string) (*PHRPage, error) {
+	return ctx.GetPHRList().GetPHRsByOwnerWithPagination(owner, pageSize, bookmark)
+}
+
+// QueryByStateWithPagination returns a page of phrs in the named state
+func (c *Contract) QueryByStateWithPagination(ctx TransactionContextInterface, state string, pageSize int32, bookmark string) (*PHRPage, error) {
+	phrState, err := ParseState(state)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx.GetPHRList().GetPHRsByStateWithPagination(phrState, pageSize, bookmark)
+}
+
+// QueryPHRs returns all phrs matching the query
+func (c *Contract) QueryPHRs(ctx TransactionContextInterface, query PHRQuery) ([]*PHR, error) {
+	return ctx.GetPHRList().GetPHRsByQuery(&query)
+}
+
+// QueryPHRsWithPagination returns a page of phrs matching the query
+func (c *Contract) QueryPHRsWithPagination(ctx TransactionContextInterface, query PHRQuery, pageSize int32, bookmark string) (*PHRPage, error) {
+	return ctx.GetPHRList().GetPHRsByQueryWithPagination(&query, pageSize, bookmark)
+}
+
+// GetPHRHistory returns every version of the phr written to the
+// ledger, in the commit order the peer returns them in
+func (c *Contract) GetPHRHistory(ctx TransactionContextInterface, issuer string, phrNumber string) ([]*PHRHistoryEntry, error) {
+	return ctx.GetPHRList().GetPHRHistory(issuer, phrNumber)
+}
+
+// VerifyPayload returns true if the hex encoded SHA-256 hash
+// is the content hash anchored on the phr at issue
+func (c *Contract) VerifyPayload(ctx TransactionContextInterface, issuer string, phrNumber string, hash string) (bool, error) {
+	phr, err := ctx.GetPHRList().GetPHR(issuer, phrNumber)
+
+	if err != nil {
+		return false, err
+	}
+
+	if phr.Payload == nil {
+		return false, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s has no anchored payload", issuer, phrNumber)
+	}
+
+	return phr.Payload.Matches(hash), nil
+}
+
+// OfferPrivateDetails puts the private details of a phr, passed in the
+// transient data under PHRPrivateTransientKey, in the implicit collection
+// of the buyer MSP, where Buy checks them against the hash on the phr.
+// Submitted by the owner and endorsed by a peer of the buyer MSP
+func (c *Contract) OfferPrivateDetails(ctx TransactionContextInterface, issuer string, phrNumber string, buyerMSP string) error {
+	if buyerMSP == "" {
+		return &ValidationError{Field: "buyerMSP", Value: buyerMSP, Reason: "Value is required"}
+	}
+
+	phr, err := ctx.GetPHRList().GetPHR(issuer, phrNumber)
+
+	if err != nil {
+		return err
+	}
+
+	caller, err := ctx.GetCaller()
+
+	if err != nil {
+		return err
+	}
+
+	if phr.Owner != caller {
+		return phrerror.Errorf(phrerror.NotOwner, "PHR %s:%s is not owned by submitter %s", issuer, phrNumber, caller)
+	}
+
+	if !phr.HasPrivateDetails() {
+		return phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s has no private details", issuer, phrNumber)
+	}
+
+	details, err := getTransientPrivateDetails(ctx, issuer, phrNumber)
+
+	if err != nil {
+		return err
+	}
+
+	if details == nil {
+		return &ValidationError{Field: PHRPrivateTransientKey, Value: "", Reason: "Value is required in the transient data"}
+	}
+
+	hash, err := details.Hash()
+
+	if err != nil {
+		return err
+	}
+
+	if hash != phr.PrivateDetailsHash {
+		return phrerror.Errorf(phrerror.Validation, "Private details do not match the hash on PHR %s:%s", issuer, phrNumber)
+	}
+
+	return ctx.GetPHRList().AddPHRPrivateDetailsFor(buyerMSP, details)
+}
+
+// GetPHRPrivateDetails returns the private details of a phr.
+// Submitted by the issuer, read from the PHRPrivateCollection only
+// its org is a member of, or by the owner, read from the implicit
+// collection of the owner MSP the details were offered in
+func (c *Contract) GetPHRPrivateDetails(ctx TransactionContextInterface, issuer string, phrNumber string) (*PHRPrivateDetails, error) {
+	phr, err := ctx.GetPHRList().GetPHR(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := ctx.GetCaller()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if phr.Issuer != caller && phr.Owner != caller {
+		return nil, phrerror.Errorf(phrerror.NotOwner, "Private details of PHR %s:%s can only be read by its issuer or owner. Submitter = %s", issuer, phrNumber, caller)
+	}
+
+	if phr.Issuer == caller {
+		return ctx.GetPHRList().GetPHRPrivateDetails(issuer, phrNumber)
+	}
+
+	mspID, err := ctx.GetOwnerMSPID(caller)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx.GetPHRList().GetPHRPrivateDetailsFor(mspID, issuer, phrNumber)
+}
+
+// GetTradeHistory returns every purchase of the phr
+// in the order they happened
+func (c *Contract) GetTradeHistory(ctx TransactionContextInterface, issuer string, phrNumber string) ([]*Trade, error) {
+	return ctx.GetTradeList().GetTrades(issuer, phrNumber)
+}
+
+// GetTradeStats returns the trade count, last price and
+// VWAP of the phr
+func (c *Contract) GetTradeStats(ctx TransactionContextInterface, issuer string, phrNumber string) (*TradeStats, error) {
+	trades, err := ctx.GetTradeList().GetTrades(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTradeStats(trades), nil
+}
@@ -0,0 +1,1390 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/fhir"
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// #########
+// HELPERS
+// #########
+type MockPHRList struct {
+	mock.Mock
+}
+
+func (mpl *MockPHRList) AddPHR(phr *PHR) error {
+	args := mpl.Called(phr)
+
+	return args.Error(0)
+}
+
+func (mpl *MockPHRList) GetPHR(issuer string, phrnumber string) (*PHR, error) {
+	args := mpl.Called(issuer, phrnumber)
+
+	return args.Get(0).(*PHR), args.Error(1)
+}
+
+func (mpl *MockPHRList) UpdatePHR(phr *PHR) error {
+	args := mpl.Called(phr)
+
+	return args.Error(0)
+}
+
+func (mpl *MockPHRList) GetPHRHistory(issuer string, phrNumber string) ([]*PHRHistoryEntry, error) {
+	args := mpl.Called(issuer, phrNumber)
+
+	return args.Get(0).([]*PHRHistoryEntry), args.Error(1)
+}
+
+func (mpl *MockPHRList) GetPHRsByIssuer(issuer string) ([]*PHR, error) {
+	args := mpl.Called(issuer)
+
+	return args.Get(0).([]*PHR), args.Error(1)
+}
+
+func (mpl *MockPHRList) GetPHRsByIssuerWithPagination(issuer string, pageSize int32, bookmark string) (*PHRPage, error) {
+	args := mpl.Called(issuer, pageSize, bookmark)
+
+	return args.Get(0).(*PHRPage), args.Error(1)
+}
+
+func (mpl *MockPHRList) GetPHRsByOwnerWithPagination(owner string, pageSize int32, bookmark string) (*PHRPage, error) {
+	args := mpl.Called(owner, pageSize, bookmark)
+
+	return args.Get(0).(*PHRPage), args.Error(1)
+}
+
+func (mpl *MockPHRList) GetPHRsByStateWithPagination(state State, pageSize int32, bookmark string) (*PHRPage, error) {
+	args := mpl.Called(state, pageSize, bookmark)
+
+	return args.Get(0).(*PHRPage), args.Error(1)
+}
+
+func (mpl *MockPHRList) GetPHRsByQuery(query *PHRQuery) ([]*PHR, error) {
+	args := mpl.Called(query)
+
+	return args.Get(0).([]*PHR), args.Error(1)
+}
+
+func (mpl *MockPHRList) GetPHRsByQueryWithPagination(query *PHRQuery, pageSize int32, bookmark string) (*PHRPage, error) {
+	args := mpl.Called(query, pageSize, bookmark)
+
+	return args.Get(0).(*PHRPage), args.Error(1)
+}
+
+func (mpl *MockPHRList) AddPHRPrivateDetails(details *PHRPrivateDetails) error {
+	args := mpl.Called(details)
+
+	return args.Error(0)
+}
+
+func (mpl *MockPHRList) GetPHRPrivateDetails(issuer string, phrNumber string) (*PHRPrivateDetails, error) {
+	args := mpl.Called(issuer, phrNumber)
+
+	return args.Get(0).(*PHRPrivateDetails), args.Error(1)
+}
+
+func (mpl *MockPHRList) AddPHRPrivateDetailsFor(mspID string, details *PHRPrivateDetails) error {
+	args := mpl.Called(mspID, details)
+
+	return args.Error(0)
+}
+
+func (mpl *MockPHRList) GetPHRPrivateDetailsFor(mspID string, issuer string, phrNumber string) (*PHRPrivateDetails, error) {
+	args := mpl.Called(mspID, issuer, phrNumber)
+
+	return args.Get(0).(*PHRPrivateDetails), args.Error(1)
+}
+
+func (mpl *MockPHRList) GetPHRPrivateDetailsHashFor(mspID string, issuer string, phrNumber string) (string, error) {
+	args := mpl.Called(mspID, issuer, phrNumber)
+
+	return args.String(0), args.Error(1)
+}
+
+func (mpl *MockPHRList) SetPHROwnerOrg(issuer string, phrNumber string, mspID string) error {
+	args := mpl.Called(issuer, phrNumber, mspID)
+
+	return args.Error(0)
+}
+
+type MockTradeList struct {
+	mock.Mock
+}
+
+func (mtl *MockTradeList) AddTrade(trade *Trade) error {
+	args := mtl.Called(trade)
+
+	return args.Error(0)
+}
+
+func (mtl *MockTradeList) GetTrades(issuer string, phrNumber string) ([]*Trade, error) {
+	args := mtl.Called(issuer, phrNumber)
+
+	return args.Get(0).([]*Trade), args.Error(1)
+}
+
+type MockListingList struct {
+	mock.Mock
+}
+
+func (mll *MockListingList) GetListing(issuer string, phrNumber string) (*Listing, error) {
+	args := mll.Called(issuer, phrNumber)
+
+	return args.Get(0).(*Listing), args.Error(1)
+}
+
+func (mll *MockListingList) UpdateListing(listing *Listing) error {
+	args := mll.Called(listing)
+
+	return args.Error(0)
+}
+
+func (mll *MockListingList) DeleteListing(issuer string, phrNumber string) error {
+	args := mll.Called(issuer, phrNumber)
+
+	return args.Error(0)
+}
+
+type MockBidList struct {
+	mock.Mock
+}
+
+func (mbl *MockBidList) GetBid(issuer string, phrNumber string, bidder string) (*Bid, error) {
+	args := mbl.Called(issuer, phrNumber, bidder)
+
+	return args.Get(0).(*Bid), args.Error(1)
+}
+
+func (mbl *MockBidList) UpdateBid(bid *Bid) error {
+	args := mbl.Called(bid)
+
+	return args.Error(0)
+}
+
+func (mbl *MockBidList) GetBids(issuer string, phrNumber string) ([]*Bid, error) {
+	args := mbl.Called(issuer, phrNumber)
+
+	return args.Get(0).([]*Bid), args.Error(1)
+}
+
+type MockAuctionList struct {
+	mock.Mock
+}
+
+func (mal *MockAuctionList) GetAuction(issuer string, phrNumber string) (*Auction, error) {
+	args := mal.Called(issuer, phrNumber)
+
+	return args.Get(0).(*Auction), args.Error(1)
+}
+
+func (mal *MockAuctionList) UpdateAuction(auction *Auction) error {
+	args := mal.Called(auction)
+
+	return args.Error(0)
+}
+
+func (mal *MockAuctionList) GetSealedBid(issuer string, phrNumber string, auctionID string, bidder string) (*SealedBid, error) {
+	args := mal.Called(issuer, phrNumber, auctionID, bidder)
+
+	return args.Get(0).(*SealedBid), args.Error(1)
+}
+
+func (mal *MockAuctionList) UpdateSealedBid(bid *SealedBid) error {
+	args := mal.Called(bid)
+
+	return args.Error(0)
+}
+
+func (mal *MockAuctionList) GetSealedBids(issuer string, phrNumber string, auctionID string) ([]*SealedBid, error) {
+	args := mal.Called(issuer, phrNumber, auctionID)
+
+	return args.Get(0).([]*SealedBid), args.Error(1)
+}
+
+type MockTokenList struct {
+	mock.Mock
+}
+
+func (mtl *MockTokenList) GetBalance(owner string) (*Balance, error) {
+	args := mtl.Called(owner)
+
+	return args.Get(0).(*Balance), args.Error(1)
+}
+
+func (mtl *MockTokenList) UpdateBalance(balance *Balance) error {
+	args := mtl.Called(balance)
+
+	return args.Error(0)
+}
+
+func (mtl *MockTokenList) GetAllowance(owner string, spender string) (*Allowance, error) {
+	args := mtl.Called(owner, spender)
+
+	return args.Get(0).(*Allowance), args.Error(1)
+}
+
+func (mtl *MockTokenList) UpdateAllowance(allowance *Allowance) error {
+	args := mtl.Called(allowance)
+
+	return args.Error(0)
+}
+
+type MockRoyaltyList struct {
+	mock.Mock
+}
+
+func (mrl *MockRoyaltyList) GetRoyaltyRate() (*RoyaltyRate, error) {
+	args := mrl.Called()
+
+	return args.Get(0).(*RoyaltyRate), args.Error(1)
+}
+
+func (mrl *MockRoyaltyList) UpdateRoyaltyRate(rate *RoyaltyRate) error {
+	args := mrl.Called(rate)
+
+	return args.Error(0)
+}
+
+func (mrl *MockRoyaltyList) AddRoyalty(royalty *Royalty) error {
+	args := mrl.Called(royalty)
+
+	return args.Error(0)
+}
+
+func (mrl *MockRoyaltyList) GetRoyalties(patient string) ([]*Royalty, error) {
+	args := mrl.Called(patient)
+
+	return args.Get(0).([]*Royalty), args.Error(1)
+}
+
+type MockConsentList struct {
+	mock.Mock
+}
+
+func (mcl *MockConsentList) GetConsent(issuer string, phrNumber string) (*Consent, error) {
+	args := mcl.Called(issuer, phrNumber)
+
+	return args.Get(0).(*Consent), args.Error(1)
+}
+
+func (mcl *MockConsentList) UpdateConsent(consent *Consent) error {
+	args := mcl.Called(consent)
+
+	return args.Error(0)
+}
+
+type MockTransientStub struct {
+	*shimtest.MockStub
+	transient map[string][]byte
+}
+
+func (mts *MockTransientStub) GetTransient() (map[string][]byte, error) {
+	return mts.transient, nil
+}
+
+type MockTransactionContext struct {
+	contractapi.TransactionContext
+	phrList        *MockPHRList
+	tradeList      *MockTradeList
+	listingList    *MockListingList
+	bidList        *MockBidList
+	auctionList    *MockAuctionList
+	tokenList      *MockTokenList
+	royaltyList    *MockRoyaltyList
+	consentList    *MockConsentList
+	identityMapper *MockIdentityMapper
+	role           Role
+	invocation     *Invocation
+	settings       *Settings
+}
+
+func (mtc *MockTransactionContext) GetPHRList() ListInterface {
+	return mtc.phrList
+}
+
+func (mtc *MockTransactionContext) GetTradeList() TradeListInterface {
+	return mtc.tradeList
+}
+
+func (mtc *MockTransactionContext) GetListingList() ListingListInterface {
+	return mtc.listingList
+}
+
+func (mtc *MockTransactionContext) GetBidList() BidListInterface {
+	return mtc.bidList
+}
+
+func (mtc *MockTransactionContext) GetAuctionList() AuctionListInterface {
+	return mtc.auctionList
+}
+
+func (mtc *MockTransactionContext) GetTokenList() TokenListInterface {
+	return mtc.tokenList
+}
+
+func (mtc *MockTransactionContext) GetRoyaltyList() RoyaltyListInterface {
+	return mtc.royaltyList
+}
+
+func (mtc *MockTransactionContext) GetConsentList() ConsentListInterface {
+	return mtc.consentList
+}
+
+func (mtc *MockTransactionContext) GetCaller() (string, error) {
+	return mtc.identityMapper.GetOwner(mtc.GetClientIdentity())
+}
+
+func (mtc *MockTransactionContext) GetOwnerMSPID(owner string) (string, error) {
+	return mtc.identityMapper.GetMSPID(owner)
+}
+
+// Authorize allows every transaction unless a role is set
+func (mtc *MockTransactionContext) Authorize(roles ...Role) error {
+	if mtc.role == "" {
+		return nil
+	}
+
+	return checkRole(mtc.role, roles)
+}
+
+func (mtc *MockTransactionContext) GetInvocation() *Invocation {
+	return mtc.invocation
+}
+
+func (mtc *MockTransactionContext) SetInvocation(inv *Invocation) {
+	mtc.invocation = inv
+}
+
+func (mtc *MockTransactionContext) GetSettings() *Settings {
+	if mtc.settings == nil {
+		return DefaultSettings()
+	}
+
+	return mtc.settings
+}
+
+func (mtc *MockTransactionContext) SetSettings(settings *Settings) {
+	mtc.settings = settings
+}
+
+var mockTxTime = time.Date(2021, 12, 10, 10, 0, 0, 0, time.UTC)
+
+func newMockTransactionContext(caller string) *MockTransactionContext {
+	mci := new(MockClientIdentity)
+	mim := new(MockIdentityMapper)
+	mim.On("GetOwner", mci).Return(caller, nil)
+	mim.On("GetMSPID", "someunmappedowner").Return("", errors.New("GetMSPID error"))
+	mim.On("GetMSPID", mock.Anything).Return("Org1MSP", nil)
+
+	stub := shimtest.NewMockStub("phr", nil)
+	stub.TxID = "sometx"
+	stub.TxTimestamp = &timestamp.Timestamp{Seconds: mockTxTime.Unix()}
+
+	ctx := new(MockTransactionContext)
+	ctx.phrList = new(MockPHRList)
+	ctx.tradeList = new(MockTradeList)
+	ctx.listingList = new(MockListingList)
+	ctx.bidList = new(MockBidList)
+	ctx.auctionList = new(MockAuctionList)
+	ctx.tokenList = new(MockTokenList)
+	ctx.royaltyList = new(MockRoyaltyList)
+	ctx.consentList = new(MockConsentList)
+	ctx.identityMapper = mim
+	ctx.SetClientIdentity(mci)
+	ctx.SetStub(stub)
+
+	return ctx
+}
+
+// assertCodedError asserts err is an error of the catalogue
+// with the passed code and message
+func assertCodedError(t *testing.T, err error, code phrerror.Code, message string, msgAndArgs ...interface{}) {
+	t.Helper()
+
+	decoded, ok := phrerror.DecodeError(err)
+
+	if assert.True(t, ok, msgAndArgs...) {
+		assert.Equal(t, code, decoded.Code, msgAndArgs...)
+		assert.Equal(t, message, decoded.Message, msgAndArgs...)
+	}
+}
+
+// getEvents returns the events set on the mock stub since last called
+func getEvents(ctx *MockTransactionContext) []*PHREvent {
+	stub, ok := ctx.GetStub().(*shimtest.MockStub)
+
+	if !ok {
+		stub = ctx.GetStub().(*MockTransientStub).MockStub
+	}
+
+	events := []*PHREvent{}
+
+	for len(stub.ChaincodeEventsChannel) > 0 {
+		ccEvent := <-stub.ChaincodeEventsChannel
+		event, _ := ParseEvent(ccEvent.Payload)
+
+		if event.Name == ccEvent.EventName {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+func resetPHR(phr *PHR) {
+	phr.Owner = "someowner"
+	phr.SetTrading()
+}
+
+func resetListing(listing *Listing) {
+	listing.Seller = "someowner"
+	listing.AskPrice = 90
+	listing.ExpiresAt = "2021-12-11T10:00:00Z"
+}
+
+// #########
+// TESTS
+// #########
+
+func TestIssue(t *testing.T) {
+	var phr *PHR
+	var err error
+
+	ctx := newMockTransactionContext("someissuer")
+	mpl := ctx.phrList
+
+	contract := new(Contract)
+
+	var sentPHR *PHR
+
+	mpl.On("AddPHR", mock.MatchedBy(func(phr *PHR) bool { sentPHR = phr; return phr.Issuer == "someissuer" })).Return(nil)
+	mpl.On("AddPHR", mock.MatchedBy(func(phr *PHR) bool { sentPHR = phr; return phr.Issuer == "someotherissuer" })).Return(errors.New("AddPHR error"))
+	mpl.On("AddPHR", mock.MatchedBy(func(phr *PHR) bool { sentPHR = phr; return phr.Issuer == "someexistingissuer" })).Return(&ledgerapi.StateExistsError{Key: "someexistingissuer:somephr"})
+	mpl.On("SetPHROwnerOrg", "someissuer", "someunendorsedphr", "Org1MSP").Return(errors.New("SetPHROwnerOrg error"))
+	mpl.On("SetPHROwnerOrg", "someissuer", mock.Anything, "Org1MSP").Return(nil)
+
+	phr, err = contract.Issue(ctx, "someotherissuer", "somephr", "", "2022-12-10T10:00:00Z", 1000, "", "", "", "")
+	assertCodedError(t, err, phrerror.Forbidden, "PHR someotherissuer:somephr can only be issued by issuer someotherissuer. Submitter = someissuer", "should error when issuer is not the submitter")
+	assert.Nil(t, phr, "should not return phr when issuer is not the submitter")
+	mpl.AssertNotCalled(t, "AddPHR", mock.Anything)
+
+	callerErrCtx := newMockTransactionContext("someissuer")
+	callerErrCtx.identityMapper = new(MockIdentityMapper)
+	callerErrCtx.identityMapper.On("GetOwner", mock.Anything).Return("", errors.New("GetOwner error"))
+	phr, err = contract.Issue(callerErrCtx, "someissuer", "somephr", "", "2022-12-10T10:00:00Z", 1000, "", "", "", "")
+	assert.EqualError(t, err, "GetOwner error", "should error when caller unknown")
+	assert.Nil(t, phr, "should not return phr when caller unknown")
+
+	phr, err = contract.Issue(ctx, "someissuer", "somephr", "someissuedate", "2022-12-10T10:00:00Z", 1000, "", "", "", "")
+	assertCodedError(t, err, phrerror.Validation, `Invalid issueDateTime "someissuedate". Expected an RFC 3339 date time`, "should error when issue date time does not parse")
+	assert.IsType(t, new(ValidationError), err, "should return validation error when issue date time does not parse")
+	assert.Nil(t, phr, "should not return phr when issue date time does not parse")
+
+	phr, err = contract.Issue(ctx, "someissuer", "somephr", "", "somematuritydate", 1000, "", "", "", "")
+	assertCodedError(t, err, phrerror.Validation, `Invalid maturityDateTime "somematuritydate". Expected an RFC 3339 date time`, "should error when maturity date time does not parse")
+	assert.Nil(t, phr, "should not return phr when maturity date time does not parse")
+
+	phr, err = contract.Issue(ctx, "someissuer", "somephr", "", "", 1000, "", "", "", "")
+	assertCodedError(t, err, phrerror.Validation, `Invalid maturityDateTime "". Value is required`, "should error when maturity date time missing")
+	assert.Nil(t, phr, "should not return phr when maturity date time missing")
+
+	phr, err = contract.Issue(ctx, "someissuer", "somephr", "", "2021-12-10T10:00:00Z", 1000, "", "", "", "")
+	assertCodedError(t, err, phrerror.Validation, `Invalid maturityDateTime "2021-12-10T10:00:00Z". Must be after the issue date time 2021-12-10T10:00:00Z`, "should error when phr matures on issue")
+	assert.Nil(t, phr, "should not return phr when phr matures on issue")
+	assert.Empty(t, getEvents(ctx), "should not emit event when validation fails")
+
+	someHash := "25c1db4edf964a5af1ef18f2efe118bfa24c2d419ee7e81e605a93aeaca2d78a"
+
+	phr, err = contract.Issue(ctx, "someissuer", "somephr", "", "2022-12-10T10:00:00Z", 1000, someHash, "", "", "")
+	assertCodedError(t, err, phrerror.Validation, `Invalid hashAlgorithm "". Expected SHA-256`, "should error when payload given without hash algorithm")
+	assert.Nil(t, phr, "should not return phr when hash algorithm missing")
+
+	phr, err = contract.Issue(ctx, "someissuer", "somephr", "", "2022-12-10T10:00:00Z", 1000, "somehash", "SHA-256", "application/fhir+json", "s3://somebucket/somephr.json")
+	assertCodedError(t, err, phrerror.Validation, `Invalid contentHash "somehash". Expected a hex encoded SHA-256 hash`, "should error when content hash not SHA-256")
+	assert.Nil(t, phr, "should not return phr when content hash not SHA-256")
+
+	phr, err = contract.Issue(ctx, "someissuer", "somephr", "", "2022-12-10T10:00:00Z", 1000, someHash, "SHA-256", "", "s3://somebucket/somephr.json")
+	assertCodedError(t, err, phrerror.Validation, `Invalid mediaType "". Expected a media type such as application/fhir+json`, "should error when media type missing")
+	assert.Nil(t, phr, "should not return phr when media type missing")
+
+	phr, err = contract.Issue(ctx, "someissuer", "somephr", "", "2022-12-10T10:00:00Z", 1000, someHash, "SHA-256", "application/fhir+json", "somephr.json")
+	assertCodedError(t, err, phrerror.Validation, `Invalid storageURI "somephr.json". Expected an absolute URI`, "should error when storage URI not absolute")
+	assert.Nil(t, phr, "should not return phr when storage URI not absolute")
+
+	expectedPHR := PHR{PHRNumber: "somephr", Issuer: "someissuer", IssueDateTime: "2021-12-10T10:00:00Z", FaceValue: 1000, MaturityDateTime: "2022-12-10T10:00:00Z", Owner: "someissuer", Payload: &Payload{ContentHash: someHash, HashAlgorithm: "SHA-256", MediaType: "application/fhir+json", StorageURI: "s3://somebucket/somephr.json"}, ClientIssueDateTime: "2021-12-09T10:00:00Z", state: 1}
+	phr, err = contract.Issue(ctx, "someissuer", "somephr", "2021-12-09T19:00:00+09:00", "2022-12-10T10:00:00Z", 1000, strings.ToUpper(someHash), "SHA-256", "application/fhir+json", "s3://somebucket/somephr.json")
+	assert.Nil(t, err, "should not error when add phr does not error")
+	assert.Equal(t, sentPHR, phr, "should send the same phr as it returns to add phr")
+	assert.Equal(t, expectedPHR, *phr, "should correctly configure phr")
+	mpl.AssertCalled(t, "SetPHROwnerOrg", "someissuer", "somephr", "Org1MSP")
+	assert.Empty(t, getEvents(ctx), "should leave the issued event to the middleware")
+
+	var sentDetails *PHRPrivateDetails
+	mpl.On("AddPHRPrivateDetails", mock.MatchedBy(func(details *PHRPrivateDetails) bool {
+		sentDetails = details
+		return details.PHRNumber == "someprivatephr"
+	})).Return(nil)
+	mpl.On("AddPHRPrivateDetails", mock.MatchedBy(func(details *PHRPrivateDetails) bool { return details.PHRNumber == "somebadprivatephr" })).Return(errors.New("AddPHRPrivateDetails error"))
+
+	setTransient(ctx, map[string][]byte{PHRPrivateTransientKey: []byte("bad json")})
+	phr, err = contract.Issue(ctx, "someissuer", "someprivatephr", "", "2022-12-10T10:00:00Z", 1000, "", "", "", "")
+	assert.EqualError(t, err, "Error deserializing phr private details. invalid character 'b' looking for beginning of value", "should error when private details do not deserialize")
+	assert.Nil(t, phr, "should not return phr when private details do not deserialize")
+	mpl.AssertNotCalled(t, "AddPHR", mock.MatchedBy(func(phr *PHR) bool { return phr.PHRNumber == "someprivatephr" }))
+
+	setTransient(ctx, map[string][]byte{PHRPrivateTransientKey: []byte(`{"patient":"somepatient","salt":"someshortsalt"}`)})
+	phr, err = contract.Issue(ctx, "someissuer", "someprivatephr", "", "2022-12-10T10:00:00Z", 1000, "", "", "", "")
+	assertCodedError(t, err, phrerror.Validation, `Invalid salt "". Must be at least 16 characters`, "should error when salt of private details too short")
+	assert.Nil(t, phr, "should not return phr when salt too short")
+
+	someSummary := `{"bundleType":"collection","resourceTypes":["Observation","Patient"],"resourceCounts":{"Observation":3,"Patient":1},"dateFrom":"2021-03-01","dateTo":"2021-06-15","codingSystems":["http://loinc.org"]}`
+	setTransient(ctx, map[string][]byte{PHRPrivateTransientKey: []byte(`{"patient":"somepatient","patientIdentifiers":{"mrn":"somemrn"},"clinicalMetadata":{"diagnosis":"somediagnosis"},"summary":` + someSummary + `,"salt":"somesaltsomesalt"}`)})
+	phr, err = contract.Issue(ctx, "someissuer", "someprivatephr", "", "2022-12-10T10:00:00Z", 1000, someHash, "SHA-256", "application/pdf", "s3://somebucket/somephr.pdf")
+	assertCodedError(t, err, phrerror.Validation, `Invalid mediaType "application/pdf". Expected application/fhir+json for a payload with a FHIR summary`, "should error when FHIR summary given for payload not FHIR")
+	assert.Nil(t, phr, "should not return phr when FHIR summary given for payload not FHIR")
+
+	phr, err = contract.Issue(ctx, "someissuer", "somebadprivatephr", "", "2022-12-10T10:00:00Z", 1000, someHash, "SHA-256", "application/fhir+json", "s3://somebucket/somephr.json")
+	assert.EqualError(t, err, "AddPHRPrivateDetails error", "should return error when add private details fails")
+	assert.Nil(t, phr, "should not return phr when add private details fails")
+
+	phr, err = contract.Issue(ctx, "someissuer", "someprivatephr", "", "2022-12-10T10:00:00Z", 1000, someHash, "SHA-256", "application/fhir+json", "s3://somebucket/somephr.json")
+	assert.Nil(t, err, "should not error when add private details does not error")
+	assert.Equal(t, HashPatient("somepatient", "somesaltsomesalt"), phr.PatientHash, "should record the salted patient hash on the public phr")
+	assert.Equal(t, "db500fa86391863d5f3fbe9f9cd26ee54c462c5d456bbd30fa7ac94e73ccd18d", phr.PrivateDetailsHash, "should record the hash of the salted private details on the public phr")
+	publicPHR, _ := phr.Serialize()
+	assert.NotContains(t, string(publicPHR), "somepatient", "should keep the patient off the public phr")
+	assert.NotContains(t, string(publicPHR), "resourceTypes", "should keep the FHIR summary off the public phr")
+	assert.Equal(t, &PHRPrivateDetails{Issuer: "someissuer", PHRNumber: "someprivatephr", Patient: "somepatient", PatientIdentifiers: map[string]string{"mrn": "somemrn"}, ClinicalMetadata: map[string]string{"diagnosis": "somediagnosis"}, Summary: &fhir.Summary{BundleType: "collection", ResourceTypes: []string{"Observation", "Patient"}, ResourceCounts: map[string]int{"Observation": 3, "Patient": 1}, DateFrom: "2021-03-01", DateTo: "2021-06-15", CodingSystems: []string{"http://loinc.org"}}, Salt: "somesaltsomesalt"}, sentDetails, "should add private details of the issued phr")
+	getEvents(ctx)
+	setTransient(ctx, nil)
+
+	phr, err = contract.Issue(ctx, "someissuer", "someunendorsedphr", "", "2022-12-10T10:00:00Z", 1000, "", "", "", "")
+	assert.EqualError(t, err, "SetPHROwnerOrg error", "should return error when setting the org of the owner fails")
+	assert.Nil(t, phr, "should not return phr when setting the org of the owner fails")
+	assert.Empty(t, getEvents(ctx), "should not emit event when setting the org of the owner fails")
+
+	unmappedownerCtx := newMockTransactionContext("someunmappedowner")
+	unmappedownerCtx.phrList = mpl
+	phr, err = contract.Issue(unmappedownerCtx, "someunmappedowner", "somephr", "", "2022-12-10T10:00:00Z", 1000, "", "", "", "")
+	assert.EqualError(t, err, "GetMSPID error", "should return error when the org of the owner is unknown")
+	assert.Nil(t, phr, "should not return phr when the org of the owner is unknown")
+	mpl.AssertNotCalled(t, "AddPHR", mock.MatchedBy(func(phr *PHR) bool { return phr.Issuer == "someunmappedowner" }))
+
+	otherissuerCtx := newMockTransactionContext("someotherissuer")
+	otherissuerCtx.phrList = mpl
+	phr, err = contract.Issue(otherissuerCtx, "someotherissuer", "somephr", "", "2022-12-10T10:00:00Z", 1000, "", "", "", "")
+	assert.EqualError(t, err, "AddPHR error", "should return error when add phr fails")
+	assert.Nil(t, phr, "should not return phr when fails")
+	assert.Empty(t, getEvents(ctx), "should not emit event when add phr fails")
+
+	existingissuerCtx := newMockTransactionContext("someexistingissuer")
+	existingissuerCtx.phrList = mpl
+	phr, err = contract.Issue(existingissuerCtx, "someexistingissuer", "somephr", "", "2022-12-10T10:00:00Z", 1000, "", "", "", "")
+	assert.IsType(t, new(ledgerapi.StateExistsError), err, "should return already exists error when phr already issued")
+	assertCodedError(t, err, phrerror.AlreadyExists, "State already exists for someexistingissuer:somephr", "should return already exists error when phr already issued")
+	assert.Nil(t, phr, "should not return phr when already issued")
+}
+
+func TestBuy(t *testing.T) {
+	var phr *PHR
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mpl := ctx.phrList
+	mtl := ctx.tradeList
+	mll := ctx.listingList
+	mtkl := ctx.tokenList
+	mrl := ctx.royaltyList
+
+	contract := new(Contract)
+
+	wsPHR := &PHR{Issuer: "someissuer", PHRNumber: "somephr"}
+	resetPHR(wsPHR)
+	unlistedPHR := new(PHR)
+	resetPHR(unlistedPHR)
+	wsListing := new(Listing)
+	resetListing(wsListing)
+
+	var sentPHR *PHR
+	var emptyPHR *PHR
+	shouldError := false
+
+	mpl.On("GetPHR", "someissuer", "somephr").Return(wsPHR, nil)
+	mpl.On("GetPHR", "someissuer", "someunlistedphr").Return(unlistedPHR, nil)
+	mpl.On("GetPHR", "someotherissuer", "someotherphr").Return(emptyPHR, errors.New("GetPHR error"))
+	mpl.On("GetPHR", "someunmappedowner", "someunmappedphr").Return(&PHR{PHRNumber: "someunmappedphr", Issuer: "someunmappedowner", Owner: "someowner", state: TRADING}, nil)
+	mpl.On("GetPHR", "someissuer", "someunendorsedphr").Return(&PHR{PHRNumber: "someunendorsedphr", Issuer: "someissuer", Owner: "someowner", state: TRADING}, nil)
+	mpl.On("UpdatePHR", mock.MatchedBy(func(phr *PHR) bool { return shouldError })).Return(errors.New("UpdatePHR error"))
+	mpl.On("UpdatePHR", mock.MatchedBy(func(phr *PHR) bool { sentPHR = phr; return !shouldError })).Return(nil)
+	mpl.On("SetPHROwnerOrg", "someissuer", "someunendorsedphr", "Org1MSP").Return(errors.New("SetPHROwnerOrg error"))
+	mpl.On("SetPHROwnerOrg", "someissuer", "somephr", "Org1MSP").Return(nil)
+	mpl.On("SetPHROwnerOrg", "someissuer", "somephr", "Org1MSP").Return(nil)
+
+	var emptyListing *Listing
+	mll.On("GetListing", "someissuer", "somephr").Return(wsListing, nil)
+	mll.On("GetListing", "someissuer", "someunlistedphr").Return(emptyListing, &ledgerapi.StateNotFoundError{Key: "someissuer:someunlistedphr"})
+	mll.On("DeleteListing", "someissuer", "somephr").Return(nil)
+
+	sellerBalance := &Balance{Owner: "someowner"}
+	buyerBalance := &Balance{Owner: "someotherowner"}
+	mtkl.On("GetBalance", "someowner").Return(sellerBalance, nil)
+	mtkl.On("GetBalance", "someotherowner").Return(buyerBalance, nil)
+	mtkl.On("GetBalance", "somepoorowner").Return(&Balance{Owner: "somepoorowner", Amount: 10}, nil)
+	mtkl.On("UpdateBalance", mock.Anything).Return(nil)
+
+	buyerAllowance := &Allowance{Owner: "someotherowner", Spender: "someowner", Amount: 10000}
+	mtkl.On("GetAllowance", "someotherowner", "someowner").Return(buyerAllowance, nil)
+	mtkl.On("GetAllowance", "somestingyowner", "someowner").Return(&Allowance{Owner: "somestingyowner", Spender: "someowner", Amount: 50}, nil)
+	mtkl.On("GetAllowance", mock.Anything, "someowner").Return(&Allowance{Spender: "someowner", Amount: 10000}, nil)
+	mtkl.On("UpdateAllowance", mock.Anything).Return(nil)
+
+	patientBalance := &Balance{Owner: "somepatienthash"}
+	mtkl.On("GetBalance", "somepatienthash").Return(patientBalance, nil)
+	mrl.On("GetRoyaltyRate").Return(&RoyaltyRate{Percent: 15}, nil)
+
+	var sentRoyalty *Royalty
+	mrl.On("AddRoyalty", mock.MatchedBy(func(royalty *Royalty) bool { sentRoyalty = royalty; return true })).Return(nil)
+
+	var sentTrade *Trade
+	mtl.On("AddTrade", mock.MatchedBy(func(trade *Trade) bool { return trade.Price == 99 })).Return(errors.New("AddTrade error"))
+	mtl.On("AddTrade", mock.MatchedBy(func(trade *Trade) bool { sentTrade = trade; trade.Sequence = 1; return trade.Price != 99 })).Return(nil)
+
+	phr, err = contract.Buy(ctx, "someissuer", "somephr", "someowner", "someotherowner", 100, "2019-12-10:10:00", "")
+	assertCodedError(t, err, phrerror.Validation, `Invalid purchaseDateTime "2019-12-10:10:00". Expected an RFC 3339 date time`, "should error when purchase date time does not parse")
+	assert.Nil(t, phr, "should not return phr when purchase date time does not parse")
+
+	phr, err = contract.Buy(ctx, "someotherissuer", "someotherphr", "someowner", "someotherowner", 100, "2019-12-10T10:00:00Z", "")
+	assert.EqualError(t, err, "GetPHR error", "should return error when GetPHR errors")
+	assert.Nil(t, phr, "should return nil for phr when GetPHR errors")
+
+	phr, err = contract.Buy(ctx, "someissuer", "somephr", "someotherowner", "someowner", 100, "2019-12-10T10:00:00Z", "")
+	assertCodedError(t, err, phrerror.NotOwner, "PHR someissuer:somephr is not owned by someotherowner", "should error when sent owner not correct")
+	assert.Nil(t, phr, "should not return phr for bad owner error")
+
+	phr, err = contract.Buy(ctx, "someissuer", "somephr", "someowner", "someowner", 100, "2019-12-10T10:00:00Z", "")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:somephr is already owned by someowner", "should error when owner buys its own phr")
+	assert.Nil(t, phr, "should not return phr when owner buys its own phr")
+	mtl.AssertNotCalled(t, "AddTrade", mock.Anything)
+
+	otherCtx := newMockTransactionContext("somethirdparty")
+	otherCtx.phrList = mpl
+	phr, err = contract.Buy(otherCtx, "someissuer", "somephr", "someowner", "someotherowner", 100, "2019-12-10T10:00:00Z", "")
+	assertCodedError(t, err, phrerror.NotOwner, "PHR someissuer:somephr is not owned or being bought by submitter somethirdparty", "should error when submitter neither owner nor buyer")
+	assert.Nil(t, phr, "should not return phr for bad submitter error")
+
+	phr, err = contract.Buy(ctx, "someissuer", "someunlistedphr", "someowner", "someotherowner", 100, "2019-12-10T10:00:00Z", "")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:someunlistedphr is not listed for sale", "should error when phr not listed")
+	assert.Nil(t, phr, "should not return phr when not listed")
+
+	resetPHR(wsPHR)
+	wsListing.Seller = "someformerowner"
+	phr, err = contract.Buy(ctx, "someissuer", "somephr", "someowner", "someotherowner", 100, "2019-12-10T10:00:00Z", "")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:somephr is not listed for sale", "should error when listing by former owner")
+	assert.Nil(t, phr, "should not return phr when listing by former owner")
+	resetListing(wsListing)
+
+	resetPHR(wsPHR)
+	wsListing.ExpiresAt = "2021-12-10T10:00:00Z"
+	phr, err = contract.Buy(ctx, "someissuer", "somephr", "someowner", "someotherowner", 100, "2019-12-10T10:00:00Z", "")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:somephr is not listed for sale", "should error when listing expired")
+	assert.Nil(t, phr, "should not return phr when listing expired")
+	resetListing(wsListing)
+
+	resetPHR(wsPHR)
+	phr, err = contract.Buy(ctx, "someissuer", "somephr", "someowner", "someotherowner", 89, "2019-12-10T10:00:00Z", "")
+	assertCodedError(t, err, phrerror.Validation, "Price 89 is below the ask price 90 of PHR someissuer:somephr", "should error when price below ask")
+	assert.Nil(t, phr, "should not return phr when price below ask")
+	mll.AssertNotCalled(t, "DeleteListing", "someissuer", "somephr")
+
+	resetPHR(wsPHR)
+	wsPHR.SetExpired()
+	phr, err = contract.Buy(ctx, "someissuer", "somephr", "someowner", "someotherowner", 100, "2019-12-10T10:00:00Z", "")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:somephr is not trading. Current state = EXPIRED")
+	assert.Nil(t, phr, "should not return phr for bad state error")
+
+	resetPHR(wsPHR)
+	wsPHR.MaturityDateTime = "2021-12-10T10:00:00Z"
+	phr, err = contract.Buy(ctx, "someissuer", "somephr", "someowner", "someotherowner", 100, "2019-12-10T10:00:00Z", "")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:somephr has matured. Maturity date time = 2021-12-10T10:00:00Z", "should error when phr has matured")
+	assert.Nil(t, phr, "should not return phr for matured error")
+	wsPHR.MaturityDateTime = ""
+
+	resetPHR(wsPHR)
+	phr, err = contract.Buy(ctx, "someissuer", "somephr", "someowner", "somestingyowner", 100, "2019-12-10T10:00:00Z", "")
+	assertCodedError(t, err, phrerror.InvalidState, "Insufficient allowance. Allowance of somestingyowner for someowner is 50, needs 100", "should error when owner submits without allowance of the buyer")
+	assert.Nil(t, phr, "should not return phr when owner submits without allowance of the buyer")
+	assert.Equal(t, "someowner", wsPHR.Owner, "should not transfer phr when owner submits without allowance of the buyer")
+
+	resetPHR(wsPHR)
+	phr, err = contract.Buy(ctx, "someissuer", "somephr", "someowner", "somepoorowner", 100, "2019-12-10T10:00:00Z", "")
+	assertCodedError(t, err, phrerror.InvalidState, "Insufficient funds. Balance of somepoorowner is 10, needs 100", "should error when buyer cannot pay")
+	assert.Nil(t, phr, "should not return phr when buyer cannot pay")
+	assert.Equal(t, "someowner", wsPHR.Owner, "should not transfer phr when buyer cannot pay")
+	mpl.AssertNotCalled(t, "UpdatePHR", wsPHR)
+
+	resetPHR(wsPHR)
+	phr, err = contract.Buy(ctx, "someissuer", "somephr", "someowner", "someunmappedowner", 100, "2019-12-10T10:00:00Z", "")
+	assert.EqualError(t, err, "GetMSPID error", "should error when the org of the buyer is unknown")
+	assert.Nil(t, phr, "should not return phr when the org of the buyer is unknown")
+	assert.Equal(t, "someowner", wsPHR.Owner, "should not transfer phr when the org of the buyer is unknown")
+
+	buyerBalance.Amount = 1000
+	resetPHR(wsPHR)
+	shouldError = true
+	phr, err = contract.Buy(ctx, "someissuer", "somephr", "someowner", "someotherowner", 100, "2019-12-10T10:00:00Z", "")
+	assert.EqualError(t, err, "UpdatePHR error", "should error when update phr fails")
+	assert.Nil(t, phr, "should not return phr for bad state error")
+	assert.Empty(t, getEvents(ctx), "should not emit event when update phr fails")
+	shouldError = false
+
+	resetPHR(wsPHR)
+	phr, err = contract.Buy(ctx, "someissuer", "somephr", "someowner", "someotherowner", 99, "2019-12-10T10:00:00Z", "")
+	assert.EqualError(t, err, "AddTrade error", "should error when add trade fails")
+	assert.Nil(t, phr, "should not return phr when add trade fails")
+	assert.Empty(t, getEvents(ctx), "should not emit event when add trade fails")
+
+	resetPHR(wsPHR)
+	wsPHR.SetIssued()
+	buyerBalance.Amount = 1000
+	sellerBalance.Amount = 0
+	buyerAllowance.Amount = 10000
+	phr, err = contract.Buy(ctx, "someissuer", "somephr", "someowner", "someotherowner", 100, "2019-12-10T10:00:00Z", "")
+	assert.Nil(t, err, "should not error when good phr and owner")
+	assert.Equal(t, "someotherowner", phr.Owner, "should update the owner of the phr")
+	assert.True(t, phr.IsTrading(), "should mark issued phr as trading")
+	assert.Equal(t, sentPHR, phr, "should update same phr as it returns in the world state")
+	assert.Equal(t, &Trade{Seller: "someowner", Buyer: "someotherowner", Issuer: "someissuer", PHRNumber: "somephr", Sequence: 1, Price: 100, PurchaseDateTime: "2019-12-10T10:00:00Z", TxID: "sometx", Timestamp: "2021-12-10T10:00:00Z"}, sentTrade, "should record the trade")
+	assert.Equal(t, []*PHREvent{{Version: EventVersion, Name: TradedEvent, Issuer: "someissuer", PHRNumber: "somephr", PreviousOwner: "someowner", NewOwner: "someotherowner", Price: 100, PreviousState: ISSUED, NewState: TRADING, Timestamp: "2021-12-10T10:00:00Z"}}, getEvents(ctx), "should emit traded event")
+	mpl.AssertCalled(t, "SetPHROwnerOrg", "someissuer", "somephr", "Org1MSP")
+	mll.AssertCalled(t, "DeleteListing", "someissuer", "somephr")
+	assert.Equal(t, 900, buyerBalance.Amount, "should debit the price from the buyer")
+	assert.Equal(t, 100, sellerBalance.Amount, "should credit the price to the seller")
+	assert.Equal(t, 9900, buyerAllowance.Amount, "should spend the allowance of the buyer when owner submits")
+	mrl.AssertNotCalled(t, "GetRoyaltyRate")
+	mrl.AssertNotCalled(t, "AddRoyalty", mock.Anything)
+	ctx.consentList.AssertNotCalled(t, "GetConsent", mock.Anything, mock.Anything)
+
+	buyerCtx := newMockTransactionContext("someotherowner")
+	buyerCtx.GetClientIdentity().(*MockClientIdentity).On("GetMSPID").Return("Org1MSP", nil)
+	buyerCtx.phrList = mpl
+	buyerCtx.tradeList = mtl
+	buyerCtx.listingList = mll
+	buyerCtx.tokenList = mtkl
+	buyerCtx.royaltyList = mrl
+	resetPHR(wsPHR)
+	phr, err = contract.Buy(buyerCtx, "someissuer", "somephr", "someowner", "someotherowner", 90, "", "")
+	assert.Nil(t, err, "should not error when buyer submits at the ask price")
+	assert.Equal(t, "someotherowner", phr.Owner, "should update the owner of the phr when buyer submits")
+
+	mcl := buyerCtx.consentList
+	wsConsent := &Consent{Issuer: "someissuer", PHRNumber: "somephr", Purposes: []string{"research"}, BuyerMSPs: []string{"Org1MSP"}, ValidUntil: "2022-12-10T10:00:00Z"}
+	var emptyConsent *Consent
+	mcl.On("GetConsent", "someissuer", "somephr").Return(emptyConsent, &ledgerapi.StateNotFoundError{Key: "someissuer:somephr"}).Once()
+	mcl.On("GetConsent", "someissuer", "somephr").Return(wsConsent, nil)
+	wsPHR.PatientHash = "somepatienthash"
+
+	resetPHR(wsPHR)
+	phr, err = contract.Buy(ctx, "someissuer", "somephr", "someowner", "someotherowner", 90, "", "research")
+	assertCodedError(t, err, phrerror.Forbidden, "PHR someissuer:somephr needs consent of its patient so must be bought by the new owner someotherowner. Submitter = someowner", "should error when owner submits buy of phr naming a patient")
+	assert.Nil(t, phr, "should not return phr when owner submits buy of phr naming a patient")
+
+	resetPHR(wsPHR)
+	phr, err = contract.Buy(buyerCtx, "someissuer", "somephr", "someowner", "someotherowner", 90, "", "")
+	assertCodedError(t, err, phrerror.Validation, `Invalid purpose "". Value is required to buy a phr naming a patient`, "should error when purpose missing")
+	assert.Nil(t, phr, "should not return phr when purpose missing")
+
+	resetPHR(wsPHR)
+	phr, err = contract.Buy(buyerCtx, "someissuer", "somephr", "someowner", "someotherowner", 90, "", "research")
+	assertCodedError(t, err, phrerror.Forbidden, "PHR someissuer:somephr has no consent of its patient", "should error when patient never consented")
+	assert.Nil(t, phr, "should not return phr when patient never consented")
+
+	resetPHR(wsPHR)
+	phr, err = contract.Buy(buyerCtx, "someissuer", "somephr", "someowner", "someotherowner", 90, "", "marketing")
+	assertCodedError(t, err, phrerror.Forbidden, `Consent on PHR someissuer:somephr does not cover MSP Org1MSP for purpose "marketing"`, "should error when consent does not cover purpose")
+	assert.Nil(t, phr, "should not return phr when consent does not cover purpose")
+
+	resetPHR(wsPHR)
+	wsConsent.RevokedDateTime = "2021-12-09T10:00:00Z"
+	phr, err = contract.Buy(buyerCtx, "someissuer", "somephr", "someowner", "someotherowner", 90, "", "research")
+	assertCodedError(t, err, phrerror.Forbidden, `Consent on PHR someissuer:somephr does not cover MSP Org1MSP for purpose "research"`, "should error when consent revoked")
+	assert.Nil(t, phr, "should not return phr when consent revoked")
+	wsConsent.RevokedDateTime = ""
+
+	resetPHR(wsPHR)
+	buyerBalance.Amount = 1000
+	sellerBalance.Amount = 0
+	phr, err = contract.Buy(buyerCtx, "someissuer", "somephr", "someowner", "someotherowner", 110, "", "research")
+	assert.Nil(t, err, "should not error when consent covers buyer MSP and purpose")
+	assert.Equal(t, "someotherowner", phr.Owner, "should update the owner of the phr when consent covers buyer")
+	assert.Equal(t, 890, buyerBalance.Amount, "should debit the whole price from the buyer")
+	assert.Equal(t, 94, sellerBalance.Amount, "should credit the price less the royalty to the seller")
+	assert.Equal(t, 16, patientBalance.Amount, "should credit the royalty rounded down to the patient")
+	assert.Equal(t, &Royalty{PatientHash: "somepatienthash", Issuer: "someissuer", PHRNumber: "somephr", Sequence: 1, Price: 110, Amount: 16, TxID: "sometx", Timestamp: "2021-12-10T10:00:00Z"}, sentRoyalty, "should record the royalty of the trade")
+
+	mpl.On("GetPHRPrivateDetailsHashFor", "Org1MSP", "someissuer", "somephr").Return("", nil).Once()
+	mpl.On("GetPHRPrivateDetailsHashFor", "Org1MSP", "someissuer", "somephr").Return("someotherhash", nil).Once()
+	mpl.On("GetPHRPrivateDetailsHashFor", "Org1MSP", "someissuer", "somephr").Return("somehash", nil)
+	wsPHR.PatientHash = ""
+	wsPHR.PrivateDetailsHash = "somehash"
+
+	resetPHR(wsPHR)
+	phr, err = contract.Buy(ctx, "someissuer", "somephr", "someowner", "someotherowner", 90, "", "")
+	assertCodedError(t, err, phrerror.Forbidden, "PHR someissuer:somephr has private details so must be bought by the new owner someotherowner. Submitter = someowner", "should error when owner submits buy of phr with private details")
+	assert.Nil(t, phr, "should not return phr when owner submits buy of phr with private details")
+
+	resetPHR(wsPHR)
+	phr, err = contract.Buy(buyerCtx, "someissuer", "somephr", "someowner", "someotherowner", 90, "", "")
+	assertCodedError(t, err, phrerror.NotFound, "Private details of PHR someissuer:somephr are not offered to MSP Org1MSP", "should error when private details not in implicit collection of buyer MSP")
+	assert.Nil(t, phr, "should not return phr when private details not offered")
+
+	resetPHR(wsPHR)
+	phr, err = contract.Buy(buyerCtx, "someissuer", "somephr", "someowner", "someotherowner", 90, "", "")
+	assertCodedError(t, err, phrerror.Validation, "Private details of PHR someissuer:somephr offered to MSP Org1MSP do not match the phr", "should error when offered private details do not match hash on phr")
+	assert.Nil(t, phr, "should not return phr when offered private details do not match")
+	assert.Equal(t, "someowner", wsPHR.Owner, "should not transfer phr when offered private details do not match")
+
+	resetPHR(wsPHR)
+	phr, err = contract.Buy(buyerCtx, "someissuer", "somephr", "someowner", "someotherowner", 90, "", "")
+	assert.Nil(t, err, "should not error when offered private details match hash on phr")
+	assert.Equal(t, "someotherowner", phr.Owner, "should update the owner of the phr when offered private details match")
+}
+
+func TestExpire(t *testing.T) {
+	var phr *PHR
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mpl := ctx.phrList
+
+	contract := new(Contract)
+
+	var sentPHR *PHR
+	wsPHR := &PHR{PHRNumber: "somephr", Issuer: "someissuer"}
+	resetPHR(wsPHR)
+
+	var emptyPHR *PHR
+	shouldError := false
+
+	mpl.On("GetPHR", "someissuer", "somephr").Return(wsPHR, nil)
+	mpl.On("GetPHR", "someotherissuer", "someotherphr").Return(emptyPHR, errors.New("GetPHR error"))
+	mpl.On("GetPHR", "someunmappedowner", "someunmappedphr").Return(&PHR{PHRNumber: "someunmappedphr", Issuer: "someunmappedowner", Owner: "someowner", state: TRADING}, nil)
+	mpl.On("GetPHR", "someissuer", "someunendorsedphr").Return(&PHR{PHRNumber: "someunendorsedphr", Issuer: "someissuer", Owner: "someowner", state: TRADING}, nil)
+	mpl.On("UpdatePHR", mock.MatchedBy(func(phr *PHR) bool { return shouldError })).Return(errors.New("UpdatePHR error"))
+	mpl.On("UpdatePHR", mock.MatchedBy(func(phr *PHR) bool { sentPHR = phr; return !shouldError })).Return(nil)
+	mpl.On("GetPHR", "someissuer", "someunlistedphr").Return(&PHR{PHRNumber: "someunlistedphr", Issuer: "someissuer", Owner: "someowner", state: TRADING}, nil)
+	mpl.On("SetPHROwnerOrg", "someissuer", "someunendorsedphr", "Org1MSP").Return(errors.New("SetPHROwnerOrg error"))
+	mpl.On("SetPHROwnerOrg", "someissuer", mock.Anything, "Org1MSP").Return(nil)
+	mll := ctx.listingList
+	mll.On("DeleteListing", "someissuer", "someunlistedphr").Return(errors.New("DeleteListing error"))
+	mll.On("DeleteListing", "someissuer", "somephr").Return(nil)
+
+	phr, err = contract.Expire(ctx, "someissuer", "somephr", "someowner", "2021-12-10:10:00")
+	assertCodedError(t, err, phrerror.Validation, `Invalid expireDateTime "2021-12-10:10:00". Expected an RFC 3339 date time`, "should error when expire date time does not parse")
+	assert.Nil(t, phr, "should not return phr when expire date time does not parse")
+
+	phr, err = contract.Expire(ctx, "someotherissuer", "someotherphr", "someowner", "2021-12-10T10:00:00Z")
+	assert.EqualError(t, err, "GetPHR error", "should error when GetPHR errors")
+	assert.Nil(t, phr, "should not return phr when GetPHR errors")
+
+	phr, err = contract.Expire(ctx, "someissuer", "somephr", "someotherowner", "2021-12-10T10:00:00Z")
+	assertCodedError(t, err, phrerror.NotOwner, "PHR someissuer:somephr is not owned by someotherowner", "should error when phr owned by someone else")
+	assert.Nil(t, phr, "should not return phr when errors as owned by someone else")
+
+	otherCtx := newMockTransactionContext("someotherowner")
+	otherCtx.phrList = mpl
+	phr, err = contract.Expire(otherCtx, "someissuer", "somephr", "someowner", "2021-12-10T10:00:00Z")
+	assertCodedError(t, err, phrerror.NotOwner, "PHR someissuer:somephr is not owned by submitter someotherowner", "should error when submitter not owner")
+	assert.Nil(t, phr, "should not return phr when submitter not owner")
+
+	resetPHR(wsPHR)
+	wsPHR.SetExpired()
+	phr, err = contract.Expire(ctx, "someissuer", "somephr", "someowner", "2021-12-10T10:00:00Z")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:somephr is already expired", "should error when phr already expired")
+	assert.Nil(t, phr, "should not return phr when errors as already expired")
+
+	shouldError = true
+	resetPHR(wsPHR)
+	phr, err = contract.Expire(ctx, "someissuer", "somephr", "someowner", "2021-12-10T10:00:00Z")
+	assert.EqualError(t, err, "UpdatePHR error", "should error when update phr errors")
+	assert.Nil(t, phr, "should not return phr when UpdatePHR errors")
+	shouldError = false
+
+	phr, err = contract.Expire(ctx, "someunmappedowner", "someunmappedphr", "someowner", "")
+	assert.EqualError(t, err, "GetMSPID error", "should error when MSP of issuer not found")
+	assert.Nil(t, phr, "should not return phr when MSP of issuer not found")
+
+	phr, err = contract.Expire(ctx, "someissuer", "someunendorsedphr", "someowner", "")
+	assert.EqualError(t, err, "SetPHROwnerOrg error", "should error when SetPHROwnerOrg errors")
+	assert.Nil(t, phr, "should not return phr when SetPHROwnerOrg errors")
+
+	phr, err = contract.Expire(ctx, "someissuer", "someunlistedphr", "someowner", "")
+	assert.EqualError(t, err, "DeleteListing error", "should error when DeleteListing errors")
+	assert.Nil(t, phr, "should not return phr when DeleteListing errors")
+
+	resetPHR(wsPHR)
+	phr, err = contract.Expire(ctx, "someissuer", "somephr", "someowner", "2021-12-10T10:00:00Z")
+	assert.Nil(t, err, "should not error on good expired")
+	assert.True(t, phr.IsExpired(), "should return expired phr")
+	assert.Equal(t, "2021-12-10T10:00:00Z", phr.ExpireDateTime, "should set expire date time from tx timestamp")
+	assert.Equal(t, "2021-12-10T10:00:00Z", phr.ClientExpireDateTime, "should keep expire date time reported by client")
+	assert.Equal(t, sentPHR, phr, "should update same phr as it returns in the world state")
+	mpl.AssertCalled(t, "SetPHROwnerOrg", "someissuer", "somephr", "Org1MSP")
+	mll.AssertCalled(t, "DeleteListing", "someissuer", "somephr")
+	assert.Empty(t, getEvents(ctx), "should leave the expired event to the middleware")
+}
+
+func TestQueryByIssuer(t *testing.T) {
+	var phrs []*PHR
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mpl := ctx.phrList
+
+	contract := new(Contract)
+
+	wsPHRs := []*PHR{{PHRNumber: "somephr", Issuer: "someissuer"}, {PHRNumber: "someotherphr", Issuer: "someissuer"}}
+	var emptyPHRs []*PHR
+
+	mpl.On("GetPHRsByIssuer", "someissuer").Return(wsPHRs, nil)
+	mpl.On("GetPHRsByIssuer", "someotherissuer").Return(emptyPHRs, errors.New("GetPHRsByIssuer error"))
+
+	phrs, err = contract.QueryByIssuer(ctx, "someotherissuer")
+	assert.EqualError(t, err, "GetPHRsByIssuer error", "should return error when GetPHRsByIssuer errors")
+	assert.Nil(t, phrs, "should not return phrs when GetPHRsByIssuer errors")
+
+	phrs, err = contract.QueryByIssuer(ctx, "someissuer")
+	assert.Nil(t, err, "should not error when GetPHRsByIssuer does not error")
+	assert.Equal(t, wsPHRs, phrs, "should return phrs of the issuer")
+}
+
+func TestQueryByIssuerWithPagination(t *testing.T) {
+	var page *PHRPage
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mpl := ctx.phrList
+
+	contract := new(Contract)
+
+	wsPage := &PHRPage{Records: []*PHR{{PHRNumber: "somephr", Issuer: "someissuer"}}, FetchedRecordsCount: 1, Bookmark: "somebookmark"}
+	var emptyPage *PHRPage
+
+	mpl.On("GetPHRsByIssuerWithPagination", "someissuer", int32(10), "").Return(wsPage, nil)
+	mpl.On("GetPHRsByIssuerWithPagination", "someotherissuer", int32(10), "").Return(emptyPage, errors.New("GetPHRsByIssuerWithPagination error"))
+
+	page, err = contract.QueryByIssuerWithPagination(ctx, "someotherissuer", 10, "")
+	assert.EqualError(t, err, "GetPHRsByIssuerWithPagination error", "should return error when GetPHRsByIssuerWithPagination errors")
+	assert.Nil(t, page, "should not return page when GetPHRsByIssuerWithPagination errors")
+
+	page, err = contract.QueryByIssuerWithPagination(ctx, "someissuer", 10, "")
+	assert.Nil(t, err, "should not error when GetPHRsByIssuerWithPagination does not error")
+	assert.Equal(t, wsPage, page, "should return page of phrs of the issuer")
+}
+
+func TestQueryByOwnerWithPagination(t *testing.T) {
+	var page *PHRPage
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mpl := ctx.phrList
+
+	contract := new(Contract)
+
+	wsPage := &PHRPage{Records: []*PHR{{PHRNumber: "somephr", Owner: "someowner"}}, FetchedRecordsCount: 1, Bookmark: "somebookmark"}
+	var emptyPage *PHRPage
+
+	mpl.On("GetPHRsByOwnerWithPagination", "someowner", int32(10), "somebookmark").Return(wsPage, nil)
+	mpl.On("GetPHRsByOwnerWithPagination", "someotherowner", int32(10), "somebookmark").Return(emptyPage, errors.New("GetPHRsByOwnerWithPagination error"))
+
+	page, err = contract.QueryByOwnerWithPagination(ctx, "someotherowner", 10, "somebookmark")
+	assert.EqualError(t, err, "GetPHRsByOwnerWithPagination error", "should return error when GetPHRsByOwnerWithPagination errors")
+	assert.Nil(t, page, "should not return page when GetPHRsByOwnerWithPagination errors")
+
+	page, err = contract.QueryByOwnerWithPagination(ctx, "someowner", 10, "somebookmark")
+	assert.Nil(t, err, "should not error when GetPHRsByOwnerWithPagination does not error")
+	assert.Equal(t, wsPage, page, "should return page of phrs of the owner")
+}
+
+func TestQueryByStateWithPagination(t *testing.T) {
+	var page *PHRPage
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mpl := ctx.phrList
+
+	contract := new(Contract)
+
+	wsPage := &PHRPage{Records: []*PHR{{PHRNumber: "somephr", state: TRADING}}, FetchedRecordsCount: 1, Bookmark: "somebookmark"}
+	var emptyPage *PHRPage
+
+	mpl.On("GetPHRsByStateWithPagination", TRADING, int32(10), "").Return(wsPage, nil)
+	mpl.On("GetPHRsByStateWithPagination", EXPIRED, int32(10), "").Return(emptyPage, errors.New("GetPHRsByStateWithPagination error"))
+
+	page, err = contract.QueryByStateWithPagination(ctx, "SOLD", 10, "")
+	assertCodedError(t, err, phrerror.Validation, "Unknown phr state SOLD", "should error when state unknown")
+	assert.Nil(t, page, "should not return page when state unknown")
+
+	page, err = contract.QueryByStateWithPagination(ctx, "EXPIRED", 10, "")
+	assert.EqualError(t, err, "GetPHRsByStateWithPagination error", "should return error when GetPHRsByStateWithPagination errors")
+	assert.Nil(t, page, "should not return page when GetPHRsByStateWithPagination errors")
+
+	page, err = contract.QueryByStateWithPagination(ctx, "TRADING", 10, "")
+	assert.Nil(t, err, "should not error when GetPHRsByStateWithPagination does not error")
+	assert.Equal(t, wsPage, page, "should return page of phrs in the state")
+}
+
+func TestQueryPHRs(t *testing.T) {
+	var phrs []*PHR
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mpl := ctx.phrList
+
+	contract := new(Contract)
+
+	wsPHRs := []*PHR{{PHRNumber: "somephr", Owner: "someowner"}}
+	var emptyPHRs []*PHR
+
+	mpl.On("GetPHRsByQuery", &PHRQuery{Owner: "someowner", CurrentState: "TRADING"}).Return(wsPHRs, nil)
+	mpl.On("GetPHRsByQuery", &PHRQuery{Owner: "someotherowner"}).Return(emptyPHRs, errors.New("GetPHRsByQuery error"))
+
+	phrs, err = contract.QueryPHRs(ctx, PHRQuery{Owner: "someotherowner"})
+	assert.EqualError(t, err, "GetPHRsByQuery error", "should return error when GetPHRsByQuery errors")
+	assert.Nil(t, phrs, "should not return phrs when GetPHRsByQuery errors")
+
+	phrs, err = contract.QueryPHRs(ctx, PHRQuery{Owner: "someowner", CurrentState: "TRADING"})
+	assert.Nil(t, err, "should not error when GetPHRsByQuery does not error")
+	assert.Equal(t, wsPHRs, phrs, "should return phrs matching the query")
+}
+
+func TestQueryPHRsWithPagination(t *testing.T) {
+	var page *PHRPage
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mpl := ctx.phrList
+
+	contract := new(Contract)
+
+	wsPage := &PHRPage{Records: []*PHR{{PHRNumber: "somephr", FaceValue: 500}}, FetchedRecordsCount: 1, Bookmark: "somebookmark"}
+	var emptyPage *PHRPage
+
+	mpl.On("GetPHRsByQueryWithPagination", &PHRQuery{MinFaceValue: 100}, int32(10), "").Return(wsPage, nil)
+	mpl.On("GetPHRsByQueryWithPagination", &PHRQuery{MinFaceValue: 1000}, int32(10), "").Return(emptyPage, errors.New("GetPHRsByQueryWithPagination error"))
+
+	page, err = contract.QueryPHRsWithPagination(ctx, PHRQuery{MinFaceValue: 1000}, 10, "")
+	assert.EqualError(t, err, "GetPHRsByQueryWithPagination error", "should return error when GetPHRsByQueryWithPagination errors")
+	assert.Nil(t, page, "should not return page when GetPHRsByQueryWithPagination errors")
+
+	page, err = contract.QueryPHRsWithPagination(ctx, PHRQuery{MinFaceValue: 100}, 10, "")
+	assert.Nil(t, err, "should not error when GetPHRsByQueryWithPagination does not error")
+	assert.Equal(t, wsPage, page, "should return page of phrs matching the query")
+}
+
+func TestGetPHRHistory(t *testing.T) {
+	var entries []*PHRHistoryEntry
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mpl := ctx.phrList
+
+	contract := new(Contract)
+
+	wsEntries := []*PHRHistoryEntry{{TxID: "sometx", Timestamp: "2021-12-10T10:00:00Z", PHR: &PHR{PHRNumber: "somephr", Issuer: "someissuer"}}}
+	var emptyEntries []*PHRHistoryEntry
+
+	mpl.On("GetPHRHistory", "someissuer", "somephr").Return(wsEntries, nil)
+	mpl.On("GetPHRHistory", "someotherissuer", "someotherphr").Return(emptyEntries, errors.New("GetPHRHistory error"))
+
+	entries, err = contract.GetPHRHistory(ctx, "someotherissuer", "someotherphr")
+	assert.EqualError(t, err, "GetPHRHistory error", "should return error when GetPHRHistory errors")
+	assert.Nil(t, entries, "should not return history when GetPHRHistory errors")
+
+	entries, err = contract.GetPHRHistory(ctx, "someissuer", "somephr")
+	assert.Nil(t, err, "should not error when GetPHRHistory does not error")
+	assert.Equal(t, wsEntries, entries, "should return history of the phr")
+}
+
+func TestOfferPrivateDetails(t *testing.T) {
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mpl := ctx.phrList
+
+	contract := new(Contract)
+
+	wsDetails := &PHRPrivateDetails{Issuer: "someissuer", PHRNumber: "somephr", PatientIdentifiers: map[string]string{"mrn": "somemrn"}}
+	wsHash, _ := wsDetails.Hash()
+	wsPHR := &PHR{Issuer: "someissuer", PHRNumber: "somephr", Owner: "someowner", PrivateDetailsHash: wsHash}
+	var emptyPHR *PHR
+
+	mpl.On("GetPHR", "someissuer", "somephr").Return(wsPHR, nil)
+	mpl.On("GetPHR", "someissuer", "someotherphr").Return(&PHR{Issuer: "someissuer", PHRNumber: "someotherphr", Owner: "someotherowner", PrivateDetailsHash: wsHash}, nil)
+	mpl.On("GetPHR", "someissuer", "somepublicphr").Return(&PHR{Issuer: "someissuer", PHRNumber: "somepublicphr", Owner: "someowner"}, nil)
+	mpl.On("GetPHR", "someotherissuer", "someotherphr").Return(emptyPHR, errors.New("GetPHR error"))
+	mpl.On("AddPHRPrivateDetailsFor", "Org1MSP", wsDetails).Return(nil)
+	mpl.On("AddPHRPrivateDetailsFor", "somebadMSP", wsDetails).Return(errors.New("AddPHRPrivateDetailsFor error"))
+
+	err = contract.OfferPrivateDetails(ctx, "someissuer", "somephr", "")
+	assertCodedError(t, err, phrerror.Validation, `Invalid buyerMSP "". Value is required`, "should error when buyer MSP missing")
+
+	err = contract.OfferPrivateDetails(ctx, "someotherissuer", "someotherphr", "Org1MSP")
+	assert.EqualError(t, err, "GetPHR error", "should error when GetPHR errors")
+
+	err = contract.OfferPrivateDetails(ctx, "someissuer", "someotherphr", "Org1MSP")
+	assertCodedError(t, err, phrerror.NotOwner, "PHR someissuer:someotherphr is not owned by submitter someowner", "should error when submitter not owner")
+
+	err = contract.OfferPrivateDetails(ctx, "someissuer", "somepublicphr", "Org1MSP")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:somepublicphr has no private details", "should error when phr has no private details")
+
+	err = contract.OfferPrivateDetails(ctx, "someissuer", "somephr", "Org1MSP")
+	assertCodedError(t, err, phrerror.Validation, `Invalid phrPrivate "". Value is required in the transient data`, "should error when transient data holds no private details")
+
+	setTransient(ctx, map[string][]byte{PHRPrivateTransientKey: []byte(`{"patientIdentifiers":{"mrn":"someothermrn"}}`)})
+	err = contract.OfferPrivateDetails(ctx, "someissuer", "somephr", "Org1MSP")
+	assertCodedError(t, err, phrerror.Validation, "Private details do not match the hash on PHR someissuer:somephr", "should error when private details differ from those issued")
+	mpl.AssertNotCalled(t, "AddPHRPrivateDetailsFor", mock.Anything, mock.Anything)
+
+	setTransient(ctx, map[string][]byte{PHRPrivateTransientKey: []byte(`{"patientIdentifiers":{"mrn":"somemrn"}}`)})
+	err = contract.OfferPrivateDetails(ctx, "someissuer", "somephr", "somebadMSP")
+	assert.EqualError(t, err, "AddPHRPrivateDetailsFor error", "should error when AddPHRPrivateDetailsFor errors")
+
+	err = contract.OfferPrivateDetails(ctx, "someissuer", "somephr", "Org1MSP")
+	assert.Nil(t, err, "should not error when private details match those issued")
+	mpl.AssertCalled(t, "AddPHRPrivateDetailsFor", "Org1MSP", wsDetails)
+}
+
+func TestGetPHRPrivateDetails(t *testing.T) {
+	var details *PHRPrivateDetails
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mpl := ctx.phrList
+
+	contract := new(Contract)
+
+	wsPHR := &PHR{Issuer: "someissuer", PHRNumber: "somephr", Owner: "someowner"}
+	wsDetails := &PHRPrivateDetails{Issuer: "someissuer", PHRNumber: "somephr", PatientIdentifiers: map[string]string{"mrn": "somemrn"}}
+	var emptyPHR *PHR
+	var emptyDetails *PHRPrivateDetails
+
+	mpl.On("GetPHR", "someissuer", "somephr").Return(wsPHR, nil)
+	mpl.On("GetPHR", "someissuer", "someotherphr").Return(&PHR{Issuer: "someissuer", PHRNumber: "someotherphr", Owner: "someotherowner"}, nil)
+	mpl.On("GetPHR", "someowner", "somemissingphr").Return(&PHR{Issuer: "someowner", PHRNumber: "somemissingphr", Owner: "someotherowner"}, nil)
+	mpl.On("GetPHR", "someotherissuer", "someotherphr").Return(emptyPHR, errors.New("GetPHR error"))
+	mpl.On("GetPHR", "someowner", "someissuedphr").Return(&PHR{Issuer: "someowner", PHRNumber: "someissuedphr", Owner: "someotherowner"}, nil)
+	mpl.On("GetPHRPrivateDetailsFor", "Org1MSP", "someissuer", "somephr").Return(wsDetails, nil)
+	mpl.On("GetPHRPrivateDetails", "someowner", "someissuedphr").Return(wsDetails, nil)
+	mpl.On("GetPHRPrivateDetails", "someowner", "somemissingphr").Return(emptyDetails, &ledgerapi.StateNotFoundError{Key: "someowner:somemissingphr"})
+
+	details, err = contract.GetPHRPrivateDetails(ctx, "someotherissuer", "someotherphr")
+	assert.EqualError(t, err, "GetPHR error", "should error when GetPHR errors")
+	assert.Nil(t, details, "should not return details when GetPHR errors")
+
+	details, err = contract.GetPHRPrivateDetails(ctx, "someissuer", "someotherphr")
+	assertCodedError(t, err, phrerror.NotOwner, "Private details of PHR someissuer:someotherphr can only be read by its issuer or owner. Submitter = someowner", "should error when submitter neither issuer nor owner")
+	assert.Nil(t, details, "should not return details to others")
+	mpl.AssertNotCalled(t, "GetPHRPrivateDetails", "someissuer", "someotherphr")
+
+	details, err = contract.GetPHRPrivateDetails(ctx, "someowner", "somemissingphr")
+	assertCodedError(t, err, phrerror.NotFound, "No state found for someowner:somemissingphr", "should error when phr has no private details")
+	assert.Nil(t, details, "should not return details when phr has none")
+
+	details, err = contract.GetPHRPrivateDetails(ctx, "someowner", "someissuedphr")
+	assert.Nil(t, err, "should not error when issuer reads private details")
+	assert.Equal(t, wsDetails, details, "should return private details of the phr from the collection of the issuer")
+
+	details, err = contract.GetPHRPrivateDetails(ctx, "someissuer", "somephr")
+	assert.Nil(t, err, "should not error when owner reads private details")
+	assert.Equal(t, wsDetails, details, "should return private details of the phr from the implicit collection of the owner MSP")
+	mpl.AssertNotCalled(t, "GetPHRPrivateDetails", "someissuer", "somephr")
+}
+
+func TestVerifyPayload(t *testing.T) {
+	var ok bool
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mpl := ctx.phrList
+
+	contract := new(Contract)
+
+	someHash := "25c1db4edf964a5af1ef18f2efe118bfa24c2d419ee7e81e605a93aeaca2d78a"
+	wsPHR := &PHR{Issuer: "someissuer", PHRNumber: "somephr", Payload: &Payload{ContentHash: someHash, HashAlgorithm: "SHA-256", MediaType: "application/fhir+json", StorageURI: "s3://somebucket/somephr.json"}}
+	var emptyPHR *PHR
+
+	mpl.On("GetPHR", "someissuer", "somephr").Return(wsPHR, nil)
+	mpl.On("GetPHR", "someissuer", "someunanchoredphr").Return(&PHR{Issuer: "someissuer", PHRNumber: "someunanchoredphr"}, nil)
+	mpl.On("GetPHR", "someotherissuer", "someotherphr").Return(emptyPHR, errors.New("GetPHR error"))
+
+	ok, err = contract.VerifyPayload(ctx, "someotherissuer", "someotherphr", someHash)
+	assert.EqualError(t, err, "GetPHR error", "should error when GetPHR errors")
+	assert.False(t, ok, "should not verify when GetPHR errors")
+
+	ok, err = contract.VerifyPayload(ctx, "someissuer", "someunanchoredphr", someHash)
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:someunanchoredphr has no anchored payload", "should error when phr has no payload")
+	assert.False(t, ok, "should not verify when phr has no payload")
+
+	ok, err = contract.VerifyPayload(ctx, "someissuer", "somephr", strings.Repeat("0", 64))
+	assert.Nil(t, err, "should not error when hash differs")
+	assert.False(t, ok, "should not verify a different hash")
+
+	ok, err = contract.VerifyPayload(ctx, "someissuer", "somephr", someHash)
+	assert.Nil(t, err, "should not error when hash matches")
+	assert.True(t, ok, "should verify the anchored hash")
+}
+
+func TestGetTradeHistory(t *testing.T) {
+	var trades []*Trade
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mtl := ctx.tradeList
+
+	contract := new(Contract)
+
+	wsTrades := []*Trade{{Issuer: "someissuer", PHRNumber: "somephr", Sequence: 1, Price: 100}}
+	var emptyTrades []*Trade
+
+	mtl.On("GetTrades", "someissuer", "somephr").Return(wsTrades, nil)
+	mtl.On("GetTrades", "someotherissuer", "someotherphr").Return(emptyTrades, errors.New("GetTrades error"))
+
+	trades, err = contract.GetTradeHistory(ctx, "someotherissuer", "someotherphr")
+	assert.EqualError(t, err, "GetTrades error", "should return error when GetTrades errors")
+	assert.Nil(t, trades, "should not return trades when GetTrades errors")
+
+	trades, err = contract.GetTradeHistory(ctx, "someissuer", "somephr")
+	assert.Nil(t, err, "should not error when GetTrades does not error")
+	assert.Equal(t, wsTrades, trades, "should return trades of the phr")
+}
+
+func TestGetTradeStats(t *testing.T) {
+	var stats *TradeStats
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mtl := ctx.tradeList
+
+	contract := new(Contract)
+
+	wsTrades := []*Trade{{Sequence: 1, Price: 100, Timestamp: "sometime"}, {Sequence: 2, Price: 200, Timestamp: "somelatertime"}}
+	var emptyTrades []*Trade
+
+	mtl.On("GetTrades", "someissuer", "somephr").Return(wsTrades, nil)
+	mtl.On("GetTrades", "someotherissuer", "someotherphr").Return(emptyTrades, errors.New("GetTrades error"))
+
+	stats, err = contract.GetTradeStats(ctx, "someotherissuer", "someotherphr")
+	assert.EqualError(t, err, "GetTrades error", "should return error when GetTrades errors")
+	assert.Nil(t, stats, "should not return stats when GetTrades errors")
+
+	stats, err = contract.GetTradeStats(ctx, "someissuer", "somephr")
+	assert.Nil(t, err, "should not error when GetTrades does not error")
+	assert.Equal(t, &TradeStats{TradeCount: 2, LastPrice: 200, LastTimestamp: "somelatertime", VWAP: 150}, stats, "should return stats over trades of the phr")
+}
+
+func TestGetMaturedPHRs(t *testing.T) {
+	var page *PHRPage
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mpl := ctx.phrList
+
+	contract := new(Contract)
+
+	maturedPHR := &PHR{PHRNumber: "somephr", Issuer: "someissuer", Owner: "someowner", MaturityDateTime: "2021-12-10T09:00:00Z", state: TRADING}
+	livePHR := &PHR{PHRNumber: "someotherphr", Issuer: "someissuer", Owner: "someowner", MaturityDateTime: "2021-12-10T11:00:00Z", state: TRADING}
+	expiredPHR := &PHR{PHRNumber: "someexpiredphr", Issuer: "someissuer", Owner: "someissuer", MaturityDateTime: "2021-12-10T09:00:00Z", state: EXPIRED}
+	var emptyPage *PHRPage
+
+	mpl.On("GetPHRsByIssuerWithPagination", "someissuer", int32(10), "").Return(&PHRPage{Records: []*PHR{maturedPHR, livePHR, expiredPHR}, FetchedRecordsCount: 3, Bookmark: "somebookmark"}, nil)
+	mpl.On("GetPHRsByStateWithPagination", TRADING, int32(10), "somebookmark").Return(emptyPage, errors.New("GetPHRsByStateWithPagination error"))
+	mpl.On("GetPHRsByStateWithPagination", ISSUED, int32(10), "").Return(&PHRPage{Records: []*PHR{}}, nil)
+
+	page, err = contract.GetMaturedPHRs(ctx, "", "SOLD", 10, "")
+	assertCodedError(t, err, phrerror.Validation, "Unknown phr state SOLD", "should error when state unknown")
+	assert.Nil(t, page, "should not return page when state unknown")
+
+	page, err = contract.GetMaturedPHRs(ctx, "", "TRADING", 10, "somebookmark")
+	assert.EqualError(t, err, "GetPHRsByStateWithPagination error", "should error when GetPHRsByStateWithPagination errors")
+	assert.Nil(t, page, "should not return page when GetPHRsByStateWithPagination errors")
+
+	page, err = contract.GetMaturedPHRs(ctx, "", "ISSUED", 10, "")
+	assert.Nil(t, err, "should not error for state")
+	assert.Equal(t, &PHRPage{Records: []*PHR{}}, page, "should return empty page when none in state")
+
+	page, err = contract.GetMaturedPHRs(ctx, "someissuer", "", 10, "")
+	assert.Nil(t, err, "should not error for issuer")
+	assert.Equal(t, &PHRPage{Records: []*PHR{maturedPHR}, FetchedRecordsCount: 3, Bookmark: "somebookmark"}, page, "should only return matured phrs which are not expired with the page bookmark")
+	mpl.AssertNotCalled(t, "UpdatePHR", mock.Anything)
+}
+
+func TestExpireMatured(t *testing.T) {
+	var phr *PHR
+	var err error
+
+	ctx := newMockTransactionContext("someotherowner")
+	mpl := ctx.phrList
+	mll := ctx.listingList
+
+	contract := new(Contract)
+
+	maturedPHR := &PHR{PHRNumber: "somephr", Issuer: "someissuer", Owner: "someowner", MaturityDateTime: "2021-12-10T09:00:00Z", state: TRADING}
+	livePHR := &PHR{PHRNumber: "someotherphr", Issuer: "someissuer", Owner: "someowner", MaturityDateTime: "2021-12-10T11:00:00Z", state: TRADING}
+	expiredPHR := &PHR{PHRNumber: "someexpiredphr", Issuer: "someissuer", Owner: "someissuer", MaturityDateTime: "2021-12-10T09:00:00Z", state: EXPIRED}
+	badPHR := &PHR{PHRNumber: "somebadphr", Issuer: "someissuer", Owner: "someowner", MaturityDateTime: "2021-12-10T09:00:00Z", state: TRADING}
+	var emptyPHR *PHR
+
+	mpl.On("GetPHR", "someissuer", "somephr").Return(maturedPHR, nil)
+	mpl.On("GetPHR", "someissuer", "someotherphr").Return(livePHR, nil)
+	mpl.On("GetPHR", "someissuer", "someexpiredphr").Return(expiredPHR, nil)
+	mpl.On("GetPHR", "someissuer", "somebadphr").Return(badPHR, nil)
+	mpl.On("GetPHR", "someotherissuer", "somephr").Return(emptyPHR, errors.New("GetPHR error"))
+	mpl.On("UpdatePHR", badPHR).Return(errors.New("UpdatePHR error"))
+	mpl.On("UpdatePHR", maturedPHR).Return(nil)
+	mpl.On("SetPHROwnerOrg", "someissuer", "somephr", "Org1MSP").Return(nil)
+	mll.On("DeleteListing", "someissuer", "somephr").Return(nil)
+
+	phr, err = contract.ExpireMatured(ctx, "someotherissuer", "somephr")
+	assert.EqualError(t, err, "GetPHR error", "should error when GetPHR errors")
+	assert.Nil(t, phr, "should not return phr when GetPHR errors")
+
+	phr, err = contract.ExpireMatured(ctx, "someissuer", "someexpiredphr")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:someexpiredphr is already expired", "should error when phr already expired")
+	assert.Nil(t, phr, "should not return phr when already expired")
+
+	phr, err = contract.ExpireMatured(ctx, "someissuer", "someotherphr")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:someotherphr has not matured. Maturity date time = 2021-12-10T11:00:00Z", "should error when phr not matured")
+	assert.Nil(t, phr, "should not return phr when not matured")
+	assert.True(t, livePHR.IsTrading(), "should not expire phr before maturity")
+
+	phr, err = contract.ExpireMatured(ctx, "someissuer", "somebadphr")
+	assert.EqualError(t, err, "UpdatePHR error", "should error when UpdatePHR errors")
+	assert.Nil(t, phr, "should not return phr when UpdatePHR errors")
+
+	phr, err = contract.ExpireMatured(ctx, "someissuer", "somephr")
+	assert.Nil(t, err, "should not error when submitter does not own matured phr")
+	assert.Equal(t, maturedPHR, phr, "should return expired phr")
+	assert.True(t, phr.IsExpired(), "should set matured phr to expired")
+	assert.Equal(t, "someissuer", phr.Owner, "should return ownership of matured phr to issuer")
+	assert.Equal(t, "2021-12-10T10:00:00Z", phr.ExpireDateTime, "should set expire date time from tx timestamp")
+	mpl.AssertCalled(t, "SetPHROwnerOrg", "someissuer", "somephr", "Org1MSP")
+	mll.AssertCalled(t, "DeleteListing", "someissuer", "somephr")
+	mpl.AssertNotCalled(t, "UpdatePHR", livePHR)
+	mpl.AssertNotCalled(t, "UpdatePHR", expiredPHR)
+}
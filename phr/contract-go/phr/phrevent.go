@@ -0,0 +1,98 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EventVersion version of the event payload. Increased
+// whenever fields of PHREvent change meaning
+const EventVersion = 1
+
+// Names of the chaincode events emitted by the phr contract
+const (
+	// IssuedEvent emitted when a phr has been issued
+	IssuedEvent = "PHRIssued"
+	// TradedEvent emitted when a phr has been bought
+	TradedEvent = "PHRTraded"
+	// ExpiredEvent emitted when a phr has been expired
+	ExpiredEvent = "PHRExpired"
+	// ListedEvent emitted when a phr has been listed for sale
+	ListedEvent = "PHRListed"
+	// DelistedEvent emitted when a listing has been withdrawn
+	DelistedEvent = "PHRDelisted"
+	// BidPlacedEvent emitted when a bid has been placed on a phr
+	BidPlacedEvent = "PHRBidPlaced"
+	// BidCancelledEvent emitted when a bid has been withdrawn
+	BidCancelledEvent = "PHRBidCancelled"
+	// AuctionStartedEvent emitted when an auction of a phr has been started
+	AuctionStartedEvent = "PHRAuctionStarted"
+	// BidCommittedEvent emitted when a sealed bid has been committed
+	BidCommittedEvent = "PHRBidCommitted"
+	// BidRevealedEvent emitted when a sealed bid has been revealed
+	BidRevealedEvent = "PHRBidRevealed"
+	// AuctionClosedEvent emitted when an auction closed without a winner
+	AuctionClosedEvent = "PHRAuctionClosed"
+	// ConsentGrantedEvent emitted when the patient of a phr has granted consent
+	ConsentGrantedEvent = "PHRConsentGranted"
+	// ConsentRevokedEvent emitted when the patient of a phr has revoked consent
+	ConsentRevokedEvent = "PHRConsentRevoked"
+)
+
+// PHREvent defines the payload of the chaincode
+// event emitted on every phr state transition
+type PHREvent struct {
+	Version       int    `json:"version"`
+	Name          string `json:"name"`
+	Issuer        string `json:"issuer"`
+	PHRNumber     string `json:"phrNumber"`
+	PreviousOwner string `json:"previousOwner"`
+	NewOwner      string `json:"newOwner"`
+	Price         int    `json:"price"`
+	PreviousState State  `json:"previousState"`
+	NewState      State  `json:"newState"`
+	Timestamp     string `json:"timestamp"`
+}
+
+// ParseEvent reads a phr event from a chaincode event payload
+func ParseEvent(payload []byte) (*PHREvent, error) {
+	event := new(PHREvent)
+
+	err := json.Unmarshal(payload, event)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing phr event. %s", err.Error())
+	}
+
+	if event.Version != EventVersion {
+		return nil, fmt.Errorf("Unsupported phr event version %d", event.Version)
+	}
+
+	return event, nil
+}
+
+// setEvent sets the event as the chaincode event of the
+// transaction, stamped with the transaction timestamp
+func setEvent(ctx TransactionContextInterface, event *PHREvent) error {
+	txTime, err := getTxTime(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	event.Version = EventVersion
+	event.Timestamp = txTime.Format(time.RFC3339Nano)
+
+	payload, err := json.Marshal(event)
+
+	if err != nil {
+		return err
+	}
+
+	return ctx.GetStub().SetEvent(event.Name, payload)
+}
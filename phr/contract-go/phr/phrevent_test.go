@@ -0,0 +1,43 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEvent(t *testing.T) {
+	var event *PHREvent
+	var err error
+
+	goodJSON := `{"version":1,"name":"PHRTraded","issuer":"someissuer","phrNumber":"somephr","previousOwner":"someowner","newOwner":"someotherowner","price":100,"previousState":1,"newState":2,"timestamp":"2021-12-10T10:00:00Z"}`
+	event, err = ParseEvent([]byte(goodJSON))
+	assert.Nil(t, err, "should not error for good payload")
+	assert.Equal(t, &PHREvent{Version: 1, Name: TradedEvent, Issuer: "someissuer", PHRNumber: "somephr", PreviousOwner: "someowner", NewOwner: "someotherowner", Price: 100, PreviousState: ISSUED, NewState: TRADING, Timestamp: "2021-12-10T10:00:00Z"}, event, "should read every field of the payload")
+
+	event, err = ParseEvent([]byte(`{"version":2,"name":"PHRTraded"}`))
+	assert.EqualError(t, err, "Unsupported phr event version 2", "should error for other versions")
+	assert.Nil(t, event, "should not return event for other versions")
+
+	event, err = ParseEvent([]byte("bad json"))
+	assert.EqualError(t, err, "Error parsing phr event. invalid character 'b' looking for beginning of value", "should error for bad payload")
+	assert.Nil(t, event, "should not return event for bad payload")
+}
+
+func TestSetEvent(t *testing.T) {
+	ctx := newMockTransactionContext("someowner")
+
+	err := setEvent(ctx, &PHREvent{Name: IssuedEvent, Issuer: "someissuer", PHRNumber: "somephr"})
+	assert.Nil(t, err, "should not error when stub has tx timestamp")
+	assert.Equal(t, []*PHREvent{{Version: EventVersion, Name: IssuedEvent, Issuer: "someissuer", PHRNumber: "somephr", Timestamp: "2021-12-10T10:00:00Z"}}, getEvents(ctx), "should set versioned event stamped with tx timestamp")
+
+	ctx.GetStub().(*shimtest.MockStub).TxTimestamp = nil
+	err = setEvent(ctx, &PHREvent{Name: IssuedEvent})
+	assert.EqualError(t, err, "TxTimestamp not set", "should error when tx timestamp unavailable")
+	assert.Empty(t, getEvents(ctx), "should not set event when tx timestamp unavailable")
+}
@@ -0,0 +1,297 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/endorsement"
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+)
+
+// PHRPage defines a page of phrs returned by
+// a paginated query
+type PHRPage struct {
+	Records             []*PHR `json:"records"`
+	FetchedRecordsCount int32  `json:"fetchedRecordsCount"`
+	Bookmark            string `json:"bookmark"`
+}
+
+// PHRHistoryEntry defines a phr as written
+// by a transaction
+type PHRHistoryEntry struct {
+	TxID      string `json:"txId"`
+	Timestamp string `json:"timestamp"`
+	IsDelete  bool   `json:"isDelete"`
+	PHR       *PHR   `json:"phr"`
+}
+
+// ListInterface defines functionality needed
+// to interact with the world state on behalf
+// of a phr
+type ListInterface interface {
+	AddPHR(*PHR) error
+	GetPHR(string, string) (*PHR, error)
+	UpdatePHR(*PHR) error
+	GetPHRHistory(string, string) ([]*PHRHistoryEntry, error)
+	GetPHRsByIssuer(string) ([]*PHR, error)
+	GetPHRsByIssuerWithPagination(string, int32, string) (*PHRPage, error)
+	GetPHRsByOwnerWithPagination(string, int32, string) (*PHRPage, error)
+	GetPHRsByStateWithPagination(State, int32, string) (*PHRPage, error)
+	GetPHRsByQuery(*PHRQuery) ([]*PHR, error)
+	GetPHRsByQueryWithPagination(*PHRQuery, int32, string) (*PHRPage, error)
+	AddPHRPrivateDetails(*PHRPrivateDetails) error
+	GetPHRPrivateDetails(string, string) (*PHRPrivateDetails, error)
+	AddPHRPrivateDetailsFor(string, *PHRPrivateDetails) error
+	GetPHRPrivateDetailsFor(string, string, string) (*PHRPrivateDetails, error)
+	GetPHRPrivateDetailsHashFor(string, string, string) (string, error)
+	SetPHROwnerOrg(string, string, string) error
+}
+
+// listName namespace of phrs in world state. Also
+// stored as the class of each phr
+const listName = "org.phrnet.phrlist"
+
+// privateListName namespace of phr private details
+// in the private data collection
+const privateListName = "org.phrnet.phrprivatelist"
+
+type list struct {
+	stateList        ledgerapi.StateListInterface
+	privateStateList ledgerapi.StateListInterface
+}
+
+func (phrl *list) AddPHR(phr *PHR) error {
+	return phrl.stateList.AddState(phr)
+}
+
+func (phrl *list) GetPHR(issuer string, phrNumber string) (*PHR, error) {
+	phr := new(PHR)
+
+	err := phrl.stateList.GetState(CreatePHRKey(issuer, phrNumber), phr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return phr, nil
+}
+
+func (phrl *list) UpdatePHR(phr *PHR) error {
+	return phrl.stateList.UpdateState(phr)
+}
+
+func (phrl *list) GetPHRHistory(issuer string, phrNumber string) ([]*PHRHistoryEntry, error) {
+	iterator, err := phrl.stateList.GetStateHistory(CreatePHRKey(issuer, phrNumber))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer iterator.Close()
+
+	entries := []*PHRHistoryEntry{}
+
+	for iterator.HasNext() {
+		phr := new(PHR)
+
+		modification, err := iterator.Next(phr)
+
+		if err != nil {
+			return nil, err
+		}
+
+		entry := &PHRHistoryEntry{TxID: modification.TxID, Timestamp: modification.Timestamp.Format(time.RFC3339Nano), IsDelete: modification.IsDelete}
+
+		if !modification.IsDelete {
+			entry.PHR = phr
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (phrl *list) GetPHRsByIssuer(issuer string) ([]*PHR, error) {
+	iterator, err := phrl.stateList.GetStatesByPartialKey(issuer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return readPHRs(iterator)
+}
+
+func (phrl *list) GetPHRsByIssuerWithPagination(issuer string, pageSize int32, bookmark string) (*PHRPage, error) {
+	iterator, metadata, err := phrl.stateList.GetStatesByPartialKeyWithPagination(issuer, pageSize, bookmark)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return readPHRPage(iterator, metadata)
+}
+
+func (phrl *list) GetPHRsByOwnerWithPagination(owner string, pageSize int32, bookmark string) (*PHRPage, error) {
+	return phrl.GetPHRsByQueryWithPagination(&PHRQuery{Owner: owner}, pageSize, bookmark)
+}
+
+func (phrl *list) GetPHRsByStateWithPagination(state State, pageSize int32, bookmark string) (*PHRPage, error) {
+	return phrl.GetPHRsByQueryWithPagination(&PHRQuery{CurrentState: state.String()}, pageSize, bookmark)
+}
+
+func (phrl *list) GetPHRsByQuery(query *PHRQuery) ([]*PHR, error) {
+	queryString, err := query.JSON()
+
+	if err != nil {
+		return nil, err
+	}
+
+	iterator, err := phrl.stateList.GetStatesByQuery(queryString)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return readPHRs(iterator)
+}
+
+func (phrl *list) GetPHRsByQueryWithPagination(query *PHRQuery, pageSize int32, bookmark string) (*PHRPage, error) {
+	queryString, err := query.JSON()
+
+	if err != nil {
+		return nil, err
+	}
+
+	iterator, metadata, err := phrl.stateList.GetStatesByQueryWithPagination(queryString, pageSize, bookmark)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return readPHRPage(iterator, metadata)
+}
+
+// readPHRPage reads a page of phrs from an iterator and closes it
+func readPHRPage(iterator ledgerapi.StateIteratorInterface, metadata *ledgerapi.QueryMetadata) (*PHRPage, error) {
+	phrs, err := readPHRs(iterator)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &PHRPage{Records: phrs, FetchedRecordsCount: metadata.FetchedRecordsCount, Bookmark: metadata.Bookmark}, nil
+}
+
+// readPHRs reads all phrs from an iterator and closes it
+func readPHRs(iterator ledgerapi.StateIteratorInterface) ([]*PHR, error) {
+	defer iterator.Close()
+
+	phrs := []*PHR{}
+
+	for iterator.HasNext() {
+		phr := new(PHR)
+
+		err := iterator.Next(phr)
+
+		if err != nil {
+			return nil, err
+		}
+
+		phrs = append(phrs, phr)
+	}
+
+	return phrs, nil
+}
+
+// AddPHRPrivateDetails puts the private details of a phr
+// into the PHRPrivateCollection
+func (phrl *list) AddPHRPrivateDetails(details *PHRPrivateDetails) error {
+	return phrl.privateStateList.PutPrivateData(PHRPrivateCollection, details)
+}
+
+// GetPHRPrivateDetails returns the private details of a phr
+// from the PHRPrivateCollection
+func (phrl *list) GetPHRPrivateDetails(issuer string, phrNumber string) (*PHRPrivateDetails, error) {
+	details := new(PHRPrivateDetails)
+
+	err := phrl.privateStateList.GetPrivateData(PHRPrivateCollection, CreatePHRKey(issuer, phrNumber), details)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// AddPHRPrivateDetailsFor puts the private details of a phr
+// into the implicit collection of an MSP
+func (phrl *list) AddPHRPrivateDetailsFor(mspID string, details *PHRPrivateDetails) error {
+	return phrl.privateStateList.PutPrivateData(ImplicitCollection(mspID), details)
+}
+
+// GetPHRPrivateDetailsFor returns the private details of a phr
+// from the implicit collection of an MSP
+func (phrl *list) GetPHRPrivateDetailsFor(mspID string, issuer string, phrNumber string) (*PHRPrivateDetails, error) {
+	details := new(PHRPrivateDetails)
+
+	err := phrl.privateStateList.GetPrivateData(ImplicitCollection(mspID), CreatePHRKey(issuer, phrNumber), details)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// GetPHRPrivateDetailsHashFor returns the hex encoded hash of the private
+// details of a phr in the implicit collection of an MSP, or an empty
+// string if there are none
+func (phrl *list) GetPHRPrivateDetailsHashFor(mspID string, issuer string, phrNumber string) (string, error) {
+	hash, err := phrl.privateStateList.GetPrivateDataHash(ImplicitCollection(mspID), CreatePHRKey(issuer, phrNumber))
+
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash), nil
+}
+
+// SetPHROwnerOrg sets the key-level endorsement policy of a
+// phr to require a peer of the MSP of its owner
+func (phrl *list) SetPHROwnerOrg(issuer string, phrNumber string, mspID string) error {
+	policy, err := endorsement.NewPolicy(mspID)
+
+	if err != nil {
+		return err
+	}
+
+	return phrl.stateList.SetStateValidationParameter(CreatePHRKey(issuer, phrNumber), policy)
+}
+
+// NewList create a new list from context
+func newList(ctx TransactionContextInterface) *list {
+	stateList := new(ledgerapi.StateList)
+	stateList.Ctx = ctx
+	stateList.Name = listName
+	stateList.Deserialize = func(bytes []byte, state ledgerapi.StateInterface) error {
+		return Deserialize(bytes, state.(*PHR))
+	}
+
+	privateStateList := new(ledgerapi.StateList)
+	privateStateList.Ctx = ctx
+	privateStateList.Name = privateListName
+	privateStateList.Deserialize = func(bytes []byte, state ledgerapi.StateInterface) error {
+		return DeserializePHRPrivateDetails(bytes, state.(*PHRPrivateDetails))
+	}
+
+	list := new(list)
+	list.stateList = stateList
+	list.privateStateList = privateStateList
+
+	return list
+}
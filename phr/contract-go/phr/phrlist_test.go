@@ -0,0 +1,574 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/endorsement"
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// #########
+// HELPERS
+// #########
+
+type MockStateList struct {
+	mock.Mock
+}
+
+func (msl *MockStateList) AddState(state ledgerapi.StateInterface) error {
+	args := msl.Called(state)
+
+	return args.Error(0)
+}
+
+func (msl *MockStateList) GetState(key string, state ledgerapi.StateInterface) error {
+	args := msl.Called(key, state)
+
+	switch s := state.(type) {
+	case *PHR:
+		s.PHRNumber = "somephr"
+	case *Listing:
+		s.PHRNumber = "somephr"
+	case *Bid:
+		s.PHRNumber = "somephr"
+	case *Auction:
+		s.PHRNumber = "somephr"
+	case *SealedBid:
+		s.PHRNumber = "somephr"
+	case *Balance:
+		s.Amount = 100
+	case *Allowance:
+		s.Amount = 100
+	case *Consent:
+		s.PHRNumber = "somephr"
+	case *RoyaltyRate:
+		s.Percent = 10
+	}
+
+	return args.Error(0)
+}
+
+func (msl *MockStateList) PutPrivateData(collection string, state ledgerapi.StateInterface) error {
+	args := msl.Called(collection, state)
+
+	return args.Error(0)
+}
+
+func (msl *MockStateList) GetPrivateDataHash(collection string, key string) ([]byte, error) {
+	args := msl.Called(collection, key)
+
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (msl *MockStateList) GetPrivateData(collection string, key string, state ledgerapi.StateInterface) error {
+	args := msl.Called(collection, key, state)
+
+	if s, ok := state.(*PHRPrivateDetails); ok {
+		s.PHRNumber = "somephr"
+	}
+
+	return args.Error(0)
+}
+
+func (msl *MockStateList) SetStateValidationParameter(key string, policy []byte) error {
+	args := msl.Called(key, policy)
+
+	return args.Error(0)
+}
+
+func (msl *MockStateList) UpdateState(state ledgerapi.StateInterface) error {
+	args := msl.Called(state)
+
+	return args.Error(0)
+}
+
+func (msl *MockStateList) DeleteState(key string) error {
+	args := msl.Called(key)
+
+	return args.Error(0)
+}
+
+func (msl *MockStateList) GetStateHistory(key string) (ledgerapi.StateHistoryIteratorInterface, error) {
+	args := msl.Called(key)
+
+	return args.Get(0).(ledgerapi.StateHistoryIteratorInterface), args.Error(1)
+}
+
+func (msl *MockStateList) GetStatesByPartialKey(partialKey string) (ledgerapi.StateIteratorInterface, error) {
+	args := msl.Called(partialKey)
+
+	return args.Get(0).(ledgerapi.StateIteratorInterface), args.Error(1)
+}
+
+func (msl *MockStateList) GetStatesByRange(startKey string, endKey string) (ledgerapi.StateIteratorInterface, error) {
+	args := msl.Called(startKey, endKey)
+
+	return args.Get(0).(ledgerapi.StateIteratorInterface), args.Error(1)
+}
+
+func (msl *MockStateList) GetStatesByQuery(query string) (ledgerapi.StateIteratorInterface, error) {
+	args := msl.Called(query)
+
+	return args.Get(0).(ledgerapi.StateIteratorInterface), args.Error(1)
+}
+
+func (msl *MockStateList) GetStatesByPartialKeyWithPagination(partialKey string, pageSize int32, bookmark string) (ledgerapi.StateIteratorInterface, *ledgerapi.QueryMetadata, error) {
+	args := msl.Called(partialKey, pageSize, bookmark)
+
+	return args.Get(0).(ledgerapi.StateIteratorInterface), args.Get(1).(*ledgerapi.QueryMetadata), args.Error(2)
+}
+
+func (msl *MockStateList) GetStatesByQueryWithPagination(query string, pageSize int32, bookmark string) (ledgerapi.StateIteratorInterface, *ledgerapi.QueryMetadata, error) {
+	args := msl.Called(query, pageSize, bookmark)
+
+	return args.Get(0).(ledgerapi.StateIteratorInterface), args.Get(1).(*ledgerapi.QueryMetadata), args.Error(2)
+}
+
+type MockStateIterator struct {
+	phrNumbers []string
+	err        error
+	closed     bool
+}
+
+func (msi *MockStateIterator) HasNext() bool {
+	return len(msi.phrNumbers) > 0
+}
+
+func (msi *MockStateIterator) Next(state ledgerapi.StateInterface) error {
+	if msi.err != nil {
+		return msi.err
+	}
+
+	state.(*PHR).PHRNumber = msi.phrNumbers[0]
+	msi.phrNumbers = msi.phrNumbers[1:]
+
+	return nil
+}
+
+func (msi *MockStateIterator) Close() error {
+	msi.closed = true
+
+	return nil
+}
+
+type MockStateHistoryIterator struct {
+	modifications []*ledgerapi.StateModification
+	owners        []string
+	err           error
+	closed        bool
+}
+
+func (mshi *MockStateHistoryIterator) HasNext() bool {
+	return len(mshi.modifications) > 0
+}
+
+func (mshi *MockStateHistoryIterator) Next(state ledgerapi.StateInterface) (*ledgerapi.StateModification, error) {
+	if mshi.err != nil {
+		return nil, mshi.err
+	}
+
+	modification := mshi.modifications[0]
+	mshi.modifications = mshi.modifications[1:]
+
+	if !modification.IsDelete {
+		state.(*PHR).Owner = mshi.owners[0]
+	}
+
+	mshi.owners = mshi.owners[1:]
+
+	return modification, nil
+}
+
+func (mshi *MockStateHistoryIterator) Close() error {
+	mshi.closed = true
+
+	return nil
+}
+
+// #########
+// TESTS
+// #########
+
+func TestAddPHR(t *testing.T) {
+	phr := new(PHR)
+
+	list := new(list)
+	msl := new(MockStateList)
+	msl.On("AddState", phr).Return(errors.New("Called add state correctly"))
+	list.stateList = msl
+
+	err := list.AddPHR(phr)
+	assert.EqualError(t, err, "Called add state correctly", "should call state list add state with phr")
+}
+
+func TestGetPHR(t *testing.T) {
+	var phr *PHR
+	var err error
+
+	list := new(list)
+	msl := new(MockStateList)
+	msl.On("GetState", CreatePHRKey("someissuer", "somephr"), mock.MatchedBy(func(state ledgerapi.StateInterface) bool { _, ok := state.(*PHR); return ok })).Return(nil)
+	msl.On("GetState", CreatePHRKey("someotherissuer", "someotherphr"), mock.MatchedBy(func(state ledgerapi.StateInterface) bool { _, ok := state.(*PHR); return ok })).Return(errors.New("GetState error"))
+	list.stateList = msl
+
+	phr, err = list.GetPHR("someissuer", "somephr")
+	assert.Nil(t, err, "should not error when get state on state list does not error")
+	assert.Equal(t, phr.PHRNumber, "somephr", "should use state list GetState to fill phr")
+
+	phr, err = list.GetPHR("someotherissuer", "someotherphr")
+	assert.EqualError(t, err, "GetState error", "should return error when state list get state errors")
+	assert.Nil(t, phr, "should not return phr on error")
+}
+
+func TestUpdatePHR(t *testing.T) {
+	phr := new(PHR)
+
+	list := new(list)
+	msl := new(MockStateList)
+	msl.On("UpdateState", phr).Return(errors.New("Called update state correctly"))
+	list.stateList = msl
+
+	err := list.UpdatePHR(phr)
+	assert.EqualError(t, err, "Called update state correctly", "should call state list update state with phr")
+}
+
+func TestListGetPHRHistory(t *testing.T) {
+	var entries []*PHRHistoryEntry
+	var err error
+
+	issued := time.Date(2021, 12, 10, 10, 0, 0, 0, time.UTC)
+	bought := issued.Add(time.Hour)
+	expired := bought.Add(time.Hour)
+
+	list := new(list)
+	msl := new(MockStateList)
+	goodIterator := &MockStateHistoryIterator{
+		modifications: []*ledgerapi.StateModification{{TxID: "expiretx", Timestamp: expired}, {TxID: "issuetx", Timestamp: issued}, {TxID: "buytx", Timestamp: bought}},
+		owners:        []string{"someissuer", "someissuer", "someowner"},
+	}
+	deleteIterator := &MockStateHistoryIterator{
+		modifications: []*ledgerapi.StateModification{{TxID: "deletetx", Timestamp: issued, IsDelete: true}},
+		owners:        []string{""},
+	}
+	badIterator := &MockStateHistoryIterator{modifications: []*ledgerapi.StateModification{{TxID: "issuetx"}}, err: errors.New("Next error")}
+	var emptyIterator *MockStateHistoryIterator
+	msl.On("GetStateHistory", CreatePHRKey("someissuer", "somephr")).Return(goodIterator, nil)
+	msl.On("GetStateHistory", CreatePHRKey("someissuer", "somedeletedphr")).Return(deleteIterator, nil)
+	msl.On("GetStateHistory", CreatePHRKey("someissuer", "somebadphr")).Return(badIterator, nil)
+	msl.On("GetStateHistory", CreatePHRKey("someotherissuer", "someotherphr")).Return(emptyIterator, errors.New("GetStateHistory error"))
+	list.stateList = msl
+
+	entries, err = list.GetPHRHistory("someotherissuer", "someotherphr")
+	assert.EqualError(t, err, "GetStateHistory error", "should return error when state list get history errors")
+	assert.Nil(t, entries, "should not return history on error")
+
+	entries, err = list.GetPHRHistory("someissuer", "somebadphr")
+	assert.EqualError(t, err, "Next error", "should return error when iterator errors")
+	assert.Nil(t, entries, "should not return history on iterator error")
+	assert.True(t, badIterator.closed, "should close iterator on error")
+
+	entries, err = list.GetPHRHistory("someissuer", "somedeletedphr")
+	assert.Nil(t, err, "should not error for deleted phr")
+	assert.Equal(t, []*PHRHistoryEntry{{TxID: "deletetx", Timestamp: "2021-12-10T10:00:00Z", IsDelete: true}}, entries, "should not return phr for delete")
+
+	entries, err = list.GetPHRHistory("someissuer", "somephr")
+	assert.Nil(t, err, "should not error when state list get history does not error")
+	assert.Equal(t, []*PHRHistoryEntry{
+		{TxID: "expiretx", Timestamp: "2021-12-10T12:00:00Z", PHR: &PHR{Owner: "someissuer"}},
+		{TxID: "issuetx", Timestamp: "2021-12-10T10:00:00Z", PHR: &PHR{Owner: "someissuer"}},
+		{TxID: "buytx", Timestamp: "2021-12-10T11:00:00Z", PHR: &PHR{Owner: "someowner"}},
+	}, entries, "should keep ledger order rather than sort by proposal timestamp")
+	assert.True(t, goodIterator.closed, "should close iterator when done")
+}
+
+func TestGetPHRsByIssuer(t *testing.T) {
+	var phrs []*PHR
+	var err error
+
+	list := new(list)
+	msl := new(MockStateList)
+	goodIterator := &MockStateIterator{phrNumbers: []string{"somephr", "someotherphr"}}
+	badIterator := &MockStateIterator{phrNumbers: []string{"somephr"}, err: errors.New("Next error")}
+	var emptyIterator *MockStateIterator
+	msl.On("GetStatesByPartialKey", "someissuer").Return(goodIterator, nil)
+	msl.On("GetStatesByPartialKey", "somebadissuer").Return(badIterator, nil)
+	msl.On("GetStatesByPartialKey", "someotherissuer").Return(emptyIterator, errors.New("GetStatesByPartialKey error"))
+	list.stateList = msl
+
+	phrs, err = list.GetPHRsByIssuer("someotherissuer")
+	assert.EqualError(t, err, "GetStatesByPartialKey error", "should return error when state list get states errors")
+	assert.Nil(t, phrs, "should not return phrs on error")
+
+	phrs, err = list.GetPHRsByIssuer("somebadissuer")
+	assert.EqualError(t, err, "Next error", "should return error when iterator errors")
+	assert.Nil(t, phrs, "should not return phrs on iterator error")
+	assert.True(t, badIterator.closed, "should close iterator on error")
+
+	phrs, err = list.GetPHRsByIssuer("someissuer")
+	assert.Nil(t, err, "should not error when state list get states does not error")
+	assert.Equal(t, []*PHR{{PHRNumber: "somephr"}, {PHRNumber: "someotherphr"}}, phrs, "should read every phr from iterator")
+	assert.True(t, goodIterator.closed, "should close iterator when done")
+}
+
+func TestGetPHRsByIssuerWithPagination(t *testing.T) {
+	var page *PHRPage
+	var err error
+
+	list := new(list)
+	msl := new(MockStateList)
+	goodIterator := &MockStateIterator{phrNumbers: []string{"somephr"}}
+	badIterator := &MockStateIterator{phrNumbers: []string{"somephr"}, err: errors.New("Next error")}
+	var emptyIterator *MockStateIterator
+	var emptyMetadata *ledgerapi.QueryMetadata
+	metadata := &ledgerapi.QueryMetadata{FetchedRecordsCount: 1, Bookmark: "somebookmark"}
+	msl.On("GetStatesByPartialKeyWithPagination", "someissuer", int32(1), "").Return(goodIterator, metadata, nil)
+	msl.On("GetStatesByPartialKeyWithPagination", "somebadissuer", int32(1), "").Return(badIterator, metadata, nil)
+	msl.On("GetStatesByPartialKeyWithPagination", "someotherissuer", int32(1), "").Return(emptyIterator, emptyMetadata, errors.New("GetStatesByPartialKeyWithPagination error"))
+	list.stateList = msl
+
+	page, err = list.GetPHRsByIssuerWithPagination("someotherissuer", 1, "")
+	assert.EqualError(t, err, "GetStatesByPartialKeyWithPagination error", "should return error when state list get states errors")
+	assert.Nil(t, page, "should not return page on error")
+
+	page, err = list.GetPHRsByIssuerWithPagination("somebadissuer", 1, "")
+	assert.EqualError(t, err, "Next error", "should return error when iterator errors")
+	assert.Nil(t, page, "should not return page on iterator error")
+
+	page, err = list.GetPHRsByIssuerWithPagination("someissuer", 1, "")
+	assert.Nil(t, err, "should not error when state list get states does not error")
+	assert.Equal(t, &PHRPage{Records: []*PHR{{PHRNumber: "somephr"}}, FetchedRecordsCount: 1, Bookmark: "somebookmark"}, page, "should return page of phrs with query metadata")
+	assert.True(t, goodIterator.closed, "should close iterator when done")
+}
+
+func TestGetPHRsByOwnerWithPagination(t *testing.T) {
+	var page *PHRPage
+	var err error
+
+	list := new(list)
+	msl := new(MockStateList)
+	iterator := &MockStateIterator{phrNumbers: []string{"somephr"}}
+	var emptyIterator *MockStateIterator
+	var emptyMetadata *ledgerapi.QueryMetadata
+	metadata := &ledgerapi.QueryMetadata{FetchedRecordsCount: 1, Bookmark: "somebookmark"}
+	msl.On("GetStatesByQueryWithPagination", `{"selector":{"class":"org.phrnet.phrlist","owner":"someowner"}}`, int32(1), "").Return(iterator, metadata, nil)
+	msl.On("GetStatesByQueryWithPagination", `{"selector":{"class":"org.phrnet.phrlist","owner":"someotherowner"}}`, int32(1), "").Return(emptyIterator, emptyMetadata, errors.New("GetStatesByQueryWithPagination error"))
+	list.stateList = msl
+
+	page, err = list.GetPHRsByOwnerWithPagination("someotherowner", 1, "")
+	assert.EqualError(t, err, "GetStatesByQueryWithPagination error", "should return error when state list query errors")
+	assert.Nil(t, page, "should not return page on error")
+
+	page, err = list.GetPHRsByOwnerWithPagination("someowner", 1, "")
+	assert.Nil(t, err, "should not error when state list query does not error")
+	assert.Equal(t, &PHRPage{Records: []*PHR{{PHRNumber: "somephr"}}, FetchedRecordsCount: 1, Bookmark: "somebookmark"}, page, "should query phrs by owner and class")
+}
+
+func TestGetPHRsByStateWithPagination(t *testing.T) {
+	var page *PHRPage
+	var err error
+
+	list := new(list)
+	msl := new(MockStateList)
+	iterator := &MockStateIterator{phrNumbers: []string{"somephr"}}
+	metadata := &ledgerapi.QueryMetadata{FetchedRecordsCount: 1, Bookmark: "somebookmark"}
+	msl.On("GetStatesByQueryWithPagination", `{"selector":{"class":"org.phrnet.phrlist","currentState":2}}`, int32(1), "somebookmark").Return(iterator, metadata, nil)
+	list.stateList = msl
+
+	page, err = list.GetPHRsByStateWithPagination(TRADING, 1, "somebookmark")
+	assert.Nil(t, err, "should not error when state list query does not error")
+	assert.Equal(t, &PHRPage{Records: []*PHR{{PHRNumber: "somephr"}}, FetchedRecordsCount: 1, Bookmark: "somebookmark"}, page, "should query phrs by state and class")
+}
+
+func TestGetPHRsByQuery(t *testing.T) {
+	var phrs []*PHR
+	var err error
+
+	list := new(list)
+	msl := new(MockStateList)
+	iterator := &MockStateIterator{phrNumbers: []string{"somephr", "someotherphr"}}
+	var emptyIterator *MockStateIterator
+	msl.On("GetStatesByQuery", `{"selector":{"class":"org.phrnet.phrlist","issuer":"someissuer","owner":"someowner"}}`).Return(iterator, nil)
+	msl.On("GetStatesByQuery", `{"selector":{"class":"org.phrnet.phrlist","owner":"someotherowner"}}`).Return(emptyIterator, errors.New("GetStatesByQuery error"))
+	list.stateList = msl
+
+	phrs, err = list.GetPHRsByQuery(&PHRQuery{CurrentState: "SOLD"})
+	assertCodedError(t, err, phrerror.Validation, "Unknown phr state SOLD", "should return error when query is invalid")
+	assert.Nil(t, phrs, "should not return phrs when query is invalid")
+
+	phrs, err = list.GetPHRsByQuery(&PHRQuery{Owner: "someotherowner"})
+	assert.EqualError(t, err, "GetStatesByQuery error", "should return error when state list query errors")
+	assert.Nil(t, phrs, "should not return phrs on error")
+
+	phrs, err = list.GetPHRsByQuery(&PHRQuery{Owner: "someowner", Issuer: "someissuer"})
+	assert.Nil(t, err, "should not error when state list query does not error")
+	assert.Equal(t, []*PHR{{PHRNumber: "somephr"}, {PHRNumber: "someotherphr"}}, phrs, "should read every phr matching the query")
+	assert.True(t, iterator.closed, "should close iterator when done")
+}
+
+func TestGetPHRsByQueryWithPagination(t *testing.T) {
+	var page *PHRPage
+	var err error
+
+	list := new(list)
+	msl := new(MockStateList)
+	iterator := &MockStateIterator{phrNumbers: []string{"somephr"}}
+	metadata := &ledgerapi.QueryMetadata{FetchedRecordsCount: 1, Bookmark: "somebookmark"}
+	msl.On("GetStatesByQueryWithPagination", `{"selector":{"class":"org.phrnet.phrlist","faceValue":{"$gte":100,"$lte":1000}}}`, int32(1), "").Return(iterator, metadata, nil)
+	list.stateList = msl
+
+	page, err = list.GetPHRsByQueryWithPagination(&PHRQuery{MinFaceValue: 1000, MaxFaceValue: 100}, 1, "")
+	assertCodedError(t, err, phrerror.Validation, "Min face value 1000 is greater than max face value 100", "should return error when query is invalid")
+	assert.Nil(t, page, "should not return page when query is invalid")
+
+	page, err = list.GetPHRsByQueryWithPagination(&PHRQuery{MinFaceValue: 100, MaxFaceValue: 1000}, 1, "")
+	assert.Nil(t, err, "should not error when state list query does not error")
+	assert.Equal(t, &PHRPage{Records: []*PHR{{PHRNumber: "somephr"}}, FetchedRecordsCount: 1, Bookmark: "somebookmark"}, page, "should return page of phrs matching the query")
+}
+
+func TestAddPHRPrivateDetails(t *testing.T) {
+	details := new(PHRPrivateDetails)
+
+	list := new(list)
+	msl := new(MockStateList)
+	msl.On("PutPrivateData", PHRPrivateCollection, details).Return(errors.New("Called put private data correctly"))
+	list.privateStateList = msl
+
+	err := list.AddPHRPrivateDetails(details)
+	assert.EqualError(t, err, "Called put private data correctly", "should call private state list put private data with details")
+}
+
+func TestListGetPHRPrivateDetails(t *testing.T) {
+	var details *PHRPrivateDetails
+	var err error
+
+	list := new(list)
+	msl := new(MockStateList)
+	msl.On("GetPrivateData", PHRPrivateCollection, CreatePHRKey("someissuer", "somephr"), mock.MatchedBy(func(state ledgerapi.StateInterface) bool { _, ok := state.(*PHRPrivateDetails); return ok })).Return(nil)
+	msl.On("GetPrivateData", PHRPrivateCollection, CreatePHRKey("someotherissuer", "someotherphr"), mock.MatchedBy(func(state ledgerapi.StateInterface) bool { _, ok := state.(*PHRPrivateDetails); return ok })).Return(errors.New("GetPrivateData error"))
+	list.privateStateList = msl
+
+	details, err = list.GetPHRPrivateDetails("someissuer", "somephr")
+	assert.Nil(t, err, "should not error when get private data on state list does not error")
+	assert.Equal(t, "somephr", details.PHRNumber, "should use state list GetPrivateData to fill details")
+
+	details, err = list.GetPHRPrivateDetails("someotherissuer", "someotherphr")
+	assert.EqualError(t, err, "GetPrivateData error", "should return error when state list get private data errors")
+	assert.Nil(t, details, "should not return details on error")
+}
+
+func TestAddPHRPrivateDetailsFor(t *testing.T) {
+	details := new(PHRPrivateDetails)
+
+	list := new(list)
+	msl := new(MockStateList)
+	msl.On("PutPrivateData", "_implicit_org_Org1MSP", details).Return(errors.New("Called put private data correctly"))
+	list.privateStateList = msl
+
+	err := list.AddPHRPrivateDetailsFor("Org1MSP", details)
+	assert.EqualError(t, err, "Called put private data correctly", "should call private state list put private data with implicit collection of MSP")
+}
+
+func TestGetPHRPrivateDetailsFor(t *testing.T) {
+	var details *PHRPrivateDetails
+	var err error
+
+	list := new(list)
+	msl := new(MockStateList)
+	msl.On("GetPrivateData", "_implicit_org_Org1MSP", CreatePHRKey("someissuer", "somephr"), mock.MatchedBy(func(state ledgerapi.StateInterface) bool { _, ok := state.(*PHRPrivateDetails); return ok })).Return(nil)
+	msl.On("GetPrivateData", "_implicit_org_Org2MSP", CreatePHRKey("someissuer", "somephr"), mock.MatchedBy(func(state ledgerapi.StateInterface) bool { _, ok := state.(*PHRPrivateDetails); return ok })).Return(errors.New("GetPrivateData error"))
+	list.privateStateList = msl
+
+	details, err = list.GetPHRPrivateDetailsFor("Org1MSP", "someissuer", "somephr")
+	assert.Nil(t, err, "should not error when get private data on state list does not error")
+	assert.Equal(t, "somephr", details.PHRNumber, "should use state list GetPrivateData on implicit collection of MSP to fill details")
+
+	details, err = list.GetPHRPrivateDetailsFor("Org2MSP", "someissuer", "somephr")
+	assert.EqualError(t, err, "GetPrivateData error", "should return error when state list get private data errors")
+	assert.Nil(t, details, "should not return details on error")
+}
+
+func TestGetPHRPrivateDetailsHashFor(t *testing.T) {
+	var hash string
+	var err error
+
+	list := new(list)
+	msl := new(MockStateList)
+	msl.On("GetPrivateDataHash", "_implicit_org_Org1MSP", CreatePHRKey("someissuer", "somephr")).Return([]byte{0xab, 0xcd}, nil)
+	msl.On("GetPrivateDataHash", "_implicit_org_Org1MSP", CreatePHRKey("someissuer", "someotherphr")).Return([]byte(nil), nil)
+	msl.On("GetPrivateDataHash", "_implicit_org_Org2MSP", CreatePHRKey("someissuer", "somephr")).Return([]byte(nil), errors.New("GetPrivateDataHash error"))
+	list.privateStateList = msl
+
+	hash, err = list.GetPHRPrivateDetailsHashFor("Org1MSP", "someissuer", "somephr")
+	assert.Nil(t, err, "should not error when get private data hash does not error")
+	assert.Equal(t, "abcd", hash, "should hex encode the hash")
+
+	hash, err = list.GetPHRPrivateDetailsHashFor("Org1MSP", "someissuer", "someotherphr")
+	assert.Nil(t, err, "should not error when there are no private details")
+	assert.Equal(t, "", hash, "should return empty hash when there are no private details")
+
+	hash, err = list.GetPHRPrivateDetailsHashFor("Org2MSP", "someissuer", "somephr")
+	assert.EqualError(t, err, "GetPrivateDataHash error", "should return error when get private data hash errors")
+	assert.Equal(t, "", hash, "should not return hash on error")
+}
+
+func TestSetPHROwnerOrg(t *testing.T) {
+	var err error
+
+	policy, _ := endorsement.NewPolicy("Org2MSP")
+
+	list := new(list)
+	msl := new(MockStateList)
+	msl.On("SetStateValidationParameter", CreatePHRKey("someissuer", "somephr"), policy).Return(errors.New("Called set state validation parameter correctly"))
+	list.stateList = msl
+
+	err = list.SetPHROwnerOrg("someissuer", "somephr", "Org2MSP")
+	assert.EqualError(t, err, "Called set state validation parameter correctly", "should call state list set state validation parameter with policy of msp")
+
+	err = list.SetPHROwnerOrg("someissuer", "somephr", "")
+	assert.EqualError(t, err, "Endorsement policy cannot require an empty MSP ID", "should error when msp is empty")
+
+	stub := shimtest.NewMockStub("phr", nil)
+	ctx := new(TransactionContext)
+	ctx.SetStub(stub)
+
+	err = newList(ctx).SetPHROwnerOrg("someissuer", "somephr", "Org1MSP")
+	assert.Nil(t, err, "should not error when stub sets policy")
+	key, _ := stub.CreateCompositeKey(listName, []string{"someissuer", "somephr"})
+	stored, _ := stub.GetStateValidationParameter(key)
+	orgs, _ := endorsement.Orgs(stored)
+	assert.Equal(t, []string{"Org1MSP"}, orgs, "should set policy on ledger key of phr")
+}
+
+func TestNewStateList(t *testing.T) {
+	ctx := new(TransactionContext)
+	list := newList(ctx)
+	stateList, ok := list.stateList.(*ledgerapi.StateList)
+
+	assert.True(t, ok, "should make statelist of type ledgerapi.StateList")
+	assert.Equal(t, ctx, stateList.Ctx, "should set the context to passed context")
+	assert.Equal(t, "org.phrnet.phrlist", stateList.Name, "should set the name for the list")
+
+	expectedErr := Deserialize([]byte("bad json"), new(PHR))
+	err := stateList.Deserialize([]byte("bad json"), new(PHR))
+	assert.EqualError(t, err, expectedErr.Error(), "should call Deserialize when stateList.Deserialize called")
+
+	privateStateList, ok := list.privateStateList.(*ledgerapi.StateList)
+
+	assert.True(t, ok, "should make private statelist of type ledgerapi.StateList")
+	assert.Equal(t, ctx, privateStateList.Ctx, "should set the context of the private statelist to passed context")
+	assert.Equal(t, "org.phrnet.phrprivatelist", privateStateList.Name, "should set the name for the private list")
+
+	expectedErr = DeserializePHRPrivateDetails([]byte("bad json"), new(PHRPrivateDetails))
+	err = privateStateList.Deserialize([]byte("bad json"), new(PHRPrivateDetails))
+	assert.EqualError(t, err, expectedErr.Error(), "should call DeserializePHRPrivateDetails when privateStateList.Deserialize called")
+}
@@ -0,0 +1,171 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/fhir"
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+)
+
+// PHRPrivateCollection private data collection holding the
+// private details of phrs. Defined in collections_config.json
+const PHRPrivateCollection = "phrPrivateDetails"
+
+// PHRPrivateTransientKey key of the transient data holding
+// the private details passed to Issue
+const PHRPrivateTransientKey = "phrPrivate"
+
+// PatientSaltTransientKey key of the transient data holding the
+// salt of the patient hash, passed by the patient to change consent
+const PatientSaltTransientKey = "patientSalt"
+
+// MinSaltLength minimum length of the salt of private details, so
+// their hash and the patient hash cannot be found by guessing
+const MinSaltLength = 16
+
+// ImplicitCollection returns the name of the implicit private
+// data collection of an MSP, which only its peers store
+func ImplicitCollection(mspID string) string {
+	return "_implicit_org_" + mspID
+}
+
+// PHRPrivateDetails defines the sensitive attributes of a phr,
+// kept in a private data collection instead of the world state
+type PHRPrivateDetails struct {
+	Issuer    string `json:"issuer"`
+	PHRNumber string `json:"phrNumber"`
+	// Pseudonymous identifier of the patient the record is about
+	Patient            string            `json:"patient,omitempty" metadata:"patient,optional"`
+	PatientIdentifiers map[string]string `json:"patientIdentifiers,omitempty" metadata:"patientIdentifiers,optional"`
+	ClinicalMetadata   map[string]string `json:"clinicalMetadata,omitempty" metadata:"clinicalMetadata,optional"`
+	// Summary of the FHIR bundle the payload of the phr holds
+	Summary *fhir.Summary `json:"summary,omitempty" metadata:"summary,optional"`
+	// Random value chosen by the issuer, hashed with the details
+	// and the patient so neither hash can be found by guessing
+	Salt string `json:"salt"`
+}
+
+// GetSplitKey returns values which should be used to form key
+func (details *PHRPrivateDetails) GetSplitKey() []string {
+	return []string{details.Issuer, details.PHRNumber}
+}
+
+// Serialize formats the private details as JSON bytes
+func (details *PHRPrivateDetails) Serialize() ([]byte, error) {
+	return json.Marshal(details)
+}
+
+// Hash returns the hex encoded SHA-256 of the serialized private
+// details, the same hash the ledger records for them. The details
+// hold the salt, so the hash is salted
+func (details *PHRPrivateDetails) Hash() (string, error) {
+	bytes, err := details.Serialize()
+
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(bytes)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// HashPatient returns the hex encoded SHA-256 of the JSON array of the
+// patient and the salt of the private details, the patient hash kept
+// on the phr
+func HashPatient(patient string, salt string) string {
+	bytes, _ := json.Marshal([]string{patient, salt})
+	sum := sha256.Sum256(bytes)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// validate checks the private details passed to Issue for a phr
+// with the payload. The salt is required, and a FHIR summary is only
+// allowed with a FHIR payload
+func (details *PHRPrivateDetails) validate(payload *Payload) error {
+	if len(details.Salt) < MinSaltLength {
+		return &ValidationError{Field: "salt", Value: "", Reason: fmt.Sprintf("Must be at least %d characters", MinSaltLength)}
+	}
+
+	if details.Summary == nil {
+		return nil
+	}
+
+	if payload == nil {
+		return &ValidationError{Field: "mediaType", Value: "", Reason: "Expected " + MediaTypeFHIR + " for a payload with a FHIR summary"}
+	}
+
+	if !payload.IsFHIR() {
+		return &ValidationError{Field: "mediaType", Value: payload.MediaType, Reason: "Expected " + MediaTypeFHIR + " for a payload with a FHIR summary"}
+	}
+
+	return details.Summary.Validate()
+}
+
+// DeserializePHRPrivateDetails formats the private details from JSON bytes
+func DeserializePHRPrivateDetails(bytes []byte, details *PHRPrivateDetails) error {
+	err := json.Unmarshal(bytes, details)
+
+	if err != nil {
+		return fmt.Errorf("Error deserializing phr private details. %s", err.Error())
+	}
+
+	return nil
+}
+
+// getTransientPrivateDetails reads the private details of a phr from the
+// transient data. Returns nil when the transient data holds none
+func getTransientPrivateDetails(ctx TransactionContextInterface, issuer string, phrNumber string) (*PHRPrivateDetails, error) {
+	transient, err := ctx.GetStub().GetTransient()
+
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, ok := transient[PHRPrivateTransientKey]
+
+	if !ok {
+		return nil, nil
+	}
+
+	details := new(PHRPrivateDetails)
+
+	err = DeserializePHRPrivateDetails(bytes, details)
+
+	if err != nil {
+		return nil, err
+	}
+
+	details.Issuer = issuer
+	details.PHRNumber = phrNumber
+
+	return details, nil
+}
+
+// checkPrivateTransfer checks the private details of a phr were put in
+// the implicit collection of the buyer MSP, and match the hash on the phr
+func checkPrivateTransfer(ctx TransactionContextInterface, phr *PHR, mspID string) error {
+	hash, err := ctx.GetPHRList().GetPHRPrivateDetailsHashFor(mspID, phr.Issuer, phr.PHRNumber)
+
+	if err != nil {
+		return err
+	}
+
+	if hash == "" {
+		return phrerror.Errorf(phrerror.NotFound, "Private details of PHR %s:%s are not offered to MSP %s", phr.Issuer, phr.PHRNumber, mspID)
+	}
+
+	if hash != phr.PrivateDetailsHash {
+		return phrerror.Errorf(phrerror.Validation, "Private details of PHR %s:%s offered to MSP %s do not match the phr", phr.Issuer, phr.PHRNumber, mspID)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/fhir"
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// #########
+// HELPERS
+// #########
+
+// MockImplicitCollectionStub simulates the implicit collections of MSPs
+// with the hashes of private data the mock stub does not implement
+type MockImplicitCollectionStub struct {
+	*shimtest.MockStub
+}
+
+func (mics *MockImplicitCollectionStub) GetPrivateDataHash(collection string, key string) ([]byte, error) {
+	value, err := mics.MockStub.GetPrivateData(collection, key)
+
+	if err != nil || value == nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(value)
+
+	return sum[:], nil
+}
+
+func newImplicitCollectionContext(mspID string) *TransactionContext {
+	ctx := new(TransactionContext)
+	ctx.SetStub(&MockImplicitCollectionStub{MockStub: shimtest.NewMockStub("phr", nil)})
+	ctx.SetClientIdentity(newMockClientIdentity(mspID, ""))
+
+	return ctx
+}
+
+// #########
+// TESTS
+// #########
+
+func TestImplicitCollection(t *testing.T) {
+	assert.Equal(t, "_implicit_org_Org1MSP", ImplicitCollection("Org1MSP"), "should return the implicit collection name of the MSP")
+}
+
+func TestPHRPrivateDetailsGetSplitKey(t *testing.T) {
+	details := &PHRPrivateDetails{Issuer: "someissuer", PHRNumber: "somephr"}
+
+	assert.Equal(t, []string{"someissuer", "somephr"}, details.GetSplitKey(), "should return issuer and phr number as split key")
+}
+
+func TestPHRPrivateDetailsSerialize(t *testing.T) {
+	details := &PHRPrivateDetails{Issuer: "someissuer", PHRNumber: "somephr", Patient: "somepatient", PatientIdentifiers: map[string]string{"mrn": "somemrn"}, ClinicalMetadata: map[string]string{"diagnosis": "somediagnosis"}, Summary: &fhir.Summary{BundleType: "collection", ResourceTypes: []string{"Observation"}, ResourceCounts: map[string]int{"Observation": 2}}, Salt: "somesaltsomesalt"}
+
+	bytes, err := details.Serialize()
+	assert.Nil(t, err, "should not error on serialize")
+	assert.Equal(t, `{"issuer":"someissuer","phrNumber":"somephr","patient":"somepatient","patientIdentifiers":{"mrn":"somemrn"},"clinicalMetadata":{"diagnosis":"somediagnosis"},"summary":{"bundleType":"collection","resourceTypes":["Observation"],"resourceCounts":{"Observation":2}},"salt":"somesaltsomesalt"}`, string(bytes), "should return JSON formatted value")
+}
+
+func TestPHRPrivateDetailsHash(t *testing.T) {
+	details := &PHRPrivateDetails{Issuer: "someissuer", PHRNumber: "somephr", Patient: "somepatient", PatientIdentifiers: map[string]string{"mrn": "somemrn"}, Salt: "somesaltsomesalt"}
+
+	hash, err := details.Hash()
+	assert.Nil(t, err, "should not error on hash")
+	assert.Equal(t, "fddebb5d0f8cf00f3585c783e17c2f23b1aa0ba4397e08397f7a9a21fd4426c3", hash, "should return hex encoded SHA-256 of the JSON")
+
+	details.Salt = "someothersaltsomeothersalt"
+	hash, _ = details.Hash()
+	assert.Equal(t, "86c6145b53187a83a5df5d83e014a7895ce35b6faf4948ce2879ab14758ba956", hash, "should change hash with the salt")
+}
+
+func TestHashPatient(t *testing.T) {
+	assert.Equal(t, "b7f9be195056321a97f9f399a6ef2c1efd51aad69e960c5ba3971ef473f86f89", HashPatient("somepatient", "somesaltsomesalt"), "should return hex encoded SHA-256 of the JSON array of patient and salt")
+	assert.NotEqual(t, HashPatient("somepatient", "somesaltsomesalt"), HashPatient("somepatient", "someothersaltsomeothersalt"), "should change hash with the salt")
+	assert.NotEqual(t, HashPatient("somepatient", "somesaltsomesalt"), HashPatient("somepatients", "omesaltsomesalt"), "should not collide when characters move between patient and salt")
+}
+
+func TestPHRPrivateDetailsValidate(t *testing.T) {
+	fhirPayload := &Payload{MediaType: "application/fhir+json; fhirVersion=4.0"}
+	summary := &fhir.Summary{BundleType: "collection", ResourceTypes: []string{"Observation"}, ResourceCounts: map[string]int{"Observation": 2}}
+
+	err := (&PHRPrivateDetails{Salt: "someshortsalt"}).validate(nil)
+	assertCodedError(t, err, phrerror.Validation, `Invalid salt "". Must be at least 16 characters`, "should error when salt too short")
+
+	err = (&PHRPrivateDetails{Salt: "somesaltsomesalt"}).validate(nil)
+	assert.Nil(t, err, "should not error without summary")
+
+	err = (&PHRPrivateDetails{Summary: summary, Salt: "somesaltsomesalt"}).validate(nil)
+	assertCodedError(t, err, phrerror.Validation, `Invalid mediaType "". Expected application/fhir+json for a payload with a FHIR summary`, "should error when summary given without payload")
+
+	err = (&PHRPrivateDetails{Summary: summary, Salt: "somesaltsomesalt"}).validate(&Payload{MediaType: "application/pdf"})
+	assertCodedError(t, err, phrerror.Validation, `Invalid mediaType "application/pdf". Expected application/fhir+json for a payload with a FHIR summary`, "should error when summary given for payload not FHIR")
+
+	err = (&PHRPrivateDetails{Summary: &fhir.Summary{BundleType: "collection"}, Salt: "somesaltsomesalt"}).validate(fhirPayload)
+	assertCodedError(t, err, phrerror.Validation, "Invalid FHIR summary. Summary counts no resources", "should error when summary does not validate")
+
+	err = (&PHRPrivateDetails{Summary: summary, Salt: "somesaltsomesalt"}).validate(fhirPayload)
+	assert.Nil(t, err, "should not error for summary of FHIR payload")
+}
+
+func TestDeserializePHRPrivateDetails(t *testing.T) {
+	var details *PHRPrivateDetails
+	var err error
+
+	details = new(PHRPrivateDetails)
+	err = DeserializePHRPrivateDetails([]byte(`{"issuer":"someissuer","phrNumber":"somephr","patientIdentifiers":{"mrn":"somemrn"}}`), details)
+	assert.Nil(t, err, "should not return error for deserialize")
+	assert.Equal(t, &PHRPrivateDetails{Issuer: "someissuer", PHRNumber: "somephr", PatientIdentifiers: map[string]string{"mrn": "somemrn"}}, details, "should create expected details")
+
+	details = new(PHRPrivateDetails)
+	err = DeserializePHRPrivateDetails([]byte(`{"patientIdentifiers":"somemrn"}`), details)
+	assert.EqualError(t, err, "Error deserializing phr private details. json: cannot unmarshal string into Go struct field PHRPrivateDetails.patientIdentifiers of type map[string]string", "should return error for bad data")
+}
+
+func TestGetTransientPrivateDetails(t *testing.T) {
+	var details *PHRPrivateDetails
+	var err error
+
+	ctx := newMockTransactionContext("someissuer")
+
+	details, err = getTransientPrivateDetails(ctx, "someissuer", "somephr")
+	assert.Nil(t, err, "should not error when transient data holds no details")
+	assert.Nil(t, details, "should not return details when transient data holds none")
+
+	setTransient(ctx, map[string][]byte{PHRPrivateTransientKey: []byte("bad json")})
+	details, err = getTransientPrivateDetails(ctx, "someissuer", "somephr")
+	assert.EqualError(t, err, "Error deserializing phr private details. invalid character 'b' looking for beginning of value", "should error when details do not deserialize")
+	assert.Nil(t, details, "should not return details when they do not deserialize")
+
+	setTransient(ctx, map[string][]byte{PHRPrivateTransientKey: []byte(`{"issuer":"someotherissuer","clinicalMetadata":{"diagnosis":"somediagnosis"}}`)})
+	details, err = getTransientPrivateDetails(ctx, "someissuer", "somephr")
+	assert.Nil(t, err, "should not error when details deserialize")
+	assert.Equal(t, &PHRPrivateDetails{Issuer: "someissuer", PHRNumber: "somephr", ClinicalMetadata: map[string]string{"diagnosis": "somediagnosis"}}, details, "should key details by the issued phr")
+}
+
+func TestCheckPrivateTransfer(t *testing.T) {
+	var err error
+
+	ctx := newImplicitCollectionContext("Org1MSP")
+	list := ctx.GetPHRList()
+
+	details := &PHRPrivateDetails{Issuer: "someissuer", PHRNumber: "somephr", PatientIdentifiers: map[string]string{"mrn": "somemrn"}}
+	hash, _ := details.Hash()
+	phr := &PHR{Issuer: "someissuer", PHRNumber: "somephr", Owner: "someowner", PrivateDetailsHash: hash}
+
+	err = list.AddPHRPrivateDetailsFor("Org2MSP", details)
+	assert.Nil(t, err, "should put private details in implicit collection of another MSP")
+
+	err = checkPrivateTransfer(ctx, phr, "Org1MSP")
+	assertCodedError(t, err, phrerror.NotFound, "Private details of PHR someissuer:somephr are not offered to MSP Org1MSP", "should error when private details only offered to another MSP")
+
+	err = list.AddPHRPrivateDetailsFor("Org1MSP", &PHRPrivateDetails{Issuer: "someissuer", PHRNumber: "somephr", PatientIdentifiers: map[string]string{"mrn": "someothermrn"}})
+	assert.Nil(t, err, "should put other private details in implicit collection of buyer MSP")
+
+	err = checkPrivateTransfer(ctx, phr, "Org1MSP")
+	assertCodedError(t, err, phrerror.Validation, "Private details of PHR someissuer:somephr offered to MSP Org1MSP do not match the phr", "should error when offered private details differ")
+
+	err = list.AddPHRPrivateDetailsFor("Org1MSP", details)
+	assert.Nil(t, err, "should replace private details in implicit collection of buyer MSP")
+
+	err = checkPrivateTransfer(ctx, phr, "Org1MSP")
+	assert.Nil(t, err, "should not error when the ledger hash of the offered private details matches the phr")
+}
@@ -0,0 +1,113 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"encoding/json"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+)
+
+// PHRQuery defines the fields phrs can be queried on.
+// Fields left empty or zero are not used in the query
+type PHRQuery struct {
+	Owner        string `json:"owner"`
+	Issuer       string `json:"issuer"`
+	CurrentState string `json:"currentState"`
+	MinFaceValue int    `json:"minFaceValue"`
+	MaxFaceValue int    `json:"maxFaceValue"`
+	MaturityFrom string `json:"maturityFrom"`
+	MaturityTo   string `json:"maturityTo"`
+}
+
+// Selector returns the CouchDB selector matching the query. Ranges
+// are inclusive and compared against the JSON written by PHR.MarshalJSON.
+// Maturity date times are normalized to UTC as the ledger stores them
+func (q *PHRQuery) Selector() (map[string]interface{}, error) {
+	selector := map[string]interface{}{"class": listName}
+
+	if q.Owner != "" {
+		selector["owner"] = q.Owner
+	}
+
+	if q.Issuer != "" {
+		selector["issuer"] = q.Issuer
+	}
+
+	if q.CurrentState != "" {
+		state, err := ParseState(q.CurrentState)
+
+		if err != nil {
+			return nil, err
+		}
+
+		selector["currentState"] = state
+	}
+
+	if q.MinFaceValue != 0 && q.MaxFaceValue != 0 && q.MinFaceValue > q.MaxFaceValue {
+		return nil, phrerror.Errorf(phrerror.Validation, "Min face value %d is greater than max face value %d", q.MinFaceValue, q.MaxFaceValue)
+	}
+
+	if faceValue := rangeSelector(q.MinFaceValue, q.MaxFaceValue, q.MinFaceValue != 0, q.MaxFaceValue != 0); faceValue != nil {
+		selector["faceValue"] = faceValue
+	}
+
+	maturityFrom, err := parseDateTime("maturityFrom", q.MaturityFrom, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	maturityTo, err := parseDateTime("maturityTo", q.MaturityTo, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if maturityFrom != "" && maturityTo != "" && maturityFrom > maturityTo {
+		return nil, phrerror.Errorf(phrerror.Validation, "Maturity from %s is after maturity to %s", maturityFrom, maturityTo)
+	}
+
+	if maturity := rangeSelector(maturityFrom, maturityTo, maturityFrom != "", maturityTo != ""); maturity != nil {
+		selector["maturityDateTime"] = maturity
+	}
+
+	return selector, nil
+}
+
+// JSON returns the CouchDB query string for the query
+func (q *PHRQuery) JSON() (string, error) {
+	selector, err := q.Selector()
+
+	if err != nil {
+		return "", err
+	}
+
+	bytes, err := json.Marshal(map[string]interface{}{"selector": selector})
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
+
+func rangeSelector(from interface{}, to interface{}, hasFrom bool, hasTo bool) map[string]interface{} {
+	if !hasFrom && !hasTo {
+		return nil
+	}
+
+	operators := map[string]interface{}{}
+
+	if hasFrom {
+		operators["$gte"] = from
+	}
+
+	if hasTo {
+		operators["$lte"] = to
+	}
+
+	return operators
+}
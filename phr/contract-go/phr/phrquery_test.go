@@ -0,0 +1,71 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"testing"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelector(t *testing.T) {
+	var selector map[string]interface{}
+	var err error
+
+	selector, err = new(PHRQuery).Selector()
+	assert.Nil(t, err, "should not error for empty query")
+	assert.Equal(t, map[string]interface{}{"class": "org.phrnet.phrlist"}, selector, "should only select on class for empty query")
+
+	selector, err = (&PHRQuery{Owner: "someowner", Issuer: "someissuer", CurrentState: "TRADING"}).Selector()
+	assert.Nil(t, err, "should not error for good query")
+	assert.Equal(t, map[string]interface{}{"class": "org.phrnet.phrlist", "owner": "someowner", "issuer": "someissuer", "currentState": TRADING}, selector, "should select on set fields")
+
+	selector, err = (&PHRQuery{MinFaceValue: 100, MaturityTo: "2021-12-10T10:00:00Z"}).Selector()
+	assert.Nil(t, err, "should not error for open ranges")
+	assert.Equal(t, map[string]interface{}{"class": "org.phrnet.phrlist", "faceValue": map[string]interface{}{"$gte": 100}, "maturityDateTime": map[string]interface{}{"$lte": "2021-12-10T10:00:00Z"}}, selector, "should select on open ranges")
+
+	selector, err = (&PHRQuery{MinFaceValue: 100, MaxFaceValue: 1000, MaturityFrom: "2021-01-01T00:00:00Z", MaturityTo: "2021-12-31T00:00:00Z"}).Selector()
+	assert.Nil(t, err, "should not error for closed ranges")
+	assert.Equal(t, map[string]interface{}{"$gte": 100, "$lte": 1000}, selector["faceValue"], "should select on face value range")
+	assert.Equal(t, map[string]interface{}{"$gte": "2021-01-01T00:00:00Z", "$lte": "2021-12-31T00:00:00Z"}, selector["maturityDateTime"], "should select on maturity range")
+
+	selector, err = (&PHRQuery{MaturityFrom: "2021-01-01T09:00:00+09:00", MaturityTo: "2021-12-31T08:59:59.5+09:00"}).Selector()
+	assert.Nil(t, err, "should not error for maturity range with offsets")
+	assert.Equal(t, map[string]interface{}{"$gte": "2021-01-01T00:00:00Z", "$lte": "2021-12-30T23:59:59Z"}, selector["maturityDateTime"], "should normalize maturity range to UTC")
+
+	selector, err = (&PHRQuery{MaturityFrom: "2021-01-01"}).Selector()
+	assertCodedError(t, err, phrerror.Validation, `Invalid maturityFrom "2021-01-01". Expected an RFC 3339 date time`, "should error for maturity from not RFC 3339")
+	assert.Nil(t, selector, "should not return selector for bad maturity from")
+
+	selector, err = (&PHRQuery{MaturityTo: "someday"}).Selector()
+	assertCodedError(t, err, phrerror.Validation, `Invalid maturityTo "someday". Expected an RFC 3339 date time`, "should error for maturity to not RFC 3339")
+	assert.Nil(t, selector, "should not return selector for bad maturity to")
+
+	selector, err = (&PHRQuery{MaturityFrom: "2021-12-10T10:00:00+09:00", MaturityTo: "2021-12-10T00:00:00Z"}).Selector()
+	assertCodedError(t, err, phrerror.Validation, "Maturity from 2021-12-10T01:00:00Z is after maturity to 2021-12-10T00:00:00Z", "should error for empty maturity range")
+	assert.Nil(t, selector, "should not return selector for empty maturity range")
+
+	selector, err = (&PHRQuery{CurrentState: "SOLD"}).Selector()
+	assertCodedError(t, err, phrerror.Validation, "Unknown phr state SOLD", "should error for unknown state")
+	assert.Nil(t, selector, "should not return selector for unknown state")
+
+	selector, err = (&PHRQuery{MinFaceValue: 1000, MaxFaceValue: 100}).Selector()
+	assertCodedError(t, err, phrerror.Validation, "Min face value 1000 is greater than max face value 100", "should error for empty face value range")
+	assert.Nil(t, selector, "should not return selector for empty face value range")
+}
+
+func TestJSON(t *testing.T) {
+	var query string
+	var err error
+
+	query, err = (&PHRQuery{Owner: "someowner", CurrentState: "TRADING"}).JSON()
+	assert.Nil(t, err, "should not error for good query")
+	assert.Equal(t, `{"selector":{"class":"org.phrnet.phrlist","currentState":2,"owner":"someowner"}}`, query, "should wrap selector in query")
+
+	query, err = (&PHRQuery{CurrentState: "SOLD"}).JSON()
+	assertCodedError(t, err, phrerror.Validation, "Unknown phr state SOLD", "should error when selector errors")
+	assert.Equal(t, "", query, "should not return query when selector errors")
+}
@@ -0,0 +1,130 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
+)
+
+// Role of a client identity. Each transaction
+// declares the roles allowed to call it
+type Role string
+
+const (
+	// IssuerRole role of hospitals, which issue phrs
+	IssuerRole Role = "issuer"
+	// BuyerRole role of institutes, which buy phrs for research
+	BuyerRole Role = "buyer"
+	// AuditorRole role of regulators, which only read
+	AuditorRole Role = "auditor"
+	// PatientRole role of patients, who grant and
+	// revoke consent to trade the phrs about them
+	PatientRole Role = "patient"
+	// TokenAdminRole role of the clearing house, which mints
+	// payment tokens and sets the royalty rate
+	TokenAdminRole Role = "tokenadmin"
+)
+
+// RoleAttribute certificate attribute which, when
+// present, names the role of a client identity
+const RoleAttribute = "role"
+
+// PermissionError returned when the role of the client
+// identity is not one a transaction allows
+type PermissionError struct {
+	Role     Role
+	Required []Role
+}
+
+func (err *PermissionError) Error() string {
+	role := string(err.Role)
+
+	if role == "" {
+		role = "none"
+	}
+
+	required := make([]string, len(err.Required))
+
+	for i, r := range err.Required {
+		required[i] = string(r)
+	}
+
+	message := fmt.Sprintf("Permission denied for role %s. Requires one of %s", role, strings.Join(required, ", "))
+
+	return phrerror.New(phrerror.Forbidden, message, map[string]string{"role": string(err.Role), "required": strings.Join(required, ",")}).Error()
+}
+
+// RoleMapperInterface maps the identity submitting
+// a transaction to its role
+type RoleMapperInterface interface {
+	GetRole(cid.ClientIdentity) (Role, error)
+}
+
+// RoleMapper implementation of RoleMapperInterface. Looks up
+// the client MSP ID in Roles. A client may instead claim a role
+// its MSP is allowed in Allowed through the RoleAttribute of its
+// certificate
+type RoleMapper struct {
+	Roles   map[string]Role
+	Allowed map[string][]Role
+}
+
+// GetRole returns the role of the client identity, or an
+// empty role when it has none
+func (rm *RoleMapper) GetRole(ci cid.ClientIdentity) (Role, error) {
+	if ci == nil {
+		return "", fmt.Errorf("No client identity for transaction")
+	}
+
+	mspID, err := ci.GetMSPID()
+
+	if err != nil {
+		return "", err
+	}
+
+	role, found, err := ci.GetAttributeValue(RoleAttribute)
+
+	if err != nil {
+		return "", err
+	} else if found && role != "" {
+		if !rm.isAllowed(mspID, Role(role)) {
+			return "", phrerror.Errorf(phrerror.Forbidden, "Role %s is not allowed for MSP %s", role, mspID)
+		}
+
+		return Role(role), nil
+	}
+
+	return rm.Roles[mspID], nil
+}
+
+func (rm *RoleMapper) isAllowed(mspID string, role Role) bool {
+	if defaultRole, ok := rm.Roles[mspID]; ok && defaultRole == role {
+		return true
+	}
+
+	for _, allowed := range rm.Allowed[mspID] {
+		if allowed == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkRole returns a PermissionError unless
+// role is one of the required roles
+func checkRole(role Role, required []Role) error {
+	for _, r := range required {
+		if r == role {
+			return nil
+		}
+	}
+
+	return &PermissionError{Role: role, Required: required}
+}
@@ -0,0 +1,250 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/stretchr/testify/assert"
+)
+
+// #########
+// HELPERS
+// #########
+
+// FakeClientIdentity test double of a client identity
+// with an MSP ID and the attributes of its certificate
+type FakeClientIdentity struct {
+	MSPID      string
+	Attributes map[string]string
+	Err        error
+}
+
+func (fci *FakeClientIdentity) GetID() (string, error) {
+	return "x509::CN=someuser::CN=someca", fci.Err
+}
+
+func (fci *FakeClientIdentity) GetMSPID() (string, error) {
+	return fci.MSPID, fci.Err
+}
+
+func (fci *FakeClientIdentity) GetAttributeValue(attrName string) (string, bool, error) {
+	value, found := fci.Attributes[attrName]
+
+	return value, found, fci.Err
+}
+
+func (fci *FakeClientIdentity) AssertAttributeValue(attrName string, attrValue string) error {
+	value, found, err := fci.GetAttributeValue(attrName)
+
+	if err != nil {
+		return err
+	} else if !found || value != attrValue {
+		return fmt.Errorf("Attribute '%s' equals '%s', not '%s'", attrName, value, attrValue)
+	}
+
+	return nil
+}
+
+func (fci *FakeClientIdentity) GetX509Certificate() (*x509.Certificate, error) {
+	return nil, fci.Err
+}
+
+// transactionRoles roles each transaction of the contract should allow
+var transactionRoles = map[string][]Role{
+	"AcceptBid":                   {IssuerRole, BuyerRole},
+	"Approve":                     {IssuerRole, BuyerRole},
+	"BalanceOf":                   {IssuerRole, BuyerRole, AuditorRole},
+	"Buy":                         {BuyerRole},
+	"CancelBid":                   {BuyerRole},
+	"CloseAuction":                {IssuerRole, BuyerRole},
+	"ClaimRoyalties":              {PatientRole},
+	"CommitBid":                   {BuyerRole},
+	"Delist":                      {IssuerRole, BuyerRole},
+	"Expire":                      {IssuerRole, BuyerRole},
+	"ExpireMatured":               {IssuerRole, BuyerRole},
+	"GetAllowance":                {IssuerRole, BuyerRole, AuditorRole},
+	"GetAuction":                  {IssuerRole, BuyerRole, AuditorRole},
+	"GetBids":                     {IssuerRole, BuyerRole, AuditorRole},
+	"GetConsent":                  {IssuerRole, BuyerRole, AuditorRole, PatientRole},
+	"GetListing":                  {IssuerRole, BuyerRole, AuditorRole},
+	"GetMaturedPHRs":              {IssuerRole, BuyerRole, AuditorRole},
+	"GetPatientRoyalties":         {PatientRole},
+	"GetPHRHistory":               {IssuerRole, BuyerRole, AuditorRole},
+	"GetPHRPrivateDetails":        {IssuerRole, BuyerRole},
+	"GetRoyalties":                {IssuerRole, BuyerRole, AuditorRole, PatientRole},
+	"GetRoyaltyRate":              {IssuerRole, BuyerRole, AuditorRole},
+	"GetSealedBids":               {IssuerRole, BuyerRole, AuditorRole},
+	"GetTradeHistory":             {IssuerRole, BuyerRole, AuditorRole},
+	"GetTradeStats":               {IssuerRole, BuyerRole, AuditorRole},
+	"GrantConsent":                {PatientRole},
+	"Issue":                       {IssuerRole},
+	"List":                        {IssuerRole, BuyerRole},
+	"Mint":                        {TokenAdminRole},
+	"OfferPrivateDetails":         {IssuerRole, BuyerRole},
+	"PlaceBid":                    {BuyerRole},
+	"QueryByIssuer":               {IssuerRole, BuyerRole, AuditorRole},
+	"QueryByIssuerWithPagination": {IssuerRole, BuyerRole, AuditorRole},
+	"QueryByOwnerWithPagination":  {IssuerRole, BuyerRole, AuditorRole},
+	"QueryByStateWithPagination":  {IssuerRole, BuyerRole, AuditorRole},
+	"QueryPHRs":                   {IssuerRole, BuyerRole, AuditorRole},
+	"QueryPHRsWithPagination":     {IssuerRole, BuyerRole, AuditorRole},
+	"RevealBid":                   {BuyerRole},
+	"RevokeConsent":               {PatientRole},
+	"SetRoyaltyRate":              {TokenAdminRole},
+	"StartAuction":                {IssuerRole, BuyerRole},
+	"Transfer":                    {IssuerRole, BuyerRole},
+	"TransferFrom":                {IssuerRole, BuyerRole},
+	"VerifyPayload":               {IssuerRole, BuyerRole, AuditorRole},
+}
+
+// callTransaction authorizes then calls a transaction of the contract by
+// name with zero values for all arguments after the context
+func callTransaction(contract *Contract, name string, ctx TransactionContextInterface) error {
+	for _, m := range contract.getMiddleware().middleware {
+		if m.Name == "authorization" && m.appliesTo(name) {
+			err := m.Before(ctx, &Invocation{Name: name, Values: map[string]interface{}{}})
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	method := reflect.ValueOf(contract).MethodByName(name)
+	args := []reflect.Value{reflect.ValueOf(ctx)}
+
+	for i := 1; i < method.Type().NumIn(); i++ {
+		args = append(args, reflect.Zero(method.Type().In(i)))
+	}
+
+	results := method.Call(args)
+	err, _ := results[len(results)-1].Interface().(error)
+
+	return err
+}
+
+// #########
+// TESTS
+// #########
+
+func TestPermissionError(t *testing.T) {
+	err := &PermissionError{Role: AuditorRole, Required: []Role{IssuerRole, BuyerRole}}
+	assertCodedError(t, err, phrerror.Forbidden, "Permission denied for role auditor. Requires one of issuer, buyer", "should list required roles")
+
+	decoded, _ := phrerror.DecodeError(err)
+	assert.Equal(t, map[string]string{"role": "auditor", "required": "issuer,buyer"}, decoded.Details, "should detail role and required roles")
+
+	err = &PermissionError{Required: []Role{IssuerRole}}
+	assertCodedError(t, err, phrerror.Forbidden, "Permission denied for role none. Requires one of issuer", "should name missing role none")
+}
+
+func TestGetRole(t *testing.T) {
+	var role Role
+	var err error
+
+	rm := DefaultSettings().NewRoleMapper()
+
+	role, err = rm.GetRole(nil)
+	assert.EqualError(t, err, "No client identity for transaction", "should error when no client identity")
+	assert.Equal(t, Role(""), role, "should not return role when no client identity")
+
+	role, err = rm.GetRole(&FakeClientIdentity{MSPID: "Org2MSP", Err: errors.New("client identity error")})
+	assert.EqualError(t, err, "client identity error", "should error when client identity errors")
+	assert.Equal(t, Role(""), role, "should not return role when client identity errors")
+
+	role, err = rm.GetRole(&FakeClientIdentity{MSPID: "Org1MSP", Attributes: map[string]string{RoleAttribute: "auditor"}})
+	assert.Nil(t, err, "should not error when role attribute allowed for MSP")
+	assert.Equal(t, AuditorRole, role, "should use role attribute when allowed for MSP")
+
+	role, err = rm.GetRole(&FakeClientIdentity{MSPID: "Org1MSP", Attributes: map[string]string{RoleAttribute: "issuer"}})
+	assertCodedError(t, err, phrerror.Forbidden, "Role issuer is not allowed for MSP Org1MSP", "should error when role attribute not allowed for MSP")
+	assert.Equal(t, Role(""), role, "should not return role not allowed for MSP")
+
+	role, err = rm.GetRole(&FakeClientIdentity{MSPID: "Org2MSP", Attributes: map[string]string{RoleAttribute: "patient"}})
+	assert.Nil(t, err, "should not error when patient role claimed in hospital MSP")
+	assert.Equal(t, PatientRole, role, "should use patient role attribute in hospital MSP")
+
+	role, err = rm.GetRole(&FakeClientIdentity{MSPID: "Org1MSP", Attributes: map[string]string{RoleAttribute: "patient"}})
+	assertCodedError(t, err, phrerror.Forbidden, "Role patient is not allowed for MSP Org1MSP", "should error when patient role claimed in institute MSP")
+	assert.Equal(t, Role(""), role, "should not return patient role in institute MSP")
+
+	role, err = rm.GetRole(&FakeClientIdentity{MSPID: "Org2MSP", Attributes: map[string]string{RoleAttribute: "buyer"}})
+	assertCodedError(t, err, phrerror.Forbidden, "Role buyer is not allowed for MSP Org2MSP", "should error when role attribute is role of another MSP")
+	assert.Equal(t, Role(""), role, "should not return role of another MSP")
+
+	role, err = rm.GetRole(&FakeClientIdentity{MSPID: "Org4MSP", Attributes: map[string]string{RoleAttribute: "auditor"}})
+	assertCodedError(t, err, phrerror.Forbidden, "Role auditor is not allowed for MSP Org4MSP", "should error when role attribute set for MSP not mapped")
+	assert.Equal(t, Role(""), role, "should not return role for MSP not mapped")
+
+	role, err = rm.GetRole(&FakeClientIdentity{MSPID: "Org2MSP", Attributes: map[string]string{RoleAttribute: "issuer"}})
+	assert.Nil(t, err, "should not error when role attribute is role of MSP")
+	assert.Equal(t, IssuerRole, role, "should use role attribute when role of MSP")
+
+	role, err = rm.GetRole(&FakeClientIdentity{MSPID: "Org2MSP"})
+	assert.Nil(t, err, "should not error when MSP mapped")
+	assert.Equal(t, IssuerRole, role, "should map MSP ID when role attribute not set")
+
+	role, err = rm.GetRole(&FakeClientIdentity{MSPID: "Org1MSP", Attributes: map[string]string{RoleAttribute: ""}})
+	assert.Nil(t, err, "should not error when role attribute empty")
+	assert.Equal(t, BuyerRole, role, "should map MSP ID when role attribute empty")
+
+	role, err = rm.GetRole(&FakeClientIdentity{MSPID: "Org4MSP"})
+	assert.Nil(t, err, "should not error when MSP not mapped")
+	assert.Equal(t, Role(""), role, "should return no role when MSP not mapped")
+}
+
+func TestCheckRole(t *testing.T) {
+	assert.Nil(t, checkRole(BuyerRole, []Role{IssuerRole, BuyerRole}), "should allow required role")
+
+	err := checkRole(AuditorRole, []Role{IssuerRole, BuyerRole})
+	assert.IsType(t, new(PermissionError), err, "should return permission error when role not required")
+	assert.Equal(t, &PermissionError{Role: AuditorRole, Required: []Role{IssuerRole, BuyerRole}}, err, "should report role and required roles")
+
+	err = checkRole("", []Role{IssuerRole})
+	assert.IsType(t, new(PermissionError), err, "should return permission error when no role")
+}
+
+func TestTransactionRoles(t *testing.T) {
+	contract := new(Contract)
+	contractType := reflect.TypeOf(contract)
+	ctxType := reflect.TypeOf((*TransactionContextInterface)(nil)).Elem()
+
+	for i := 0; i < contractType.NumMethod(); i++ {
+		method := contractType.Method(i)
+
+		if method.Type.NumIn() < 2 || method.Type.In(1) != ctxType {
+			continue
+		}
+
+		_, ok := transactionRoles[method.Name]
+		assert.True(t, ok, "should declare roles of "+method.Name)
+	}
+
+	evaluate := map[string]bool{}
+
+	for _, name := range contract.GetEvaluateTransactions() {
+		evaluate[name] = true
+	}
+
+	for name, roles := range transactionRoles {
+		ctx := newMockTransactionContext("someowner")
+		ctx.role = "somerole"
+
+		err := callTransaction(contract, name, ctx)
+		assert.Equal(t, &PermissionError{Role: "somerole", Required: roles}, err, "should require declared roles for "+name)
+
+		if !evaluate[name] {
+			ctx.role = AuditorRole
+			err = callTransaction(contract, name, ctx)
+			assert.IsType(t, new(PermissionError), err, "should not allow auditor to submit "+name)
+		}
+	}
+}
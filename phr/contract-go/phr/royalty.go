@@ -0,0 +1,119 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// royaltyRateKey key of the single royalty rate in world state
+const royaltyRateKey = "current"
+
+// RoyaltyRate defines the percentage of the price of every
+// trade paid to the patient of the traded phr
+type RoyaltyRate struct {
+	Percent int `json:"percent"`
+}
+
+// Calculate returns the royalty on a price, rounded down
+func (rate *RoyaltyRate) Calculate(price int) int {
+	return price * rate.Percent / 100
+}
+
+// GetSplitKey returns values which should be used to form key
+func (rate *RoyaltyRate) GetSplitKey() []string {
+	return []string{royaltyRateKey}
+}
+
+// Serialize formats the royalty rate as JSON bytes
+func (rate *RoyaltyRate) Serialize() ([]byte, error) {
+	return json.Marshal(rate)
+}
+
+// DeserializeRoyaltyRate formats the royalty rate from JSON bytes
+func DeserializeRoyaltyRate(bytes []byte, rate *RoyaltyRate) error {
+	err := json.Unmarshal(bytes, rate)
+
+	if err != nil {
+		return fmt.Errorf("Error deserializing royalty rate. %s", err.Error())
+	}
+
+	return nil
+}
+
+// Royalty defines the share of a trade paid to the patient of the
+// traded phr, keyed by the patient hash of the phr so the patient
+// stays private. Sequence is that of the trade
+type Royalty struct {
+	PatientHash string `json:"patientHash"`
+	Issuer      string `json:"issuer"`
+	PHRNumber   string `json:"phrNumber"`
+	Sequence    int    `json:"sequence"`
+	Price       int    `json:"price"`
+	Amount      int    `json:"amount"`
+	TxID        string `json:"txId"`
+	Timestamp   string `json:"timestamp"`
+}
+
+// PatientRecordsTransientKey key of the transient data holding the
+// PatientRecords whose royalties GetPatientRoyalties totals
+const PatientRecordsTransientKey = "patientRecords"
+
+// RoyaltySummary defines the royalties accrued to a patient hash.
+// Unclaimed is the part not yet claimed with ClaimRoyalties
+type RoyaltySummary struct {
+	PatientHash string     `json:"patientHash"`
+	Total       int        `json:"total"`
+	Unclaimed   int        `json:"unclaimed"`
+	Royalties   []*Royalty `json:"royalties"`
+}
+
+// PatientRecord names a phr of a patient and the salt of its patient hash
+type PatientRecord struct {
+	Issuer    string `json:"issuer"`
+	PHRNumber string `json:"phrNumber"`
+	Salt      string `json:"salt"`
+}
+
+// PatientRoyalties defines the royalties accrued to a
+// patient over its phrs, with a summary for each phr
+type PatientRoyalties struct {
+	Total     int               `json:"total"`
+	Unclaimed int               `json:"unclaimed"`
+	PHRs      []*RoyaltySummary `json:"phrs"`
+}
+
+// GetSplitKey returns values which should be used to form key
+func (royalty *Royalty) GetSplitKey() []string {
+	return []string{royalty.PatientHash, royalty.Issuer, royalty.PHRNumber, CreateTradeSequence(royalty.Sequence)}
+}
+
+// Serialize formats the royalty as JSON bytes
+func (royalty *Royalty) Serialize() ([]byte, error) {
+	return json.Marshal(royalty)
+}
+
+// DeserializeRoyalty formats the royalty from JSON bytes
+func DeserializeRoyalty(bytes []byte, royalty *Royalty) error {
+	err := json.Unmarshal(bytes, royalty)
+
+	if err != nil {
+		return fmt.Errorf("Error deserializing royalty. %s", err.Error())
+	}
+
+	return nil
+}
+
+// NewRoyaltySummary totals the royalties of a patient hash
+func NewRoyaltySummary(patientHash string, royalties []*Royalty) *RoyaltySummary {
+	summary := &RoyaltySummary{PatientHash: patientHash, Royalties: royalties}
+
+	for _, royalty := range royalties {
+		summary.Total += royalty.Amount
+	}
+
+	return summary
+}
@@ -0,0 +1,84 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoyaltyRateCalculate(t *testing.T) {
+	rate := &RoyaltyRate{Percent: 15}
+
+	assert.Equal(t, 15, rate.Calculate(100), "should return percent of price")
+	assert.Equal(t, 16, rate.Calculate(110), "should round royalty down")
+	assert.Equal(t, 0, (&RoyaltyRate{}).Calculate(100), "should return zero when rate not set")
+}
+
+func TestRoyaltyRateGetSplitKey(t *testing.T) {
+	rate := &RoyaltyRate{Percent: 10}
+
+	assert.Equal(t, []string{"current"}, rate.GetSplitKey(), "should return fixed split key")
+}
+
+func TestRoyaltyRateSerialize(t *testing.T) {
+	rate := &RoyaltyRate{Percent: 10}
+
+	bytes, err := rate.Serialize()
+	assert.Nil(t, err, "should not error on serialize")
+	assert.Equal(t, `{"percent":10}`, string(bytes), "should return JSON formatted value")
+}
+
+func TestDeserializeRoyaltyRate(t *testing.T) {
+	var rate *RoyaltyRate
+	var err error
+
+	rate = new(RoyaltyRate)
+	err = DeserializeRoyaltyRate([]byte(`{"percent":10}`), rate)
+	assert.Nil(t, err, "should not return error for deserialize")
+	assert.Equal(t, &RoyaltyRate{Percent: 10}, rate, "should create expected royalty rate")
+
+	rate = new(RoyaltyRate)
+	err = DeserializeRoyaltyRate([]byte(`{"percent":"NaN"}`), rate)
+	assert.EqualError(t, err, "Error deserializing royalty rate. json: cannot unmarshal string into Go struct field RoyaltyRate.percent of type int", "should return error for bad data")
+}
+
+func TestRoyaltyGetSplitKey(t *testing.T) {
+	royalty := &Royalty{PatientHash: "somepatienthash", Issuer: "someissuer", PHRNumber: "somephr", Sequence: 3}
+
+	assert.Equal(t, []string{"somepatienthash", "someissuer", "somephr", "0000000003"}, royalty.GetSplitKey(), "should return patient hash, issuer, phr number and padded sequence as split key")
+}
+
+func TestRoyaltySerialize(t *testing.T) {
+	royalty := &Royalty{PatientHash: "somepatienthash", Issuer: "someissuer", PHRNumber: "somephr", Sequence: 1, Price: 100, Amount: 10, TxID: "sometx", Timestamp: "2021-12-10T10:00:00Z"}
+
+	bytes, err := royalty.Serialize()
+	assert.Nil(t, err, "should not error on serialize")
+	assert.Equal(t, `{"patientHash":"somepatienthash","issuer":"someissuer","phrNumber":"somephr","sequence":1,"price":100,"amount":10,"txId":"sometx","timestamp":"2021-12-10T10:00:00Z"}`, string(bytes), "should return JSON formatted value")
+}
+
+func TestDeserializeRoyalty(t *testing.T) {
+	var royalty *Royalty
+	var err error
+
+	royalty = new(Royalty)
+	err = DeserializeRoyalty([]byte(`{"patientHash":"somepatienthash","issuer":"someissuer","phrNumber":"somephr","sequence":1,"price":100,"amount":10}`), royalty)
+	assert.Nil(t, err, "should not return error for deserialize")
+	assert.Equal(t, &Royalty{PatientHash: "somepatienthash", Issuer: "someissuer", PHRNumber: "somephr", Sequence: 1, Price: 100, Amount: 10}, royalty, "should create expected royalty")
+
+	royalty = new(Royalty)
+	err = DeserializeRoyalty([]byte(`{"amount":"NaN"}`), royalty)
+	assert.EqualError(t, err, "Error deserializing royalty. json: cannot unmarshal string into Go struct field Royalty.amount of type int", "should return error for bad data")
+}
+
+func TestNewRoyaltySummary(t *testing.T) {
+	summary := NewRoyaltySummary("somepatienthash", []*Royalty{})
+	assert.Equal(t, &RoyaltySummary{PatientHash: "somepatienthash", Royalties: []*Royalty{}}, summary, "should return zero total for no royalties")
+
+	royalties := []*Royalty{{Amount: 10}, {Amount: 15}}
+	summary = NewRoyaltySummary("somepatienthash", royalties)
+	assert.Equal(t, &RoyaltySummary{PatientHash: "somepatienthash", Total: 25, Royalties: royalties}, summary, "should total the royalties")
+}
@@ -0,0 +1,195 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+)
+
+// SetRoyaltyRate sets the percentage of the price of every trade
+// paid to the patient of the traded phr. Submitted by a client
+// of the token admin MSP of the settings
+func (c *Contract) SetRoyaltyRate(ctx TransactionContextInterface, percent int) (*RoyaltyRate, error) {
+	if percent < 0 || percent > 100 {
+		return nil, &ValidationError{Field: "percent", Value: fmt.Sprint(percent), Reason: "Must be between 0 and 100"}
+	}
+
+	mspID, err := ctx.GetClientIdentity().GetMSPID()
+
+	if err != nil {
+		return nil, err
+	}
+
+	tokenAdminMSP := ctx.GetSettings().TokenAdminMSP
+
+	if mspID != tokenAdminMSP {
+		return nil, phrerror.Errorf(phrerror.Forbidden, "Only MSP %s can set the royalty rate. Submitter MSP = %s", tokenAdminMSP, mspID)
+	}
+
+	rate := RoyaltyRate{Percent: percent}
+
+	err = ctx.GetRoyaltyList().UpdateRoyaltyRate(&rate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &rate, nil
+}
+
+// GetRoyaltyRate returns the royalty rate paid to patients
+func (c *Contract) GetRoyaltyRate(ctx TransactionContextInterface) (*RoyaltyRate, error) {
+	return ctx.GetRoyaltyList().GetRoyaltyRate()
+}
+
+// GetRoyalties returns the royalties accrued to a patient hash
+func (c *Contract) GetRoyalties(ctx TransactionContextInterface, patientHash string) (*RoyaltySummary, error) {
+	return getRoyaltySummary(ctx, patientHash)
+}
+
+// GetPatientRoyalties returns the royalties accrued to the patient
+// submitting it over the PatientRecords passed in the transient data
+// under PatientRecordsTransientKey
+func (c *Contract) GetPatientRoyalties(ctx TransactionContextInterface) (*PatientRoyalties, error) {
+	transient, err := ctx.GetStub().GetTransient()
+
+	if err != nil {
+		return nil, err
+	}
+
+	records := []*PatientRecord{}
+	err = json.Unmarshal(transient[PatientRecordsTransientKey], &records)
+
+	if err != nil || len(records) == 0 {
+		return nil, &ValidationError{Field: PatientRecordsTransientKey, Value: "", Reason: "Expected a JSON array of issuer, phr number and salt in the transient data"}
+	}
+
+	patientRoyalties := &PatientRoyalties{PHRs: []*RoyaltySummary{}}
+	seen := map[string]bool{}
+
+	for _, record := range records {
+		phr, err := ctx.GetPHRList().GetPHR(record.Issuer, record.PHRNumber)
+
+		if err != nil {
+			return nil, err
+		}
+
+		ok, err := isPatient(ctx, phr, record.Salt)
+
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, phrerror.Errorf(phrerror.Forbidden, "Royalties of PHR %s:%s can only be read by its patient", record.Issuer, record.PHRNumber)
+		}
+
+		if seen[phr.PatientHash] {
+			continue
+		}
+
+		seen[phr.PatientHash] = true
+
+		summary, err := getRoyaltySummary(ctx, phr.PatientHash)
+
+		if err != nil {
+			return nil, err
+		}
+
+		patientRoyalties.Total += summary.Total
+		patientRoyalties.Unclaimed += summary.Unclaimed
+		patientRoyalties.PHRs = append(patientRoyalties.PHRs, summary)
+	}
+
+	return patientRoyalties, nil
+}
+
+// ClaimRoyalties pays the unclaimed royalties of a phr to an owner
+// named by its patient, as royalties are paid to the patient hash of
+// the phr, which no client can spend. Submitted by the patient only,
+// passing the salt as GrantConsent does
+func (c *Contract) ClaimRoyalties(ctx TransactionContextInterface, issuer string, phrNumber string, payee string) (*Balance, error) {
+	if payee == "" {
+		return nil, &ValidationError{Field: "payee", Value: payee, Reason: "Value is required"}
+	}
+
+	phr, err := ctx.GetPHRList().GetPHR(issuer, phrNumber)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !phr.NeedsConsent() {
+		return nil, phrerror.Errorf(phrerror.Validation, "PHR %s:%s names no patient", issuer, phrNumber)
+	}
+
+	salt, err := getTransientSalt(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := isPatient(ctx, phr, salt)
+
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, phrerror.Errorf(phrerror.Forbidden, "Royalties of PHR %s:%s can only be claimed by its patient", issuer, phrNumber)
+	}
+
+	_, err = ctx.GetOwnerMSPID(payee)
+
+	if err != nil {
+		return nil, err
+	}
+
+	unclaimed, err := ctx.GetTokenList().GetBalance(phr.PatientHash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if unclaimed.Amount == 0 {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "PHR %s:%s has no unclaimed royalties", issuer, phrNumber)
+	}
+
+	balance, err := ctx.GetTokenList().GetBalance(payee)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = settle(ctx, phr.PatientHash, payment{to: payee, amount: unclaimed.Amount})
+
+	if err != nil {
+		return nil, err
+	}
+
+	balance.Amount += unclaimed.Amount
+
+	return balance, nil
+}
+
+// getRoyaltySummary returns the royalties accrued
+// to a patient hash and the part not yet claimed
+func getRoyaltySummary(ctx TransactionContextInterface, patientHash string) (*RoyaltySummary, error) {
+	royalties, err := ctx.GetRoyaltyList().GetRoyalties(patientHash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	unclaimed, err := ctx.GetTokenList().GetBalance(patientHash)
+
+	if err != nil {
+		return nil, err
+	}
+
+	summary := NewRoyaltySummary(patientHash, royalties)
+	summary.Unclaimed = unclaimed.Amount
+
+	return summary, nil
+}
@@ -0,0 +1,230 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSetRoyaltyRate(t *testing.T) {
+	var rate *RoyaltyRate
+	var err error
+
+	ctx := newMockTransactionContext("hospital")
+	ctx.GetClientIdentity().(*MockClientIdentity).On("GetMSPID").Return(DefaultTokenAdminMSP, nil)
+	mrl := ctx.royaltyList
+
+	contract := new(Contract)
+
+	var sentRate *RoyaltyRate
+	mrl.On("UpdateRoyaltyRate", mock.MatchedBy(func(rate *RoyaltyRate) bool { sentRate = rate; return true })).Return(nil)
+
+	rate, err = contract.SetRoyaltyRate(ctx, -1)
+	assertCodedError(t, err, phrerror.Validation, `Invalid percent "-1". Must be between 0 and 100`, "should error when percent negative")
+	assert.Nil(t, rate, "should not return rate when percent negative")
+
+	rate, err = contract.SetRoyaltyRate(ctx, 101)
+	assertCodedError(t, err, phrerror.Validation, `Invalid percent "101". Must be between 0 and 100`, "should error when percent above 100")
+	assert.Nil(t, rate, "should not return rate when percent above 100")
+
+	mspErrCtx := newMockTransactionContext("hospital")
+	mspErrCtx.GetClientIdentity().(*MockClientIdentity).On("GetMSPID").Return("", errors.New("GetMSPID error"))
+	rate, err = contract.SetRoyaltyRate(mspErrCtx, 10)
+	assert.EqualError(t, err, "GetMSPID error", "should error when GetMSPID errors")
+	assert.Nil(t, rate, "should not return rate when GetMSPID errors")
+
+	otherCtx := newMockTransactionContext("institute")
+	otherCtx.GetClientIdentity().(*MockClientIdentity).On("GetMSPID").Return("Org1MSP", nil)
+	rate, err = contract.SetRoyaltyRate(otherCtx, 10)
+	assertCodedError(t, err, phrerror.Forbidden, "Only MSP Org3MSP can set the royalty rate. Submitter MSP = Org1MSP", "should error when submitter not of admin MSP")
+	assert.Nil(t, rate, "should not return rate when submitter not of admin MSP")
+
+	rate, err = contract.SetRoyaltyRate(ctx, 10)
+	assert.Nil(t, err, "should not error on good rate")
+	assert.Equal(t, &RoyaltyRate{Percent: 10}, rate, "should return the new rate")
+	assert.Equal(t, sentRate, rate, "should put same rate as it returns in the world state")
+}
+
+func TestGetRoyaltyRate(t *testing.T) {
+	ctx := newMockTransactionContext("someowner")
+	mrl := ctx.royaltyList
+
+	contract := new(Contract)
+
+	wsRate := &RoyaltyRate{Percent: 10}
+	mrl.On("GetRoyaltyRate").Return(wsRate, nil)
+
+	rate, err := contract.GetRoyaltyRate(ctx)
+	assert.Nil(t, err, "should not error when GetRoyaltyRate does not error")
+	assert.Equal(t, wsRate, rate, "should return rate from royalty list")
+}
+
+func TestGetRoyalties(t *testing.T) {
+	var summary *RoyaltySummary
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mrl := ctx.royaltyList
+
+	contract := new(Contract)
+
+	royalties := []*Royalty{{PatientHash: "somepatienthash", Amount: 10}, {PatientHash: "somepatienthash", Amount: 20}}
+	var emptyRoyalties []*Royalty
+	var emptyBalance *Balance
+	mrl.On("GetRoyalties", "somepatienthash").Return(royalties, nil)
+	mrl.On("GetRoyalties", "somebadpatient").Return(emptyRoyalties, errors.New("GetRoyalties error"))
+	mrl.On("GetRoyalties", "somebadbalancepatient").Return(royalties, nil)
+	ctx.tokenList.On("GetBalance", "somepatienthash").Return(&Balance{Owner: "somepatienthash", Amount: 20}, nil)
+	ctx.tokenList.On("GetBalance", "somebadbalancepatient").Return(emptyBalance, errors.New("GetBalance error"))
+
+	summary, err = contract.GetRoyalties(ctx, "somebadpatient")
+	assert.EqualError(t, err, "GetRoyalties error", "should return error when GetRoyalties errors")
+	assert.Nil(t, summary, "should not return summary when GetRoyalties errors")
+
+	summary, err = contract.GetRoyalties(ctx, "somebadbalancepatient")
+	assert.EqualError(t, err, "GetBalance error", "should return error when GetBalance errors")
+	assert.Nil(t, summary, "should not return summary when GetBalance errors")
+
+	summary, err = contract.GetRoyalties(ctx, "somepatienthash")
+	assert.Nil(t, err, "should not error when GetRoyalties does not error")
+	assert.Equal(t, &RoyaltySummary{PatientHash: "somepatienthash", Total: 30, Unclaimed: 20, Royalties: royalties}, summary, "should total royalties of the patient hash and its unclaimed balance")
+}
+
+func TestGetPatientRoyalties(t *testing.T) {
+	var patientRoyalties *PatientRoyalties
+	var err error
+
+	ctx := newMockPatientContext("somepatient", "")
+	mpl := ctx.phrList
+	mrl := ctx.royaltyList
+	mtkl := ctx.tokenList
+
+	contract := new(Contract)
+
+	firstHash := HashPatient("somepatient", "somesaltsomesalt")
+	secondHash := HashPatient("somepatient", "someothersaltsalt")
+	var emptyPHR *PHR
+	mpl.On("GetPHR", "someissuer", "somephr").Return(&PHR{Issuer: "someissuer", PHRNumber: "somephr", PatientHash: firstHash}, nil)
+	mpl.On("GetPHR", "someissuer", "someotherphr").Return(&PHR{Issuer: "someissuer", PHRNumber: "someotherphr", PatientHash: secondHash}, nil)
+	mpl.On("GetPHR", "someissuer", "somenopatientphr").Return(&PHR{Issuer: "someissuer", PHRNumber: "somenopatientphr"}, nil)
+	mpl.On("GetPHR", "someissuer", "somebadphr").Return(emptyPHR, errors.New("GetPHR error"))
+	firstRoyalties := []*Royalty{{PatientHash: firstHash, Amount: 10}, {PatientHash: firstHash, Amount: 20}}
+	secondRoyalties := []*Royalty{{PatientHash: secondHash, Amount: 5}}
+	mrl.On("GetRoyalties", firstHash).Return(firstRoyalties, nil)
+	mrl.On("GetRoyalties", secondHash).Return(secondRoyalties, nil)
+	mtkl.On("GetBalance", firstHash).Return(&Balance{Owner: firstHash, Amount: 0}, nil)
+	mtkl.On("GetBalance", secondHash).Return(&Balance{Owner: secondHash, Amount: 5}, nil)
+
+	patientRoyalties, err = contract.GetPatientRoyalties(ctx)
+	assertCodedError(t, err, phrerror.Validation, `Invalid patientRecords "". Expected a JSON array of issuer, phr number and salt in the transient data`, "should error when records missing")
+	assert.Nil(t, patientRoyalties, "should not return royalties when records missing")
+
+	setTransient(ctx, map[string][]byte{PatientRecordsTransientKey: []byte("[]")})
+	patientRoyalties, err = contract.GetPatientRoyalties(ctx)
+	assertCodedError(t, err, phrerror.Validation, `Invalid patientRecords "". Expected a JSON array of issuer, phr number and salt in the transient data`, "should error when records empty")
+	assert.Nil(t, patientRoyalties, "should not return royalties when records empty")
+
+	setTransient(ctx, map[string][]byte{PatientRecordsTransientKey: []byte(`[{"issuer":"someissuer","phrNumber":"somebadphr","salt":"somesaltsomesalt"}]`)})
+	patientRoyalties, err = contract.GetPatientRoyalties(ctx)
+	assert.EqualError(t, err, "GetPHR error", "should error when GetPHR errors")
+	assert.Nil(t, patientRoyalties, "should not return royalties when GetPHR errors")
+
+	setTransient(ctx, map[string][]byte{PatientRecordsTransientKey: []byte(`[{"issuer":"someissuer","phrNumber":"somephr","salt":"someguessedsalt"}]`)})
+	patientRoyalties, err = contract.GetPatientRoyalties(ctx)
+	assertCodedError(t, err, phrerror.Forbidden, "Royalties of PHR someissuer:somephr can only be read by its patient", "should error when salt does not match")
+	assert.Nil(t, patientRoyalties, "should not return royalties when salt does not match")
+
+	setTransient(ctx, map[string][]byte{PatientRecordsTransientKey: []byte(`[{"issuer":"someissuer","phrNumber":"somenopatientphr","salt":"somesaltsomesalt"}]`)})
+	patientRoyalties, err = contract.GetPatientRoyalties(ctx)
+	assertCodedError(t, err, phrerror.Forbidden, "Royalties of PHR someissuer:somenopatientphr can only be read by its patient", "should error when phr names no patient")
+	assert.Nil(t, patientRoyalties, "should not return royalties when phr names no patient")
+
+	otherCtx := newMockPatientContext("someotherpatient", "")
+	otherCtx.phrList = mpl
+	setTransient(otherCtx, map[string][]byte{PatientRecordsTransientKey: []byte(`[{"issuer":"someissuer","phrNumber":"somephr","salt":"somesaltsomesalt"}]`)})
+	patientRoyalties, err = contract.GetPatientRoyalties(otherCtx)
+	assertCodedError(t, err, phrerror.Forbidden, "Royalties of PHR someissuer:somephr can only be read by its patient", "should error when submitter another patient")
+	assert.Nil(t, patientRoyalties, "should not return royalties when submitter another patient")
+
+	setTransient(ctx, map[string][]byte{PatientRecordsTransientKey: []byte(`[{"issuer":"someissuer","phrNumber":"somephr","salt":"somesaltsomesalt"},{"issuer":"someissuer","phrNumber":"someotherphr","salt":"someothersaltsalt"},{"issuer":"someissuer","phrNumber":"somephr","salt":"somesaltsomesalt"}]`)})
+	patientRoyalties, err = contract.GetPatientRoyalties(ctx)
+	assert.Nil(t, err, "should not error for records of the patient")
+	assert.Equal(t, &PatientRoyalties{Total: 35, Unclaimed: 5, PHRs: []*RoyaltySummary{{PatientHash: firstHash, Total: 30, Unclaimed: 0, Royalties: firstRoyalties}, {PatientHash: secondHash, Total: 5, Unclaimed: 5, Royalties: secondRoyalties}}}, patientRoyalties, "should total royalties over the phrs of the patient once each")
+}
+
+func TestClaimRoyalties(t *testing.T) {
+	var balance *Balance
+	var err error
+
+	ctx := newMockPatientContext("somepatient", "somesaltsomesalt")
+	mpl := ctx.phrList
+	mtkl := ctx.tokenList
+
+	contract := new(Contract)
+
+	patientHash := HashPatient("somepatient", "somesaltsomesalt")
+	claimedHash := HashPatient("somepatient", "someclaimedsalt1")
+	var emptyPHR *PHR
+	mpl.On("GetPHR", "someissuer", "somephr").Return(&PHR{Issuer: "someissuer", PHRNumber: "somephr", PatientHash: patientHash}, nil)
+	mpl.On("GetPHR", "someissuer", "someclaimedphr").Return(&PHR{Issuer: "someissuer", PHRNumber: "someclaimedphr", PatientHash: claimedHash}, nil)
+	mpl.On("GetPHR", "someissuer", "somenopatientphr").Return(&PHR{Issuer: "someissuer", PHRNumber: "somenopatientphr"}, nil)
+	mpl.On("GetPHR", "someissuer", "somebadphr").Return(emptyPHR, errors.New("GetPHR error"))
+	mtkl.On("GetBalance", patientHash).Return(&Balance{Owner: patientHash, Amount: 30}, nil)
+	mtkl.On("GetBalance", claimedHash).Return(&Balance{Owner: claimedHash, Amount: 0}, nil)
+	mtkl.On("GetBalance", "somepayee").Return(&Balance{Owner: "somepayee", Amount: 100}, nil)
+	var sentBalances []*Balance
+	mtkl.On("UpdateBalance", mock.MatchedBy(func(balance *Balance) bool { sentBalances = append(sentBalances, balance); return true })).Return(nil)
+
+	balance, err = contract.ClaimRoyalties(ctx, "someissuer", "somephr", "")
+	assertCodedError(t, err, phrerror.Validation, `Invalid payee "". Value is required`, "should error when payee missing")
+	assert.Nil(t, balance, "should not return balance when payee missing")
+
+	balance, err = contract.ClaimRoyalties(ctx, "someissuer", "somebadphr", "somepayee")
+	assert.EqualError(t, err, "GetPHR error", "should error when GetPHR errors")
+	assert.Nil(t, balance, "should not return balance when GetPHR errors")
+
+	balance, err = contract.ClaimRoyalties(ctx, "someissuer", "somenopatientphr", "somepayee")
+	assertCodedError(t, err, phrerror.Validation, "PHR someissuer:somenopatientphr names no patient", "should error when phr names no patient")
+	assert.Nil(t, balance, "should not return balance when phr names no patient")
+
+	saltlessCtx := newMockPatientContext("somepatient", "")
+	saltlessCtx.phrList = mpl
+	balance, err = contract.ClaimRoyalties(saltlessCtx, "someissuer", "somephr", "somepayee")
+	assertCodedError(t, err, phrerror.Validation, `Invalid patientSalt "". Value is required in the transient data`, "should error when salt missing")
+	assert.Nil(t, balance, "should not return balance when salt missing")
+
+	otherCtx := newMockPatientContext("someotherpatient", "somesaltsomesalt")
+	otherCtx.phrList = mpl
+	balance, err = contract.ClaimRoyalties(otherCtx, "someissuer", "somephr", "somepayee")
+	assertCodedError(t, err, phrerror.Forbidden, "Royalties of PHR someissuer:somephr can only be claimed by its patient", "should error when submitter another patient")
+	assert.Nil(t, balance, "should not return balance when submitter another patient")
+
+	issuerCtx := newMockPatientContext("", "somesaltsomesalt")
+	issuerCtx.phrList = mpl
+	balance, err = contract.ClaimRoyalties(issuerCtx, "someissuer", "somephr", "somepayee")
+	assertCodedError(t, err, phrerror.Forbidden, "Client identity names no patient in attribute phr.patient", "should error when issuer claims on behalf of patient")
+	assert.Nil(t, balance, "should not return balance when issuer claims")
+
+	balance, err = contract.ClaimRoyalties(ctx, "someissuer", "somephr", "someunmappedowner")
+	assert.EqualError(t, err, "GetMSPID error", "should error when payee not mapped to an MSP")
+	assert.Nil(t, balance, "should not return balance when payee not mapped to an MSP")
+
+	claimedCtx := newMockPatientContext("somepatient", "someclaimedsalt1")
+	claimedCtx.phrList = mpl
+	claimedCtx.tokenList = mtkl
+	balance, err = contract.ClaimRoyalties(claimedCtx, "someissuer", "someclaimedphr", "somepayee")
+	assertCodedError(t, err, phrerror.InvalidState, "PHR someissuer:someclaimedphr has no unclaimed royalties", "should error when nothing to claim")
+	assert.Nil(t, balance, "should not return balance when nothing to claim")
+
+	balance, err = contract.ClaimRoyalties(ctx, "someissuer", "somephr", "somepayee")
+	assert.Nil(t, err, "should not error when patient claims")
+	assert.Equal(t, &Balance{Owner: "somepayee", Amount: 130}, balance, "should return balance of payee with royalties")
+	assert.Equal(t, []*Balance{{Owner: patientHash, Amount: 0}, {Owner: "somepayee", Amount: 130}}, sentBalances, "should move unclaimed royalties from patient hash to payee")
+}
@@ -0,0 +1,104 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+
+// RoyaltyListInterface defines functionality needed
+// to interact with the world state on behalf
+// of patient royalties
+type RoyaltyListInterface interface {
+	GetRoyaltyRate() (*RoyaltyRate, error)
+	UpdateRoyaltyRate(*RoyaltyRate) error
+	AddRoyalty(*Royalty) error
+	GetRoyalties(string) ([]*Royalty, error)
+}
+
+// royaltyRateListName namespace of the royalty rate in world state
+const royaltyRateListName = "org.phrnet.royaltyratelist"
+
+// royaltyListName namespace of royalties in world state
+const royaltyListName = "org.phrnet.royaltylist"
+
+type royaltyList struct {
+	rateStateList ledgerapi.StateListInterface
+	stateList     ledgerapi.StateListInterface
+}
+
+// GetRoyaltyRate returns the royalty rate. No
+// royalty is paid while the rate was never set
+func (rl *royaltyList) GetRoyaltyRate() (*RoyaltyRate, error) {
+	rate := new(RoyaltyRate)
+
+	err := rl.rateStateList.GetState(royaltyRateKey, rate)
+
+	if _, ok := err.(*ledgerapi.StateNotFoundError); ok {
+		return &RoyaltyRate{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
+
+// UpdateRoyaltyRate puts the royalty rate
+func (rl *royaltyList) UpdateRoyaltyRate(rate *RoyaltyRate) error {
+	return rl.rateStateList.UpdateState(rate)
+}
+
+// AddRoyalty adds a royalty to the world state
+func (rl *royaltyList) AddRoyalty(royalty *Royalty) error {
+	return rl.stateList.AddState(royalty)
+}
+
+// GetRoyalties returns the royalties of a patient hash
+func (rl *royaltyList) GetRoyalties(patientHash string) ([]*Royalty, error) {
+	iterator, err := rl.stateList.GetStatesByPartialKey(ledgerapi.MakeKey(patientHash))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer iterator.Close()
+
+	royalties := []*Royalty{}
+
+	for iterator.HasNext() {
+		royalty := new(Royalty)
+
+		err := iterator.Next(royalty)
+
+		if err != nil {
+			return nil, err
+		}
+
+		royalties = append(royalties, royalty)
+	}
+
+	return royalties, nil
+}
+
+// newRoyaltyList create a new royalty list from context
+func newRoyaltyList(ctx TransactionContextInterface) *royaltyList {
+	rateStateList := new(ledgerapi.StateList)
+	rateStateList.Ctx = ctx
+	rateStateList.Name = royaltyRateListName
+	rateStateList.Deserialize = func(bytes []byte, state ledgerapi.StateInterface) error {
+		return DeserializeRoyaltyRate(bytes, state.(*RoyaltyRate))
+	}
+
+	stateList := new(ledgerapi.StateList)
+	stateList.Ctx = ctx
+	stateList.Name = royaltyListName
+	stateList.Deserialize = func(bytes []byte, state ledgerapi.StateInterface) error {
+		return DeserializeRoyalty(bytes, state.(*Royalty))
+	}
+
+	list := new(royaltyList)
+	list.rateStateList = rateStateList
+	list.stateList = stateList
+
+	return list
+}
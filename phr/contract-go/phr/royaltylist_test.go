@@ -0,0 +1,156 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"errors"
+	"testing"
+
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// #########
+// HELPERS
+// #########
+
+type MockRoyaltyIterator struct {
+	amounts []int
+	err     error
+	closed  bool
+}
+
+func (mri *MockRoyaltyIterator) HasNext() bool {
+	return len(mri.amounts) > 0
+}
+
+func (mri *MockRoyaltyIterator) Next(state ledgerapi.StateInterface) error {
+	if mri.err != nil {
+		return mri.err
+	}
+
+	state.(*Royalty).Amount = mri.amounts[0]
+	mri.amounts = mri.amounts[1:]
+
+	return nil
+}
+
+func (mri *MockRoyaltyIterator) Close() error {
+	mri.closed = true
+
+	return nil
+}
+
+// #########
+// TESTS
+// #########
+
+func TestGetRoyaltyRateFromList(t *testing.T) {
+	var rate *RoyaltyRate
+	var err error
+
+	isRate := mock.MatchedBy(func(state ledgerapi.StateInterface) bool { _, ok := state.(*RoyaltyRate); return ok })
+
+	list := new(royaltyList)
+	msl := new(MockStateList)
+	msl.On("GetState", "current", isRate).Return(nil)
+	list.rateStateList = msl
+
+	rate, err = list.GetRoyaltyRate()
+	assert.Nil(t, err, "should not error when get state on rate state list does not error")
+	assert.Equal(t, &RoyaltyRate{Percent: 10}, rate, "should use rate state list GetState to fill royalty rate")
+
+	msl = new(MockStateList)
+	msl.On("GetState", "current", isRate).Return(&ledgerapi.StateNotFoundError{Key: "current"})
+	list.rateStateList = msl
+
+	rate, err = list.GetRoyaltyRate()
+	assert.Nil(t, err, "should not error when rate never set")
+	assert.Equal(t, &RoyaltyRate{}, rate, "should return zero rate when rate never set")
+
+	msl = new(MockStateList)
+	msl.On("GetState", "current", isRate).Return(errors.New("GetState error"))
+	list.rateStateList = msl
+
+	rate, err = list.GetRoyaltyRate()
+	assert.EqualError(t, err, "GetState error", "should return error when rate state list get state errors")
+	assert.Nil(t, rate, "should not return rate on error")
+}
+
+func TestUpdateRoyaltyRate(t *testing.T) {
+	rate := new(RoyaltyRate)
+
+	list := new(royaltyList)
+	msl := new(MockStateList)
+	msl.On("UpdateState", rate).Return(errors.New("Called update state correctly"))
+	list.rateStateList = msl
+
+	err := list.UpdateRoyaltyRate(rate)
+	assert.EqualError(t, err, "Called update state correctly", "should call rate state list update state with royalty rate")
+}
+
+func TestAddRoyalty(t *testing.T) {
+	royalty := new(Royalty)
+
+	list := new(royaltyList)
+	msl := new(MockStateList)
+	msl.On("AddState", royalty).Return(errors.New("Called add state correctly"))
+	list.stateList = msl
+
+	err := list.AddRoyalty(royalty)
+	assert.EqualError(t, err, "Called add state correctly", "should call state list add state with royalty")
+}
+
+func TestGetRoyaltiesFromList(t *testing.T) {
+	var royalties []*Royalty
+	var err error
+
+	list := new(royaltyList)
+	msl := new(MockStateList)
+	goodIterator := &MockRoyaltyIterator{amounts: []int{10, 20}}
+	badIterator := &MockRoyaltyIterator{amounts: []int{10}, err: errors.New("Next error")}
+	var emptyIterator *MockRoyaltyIterator
+	msl.On("GetStatesByPartialKey", "somepatient").Return(goodIterator, nil)
+	msl.On("GetStatesByPartialKey", "somebadpatient").Return(badIterator, nil)
+	msl.On("GetStatesByPartialKey", "someotherpatient").Return(emptyIterator, errors.New("GetStatesByPartialKey error"))
+	list.stateList = msl
+
+	royalties, err = list.GetRoyalties("someotherpatient")
+	assert.EqualError(t, err, "GetStatesByPartialKey error", "should return error when state list get states errors")
+	assert.Nil(t, royalties, "should not return royalties on error")
+
+	royalties, err = list.GetRoyalties("somebadpatient")
+	assert.EqualError(t, err, "Next error", "should return error when iterator errors")
+	assert.Nil(t, royalties, "should not return royalties on iterator error")
+	assert.True(t, badIterator.closed, "should close iterator on error")
+
+	royalties, err = list.GetRoyalties("somepatient")
+	assert.Nil(t, err, "should not error when state list get states does not error")
+	assert.Equal(t, []*Royalty{{Amount: 10}, {Amount: 20}}, royalties, "should read every royalty from iterator")
+	assert.True(t, goodIterator.closed, "should close iterator when done")
+}
+
+func TestNewRoyaltyList(t *testing.T) {
+	ctx := new(TransactionContext)
+	list := newRoyaltyList(ctx)
+	rateStateList, rateOk := list.rateStateList.(*ledgerapi.StateList)
+	stateList, ok := list.stateList.(*ledgerapi.StateList)
+
+	assert.True(t, rateOk, "should make rate statelist of type ledgerapi.StateList")
+	assert.True(t, ok, "should make statelist of type ledgerapi.StateList")
+	assert.Equal(t, ctx, rateStateList.Ctx, "should set the rate context to passed context")
+	assert.Equal(t, ctx, stateList.Ctx, "should set the context to passed context")
+	assert.Equal(t, "org.phrnet.royaltyratelist", rateStateList.Name, "should set the name for the rate list")
+	assert.Equal(t, "org.phrnet.royaltylist", stateList.Name, "should set the name for the list")
+
+	expectedErr := DeserializeRoyaltyRate([]byte("bad json"), new(RoyaltyRate))
+	err := rateStateList.Deserialize([]byte("bad json"), new(RoyaltyRate))
+	assert.EqualError(t, err, expectedErr.Error(), "should call DeserializeRoyaltyRate when rate stateList.Deserialize called")
+
+	expectedErr = DeserializeRoyalty([]byte("bad json"), new(Royalty))
+	err = stateList.Deserialize([]byte("bad json"), new(Royalty))
+	assert.EqualError(t, err, expectedErr.Error(), "should call DeserializeRoyalty when stateList.Deserialize called")
+}
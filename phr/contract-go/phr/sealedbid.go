@@ -0,0 +1,88 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// SealedBid defines a bid committed to an auction as a hash of
+// the bid and a salt. Price and salt are only set once
+// the bidder reveals them
+type SealedBid struct {
+	Issuer            string `json:"issuer"`
+	PHRNumber         string `json:"phrNumber"`
+	AuctionID         string `json:"auctionId"`
+	Bidder            string `json:"bidder"`
+	Hash              string `json:"hash"`
+	CommittedDateTime string `json:"committedDateTime"`
+	Purpose           string `json:"purpose,omitempty" metadata:"purpose,optional"`
+	Price             int    `json:"price,omitempty" metadata:"price,optional"`
+	Salt              string `json:"salt,omitempty" metadata:"salt,optional"`
+	RevealedDateTime  string `json:"revealedDateTime,omitempty" metadata:"revealedDateTime,optional"`
+}
+
+// HashBid returns the commitment for a bid. The hex encoded SHA-256 of
+// the JSON array of the issuer, phr number, bidder, price and salt, so
+// a commitment cannot be copied by another bidder or to another phr
+func HashBid(issuer string, phrNumber string, bidder string, price int, salt string) string {
+	preimage, _ := json.Marshal([]interface{}{issuer, phrNumber, bidder, price, salt})
+	sum := sha256.Sum256(preimage)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// IsRevealed returns true if the bidder has revealed the bid
+func (bid *SealedBid) IsRevealed() bool {
+	return bid.RevealedDateTime != ""
+}
+
+// GetSplitKey returns values which should be used to form key
+func (bid *SealedBid) GetSplitKey() []string {
+	return []string{bid.Issuer, bid.PHRNumber, bid.AuctionID, bid.Bidder}
+}
+
+// Serialize formats the sealed bid as JSON bytes
+func (bid *SealedBid) Serialize() ([]byte, error) {
+	return json.Marshal(bid)
+}
+
+// DeserializeSealedBid formats the sealed bid from JSON bytes
+func DeserializeSealedBid(bytes []byte, bid *SealedBid) error {
+	err := json.Unmarshal(bytes, bid)
+
+	if err != nil {
+		return fmt.Errorf("Error deserializing sealed bid. %s", err.Error())
+	}
+
+	return nil
+}
+
+// RankSealedBids returns the revealed bids at or above the reserve
+// price, highest price first. The earliest committed bid ranks
+// first on a tie
+func RankSealedBids(bids []*SealedBid, reservePrice int) []*SealedBid {
+	ranked := []*SealedBid{}
+
+	for _, bid := range bids {
+		if bid.IsRevealed() && bid.Price >= reservePrice {
+			ranked = append(ranked, bid)
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Price != ranked[j].Price {
+			return ranked[i].Price > ranked[j].Price
+		}
+
+		return ranked[i].CommittedDateTime < ranked[j].CommittedDateTime
+	})
+
+	return ranked
+}
@@ -0,0 +1,67 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashBid(t *testing.T) {
+	hash := HashBid("someissuer", "somephr", "somebidder", 100, "somesalt")
+	assert.Equal(t, "9106bffe1ebed1b144533eba9cc3d0ef588db50317637b36926ccb76b6fe17d3", hash, "should hash JSON array of issuer, phr number, bidder, price and salt")
+	assert.NotEqual(t, hash, HashBid("someissuer", "somephr", "somebidder", 101, "somesalt"), "should hash different prices differently")
+	assert.NotEqual(t, hash, HashBid("someissuer", "somephr", "somebidder", 100, "someothersalt"), "should hash different salts differently")
+	assert.NotEqual(t, hash, HashBid("someissuer", "somephr", "someotherbidder", 100, "somesalt"), "should hash different bidders differently")
+	assert.NotEqual(t, hash, HashBid("someotherissuer", "somephr", "somebidder", 100, "somesalt"), "should hash different issuers differently")
+	assert.NotEqual(t, hash, HashBid("someissuer", "someotherphr", "somebidder", 100, "somesalt"), "should hash different phrs differently")
+	assert.NotEqual(t, HashBid("someissuer", "somephr", "some:bidder", 100, "somesalt"), HashBid("someissuer", "somephr:some", "bidder", 100, "somesalt"), "should not join fields ambiguously")
+}
+
+func TestSealedBidIsRevealed(t *testing.T) {
+	assert.False(t, (&SealedBid{Hash: "somehash"}).IsRevealed(), "should not be revealed without revealed date time")
+	assert.True(t, (&SealedBid{Hash: "somehash", RevealedDateTime: "2021-12-11T10:00:00Z"}).IsRevealed(), "should be revealed with revealed date time")
+}
+
+func TestSealedBidGetSplitKey(t *testing.T) {
+	bid := &SealedBid{Issuer: "someissuer", PHRNumber: "somephr", AuctionID: "sometx", Bidder: "somebidder"}
+
+	assert.Equal(t, []string{"someissuer", "somephr", "sometx", "somebidder"}, bid.GetSplitKey(), "should return issuer, phr number, auction and bidder as split key")
+}
+
+func TestSealedBidSerialize(t *testing.T) {
+	bid := &SealedBid{Issuer: "someissuer", PHRNumber: "somephr", AuctionID: "sometx", Bidder: "somebidder", Hash: "somehash", CommittedDateTime: "2021-12-10T10:00:00Z"}
+
+	bytes, err := bid.Serialize()
+	assert.Nil(t, err, "should not error on serialize")
+	assert.Equal(t, `{"issuer":"someissuer","phrNumber":"somephr","auctionId":"sometx","bidder":"somebidder","hash":"somehash","committedDateTime":"2021-12-10T10:00:00Z"}`, string(bytes), "should not write price or salt before reveal")
+}
+
+func TestDeserializeSealedBid(t *testing.T) {
+	var bid *SealedBid
+	var err error
+
+	bid = new(SealedBid)
+	err = DeserializeSealedBid([]byte(`{"issuer":"someissuer","phrNumber":"somephr","hash":"somehash","price":100,"salt":"somesalt"}`), bid)
+	assert.Nil(t, err, "should not return error for deserialize")
+	assert.Equal(t, &SealedBid{Issuer: "someissuer", PHRNumber: "somephr", Hash: "somehash", Price: 100, Salt: "somesalt"}, bid, "should create expected sealed bid")
+
+	bid = new(SealedBid)
+	err = DeserializeSealedBid([]byte(`{"price":"NaN"}`), bid)
+	assert.EqualError(t, err, "Error deserializing sealed bid. json: cannot unmarshal string into Go struct field SealedBid.price of type int", "should return error for bad data")
+}
+
+func TestRankSealedBids(t *testing.T) {
+	early := &SealedBid{Bidder: "someearlybidder", Price: 200, CommittedDateTime: "2021-12-09T10:00:00Z", RevealedDateTime: "2021-12-11T10:00:00Z"}
+	late := &SealedBid{Bidder: "somelatebidder", Price: 200, CommittedDateTime: "2021-12-10T09:00:00Z", RevealedDateTime: "2021-12-11T09:00:00Z"}
+	low := &SealedBid{Bidder: "somelowbidder", Price: 50, CommittedDateTime: "2021-12-08T10:00:00Z", RevealedDateTime: "2021-12-11T10:00:00Z"}
+	sealed := &SealedBid{Bidder: "somesealedbidder", Hash: "somehash", CommittedDateTime: "2021-12-08T10:00:00Z"}
+
+	assert.Empty(t, RankSealedBids([]*SealedBid{}, 100), "should return no bids when no bids")
+	assert.Empty(t, RankSealedBids([]*SealedBid{low, sealed}, 100), "should return no bids when no revealed bid meets the reserve price")
+	assert.Equal(t, []*SealedBid{low}, RankSealedBids([]*SealedBid{low, sealed}, 0), "should ignore bids not revealed")
+	assert.Equal(t, []*SealedBid{early, late}, RankSealedBids([]*SealedBid{low, late, early, sealed}, 100), "should rank highest price committed earliest first")
+}
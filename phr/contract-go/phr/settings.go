@@ -0,0 +1,85 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+// DefaultTokenAdminMSP MSP of the clearing house, which is neither
+// an issuer nor a buyer of phrs, administering payment tokens
+const DefaultTokenAdminMSP = "Org3MSP"
+
+// Settings of a deployment of the contract. Every organization
+// must deploy the same settings so their peers endorse alike.
+// Owners maps an MSP ID to the owner its clients act as and
+// Members to the other owners they may act as. Roles maps an
+// MSP ID to the role of its clients and Allowed to the other
+// roles they may claim
+type Settings struct {
+	Owners        map[string]string
+	Members       map[string][]string
+	Roles         map[string]Role
+	Allowed       map[string][]Role
+	TokenAdminMSP string
+}
+
+// DefaultSettings returns the settings of the network. The institute
+// MSP buys phrs and the hospital MSP issues them. Clients of either
+// MSP may also claim the auditor role, and clients of the hospital
+// MSP, whose CA enrolls patients, the patient role
+func DefaultSettings() *Settings {
+	return &Settings{
+		Owners: map[string]string{
+			"Org1MSP": "institute",
+			"Org2MSP": "hospital",
+		},
+		Members: map[string][]string{},
+		Roles: map[string]Role{
+			"Org1MSP": BuyerRole,
+			"Org2MSP": IssuerRole,
+		},
+		Allowed: map[string][]Role{
+			"Org1MSP": {AuditorRole},
+			"Org2MSP": {AuditorRole, PatientRole},
+		},
+		TokenAdminMSP: DefaultTokenAdminMSP,
+	}
+}
+
+// NewIdentityMapper create a new identity mapper
+// using the MSP to owner mapping of the settings
+func (s *Settings) NewIdentityMapper() *IdentityMapper {
+	im := new(IdentityMapper)
+	im.Owners = map[string]string{}
+	im.Members = map[string][]string{}
+
+	for mspID, owner := range s.Owners {
+		im.Owners[mspID] = owner
+	}
+
+	for mspID, members := range s.Members {
+		im.Members[mspID] = append([]string{}, members...)
+	}
+
+	return im
+}
+
+// NewRoleMapper create a new role mapper using the MSP to role
+// mapping of the settings, with clients of the token admin MSP
+// holding the token admin role
+func (s *Settings) NewRoleMapper() *RoleMapper {
+	rm := new(RoleMapper)
+	rm.Roles = map[string]Role{}
+	rm.Allowed = map[string][]Role{}
+
+	for mspID, role := range s.Roles {
+		rm.Roles[mspID] = role
+	}
+
+	for mspID, roles := range s.Allowed {
+		rm.Allowed[mspID] = append([]Role{}, roles...)
+	}
+
+	rm.Roles[s.TokenAdminMSP] = TokenAdminRole
+
+	return rm
+}
@@ -0,0 +1,59 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultSettings(t *testing.T) {
+	settings := DefaultSettings()
+
+	assert.Equal(t, "Org3MSP", settings.TokenAdminMSP, "should name the clearing house as token admin MSP")
+	assert.Equal(t, map[string]string{"Org1MSP": "institute", "Org2MSP": "hospital"}, settings.Owners, "should map MSPs of the network to owners")
+	assert.Equal(t, map[string]Role{"Org1MSP": BuyerRole, "Org2MSP": IssuerRole}, settings.Roles, "should map MSPs of the network to roles")
+	assert.Equal(t, []Role{AuditorRole, PatientRole}, settings.Allowed["Org2MSP"], "should allow hospital clients to claim auditor and patient roles")
+}
+
+func TestSettingsNewIdentityMapper(t *testing.T) {
+	settings := &Settings{
+		Owners:  map[string]string{"someMSP": "someowner"},
+		Members: map[string][]string{"someMSP": {"somemember"}},
+	}
+	im := settings.NewIdentityMapper()
+
+	assert.Equal(t, settings.Owners, im.Owners, "should use owners of settings")
+	assert.Equal(t, settings.Members, im.Members, "should use members of settings")
+
+	im.Owners["someotherMSP"] = "someotherowner"
+	im.Members["someMSP"][0] = "someothermember"
+	assert.Equal(t, map[string]string{"someMSP": "someowner"}, settings.Owners, "should not share owners with settings")
+	assert.Equal(t, []string{"somemember"}, settings.Members["someMSP"], "should not share members with settings")
+}
+
+func TestSettingsNewRoleMapper(t *testing.T) {
+	settings := DefaultSettings()
+	settings.TokenAdminMSP = "someadminMSP"
+	rm := settings.NewRoleMapper()
+
+	role, err := rm.GetRole(&FakeClientIdentity{MSPID: "someadminMSP"})
+	assert.Nil(t, err, "should not error for token admin MSP")
+	assert.Equal(t, TokenAdminRole, role, "should map token admin MSP to token admin role")
+
+	role, err = rm.GetRole(&FakeClientIdentity{MSPID: "Org2MSP"})
+	assert.Nil(t, err, "should not error for MSP of settings")
+	assert.Equal(t, IssuerRole, role, "should keep mapping of other MSPs")
+
+	role, err = rm.GetRole(&FakeClientIdentity{MSPID: "Org2MSP", Attributes: map[string]string{RoleAttribute: "tokenadmin"}})
+	assert.NotNil(t, err, "should not allow other MSPs to claim token admin role")
+	assert.Equal(t, Role(""), role, "should not return token admin role to other MSPs")
+
+	rm.Allowed["Org2MSP"][0] = TokenAdminRole
+	assert.Equal(t, AuditorRole, settings.Allowed["Org2MSP"][0], "should not share allowed roles with settings")
+	_, ok := settings.Roles["someadminMSP"]
+	assert.False(t, ok, "should not add token admin role to settings")
+}
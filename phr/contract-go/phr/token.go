@@ -0,0 +1,66 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Balance defines the amount of payment tokens held by an owner
+type Balance struct {
+	Owner  string `json:"owner"`
+	Amount int    `json:"amount"`
+}
+
+// GetSplitKey returns values which should be used to form key
+func (balance *Balance) GetSplitKey() []string {
+	return []string{balance.Owner}
+}
+
+// Serialize formats the balance as JSON bytes
+func (balance *Balance) Serialize() ([]byte, error) {
+	return json.Marshal(balance)
+}
+
+// DeserializeBalance formats the balance from JSON bytes
+func DeserializeBalance(bytes []byte, balance *Balance) error {
+	err := json.Unmarshal(bytes, balance)
+
+	if err != nil {
+		return fmt.Errorf("Error deserializing balance. %s", err.Error())
+	}
+
+	return nil
+}
+
+// Allowance defines the amount of payment tokens a spender
+// may transfer on behalf of an owner
+type Allowance struct {
+	Owner   string `json:"owner"`
+	Spender string `json:"spender"`
+	Amount  int    `json:"amount"`
+}
+
+// GetSplitKey returns values which should be used to form key
+func (allowance *Allowance) GetSplitKey() []string {
+	return []string{allowance.Owner, allowance.Spender}
+}
+
+// Serialize formats the allowance as JSON bytes
+func (allowance *Allowance) Serialize() ([]byte, error) {
+	return json.Marshal(allowance)
+}
+
+// DeserializeAllowance formats the allowance from JSON bytes
+func DeserializeAllowance(bytes []byte, allowance *Allowance) error {
+	err := json.Unmarshal(bytes, allowance)
+
+	if err != nil {
+		return fmt.Errorf("Error deserializing allowance. %s", err.Error())
+	}
+
+	return nil
+}
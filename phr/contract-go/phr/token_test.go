@@ -0,0 +1,67 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBalanceGetSplitKey(t *testing.T) {
+	balance := &Balance{Owner: "someowner", Amount: 100}
+
+	assert.Equal(t, []string{"someowner"}, balance.GetSplitKey(), "should return owner as split key")
+}
+
+func TestBalanceSerialize(t *testing.T) {
+	balance := &Balance{Owner: "someowner", Amount: 100}
+
+	bytes, err := balance.Serialize()
+	assert.Nil(t, err, "should not error on serialize")
+	assert.Equal(t, `{"owner":"someowner","amount":100}`, string(bytes), "should return JSON formatted value")
+}
+
+func TestDeserializeBalance(t *testing.T) {
+	var balance *Balance
+	var err error
+
+	balance = new(Balance)
+	err = DeserializeBalance([]byte(`{"owner":"someowner","amount":100}`), balance)
+	assert.Nil(t, err, "should not return error for deserialize")
+	assert.Equal(t, &Balance{Owner: "someowner", Amount: 100}, balance, "should create expected balance")
+
+	balance = new(Balance)
+	err = DeserializeBalance([]byte(`{"amount":"NaN"}`), balance)
+	assert.EqualError(t, err, "Error deserializing balance. json: cannot unmarshal string into Go struct field Balance.amount of type int", "should return error for bad data")
+}
+
+func TestAllowanceGetSplitKey(t *testing.T) {
+	allowance := &Allowance{Owner: "someowner", Spender: "somespender", Amount: 100}
+
+	assert.Equal(t, []string{"someowner", "somespender"}, allowance.GetSplitKey(), "should return owner and spender as split key")
+}
+
+func TestAllowanceSerialize(t *testing.T) {
+	allowance := &Allowance{Owner: "someowner", Spender: "somespender", Amount: 100}
+
+	bytes, err := allowance.Serialize()
+	assert.Nil(t, err, "should not error on serialize")
+	assert.Equal(t, `{"owner":"someowner","spender":"somespender","amount":100}`, string(bytes), "should return JSON formatted value")
+}
+
+func TestDeserializeAllowance(t *testing.T) {
+	var allowance *Allowance
+	var err error
+
+	allowance = new(Allowance)
+	err = DeserializeAllowance([]byte(`{"owner":"someowner","spender":"somespender","amount":100}`), allowance)
+	assert.Nil(t, err, "should not return error for deserialize")
+	assert.Equal(t, &Allowance{Owner: "someowner", Spender: "somespender", Amount: 100}, allowance, "should create expected allowance")
+
+	allowance = new(Allowance)
+	err = DeserializeAllowance([]byte(`{"amount":"NaN"}`), allowance)
+	assert.EqualError(t, err, "Error deserializing allowance. json: cannot unmarshal string into Go struct field Allowance.amount of type int", "should return error for bad data")
+}
@@ -0,0 +1,254 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"fmt"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+)
+
+// Mint creates payment tokens in the balance of an owner.
+// Submitted by a client of the token admin MSP of the settings
+func (c *Contract) Mint(ctx TransactionContextInterface, owner string, amount int) (*Balance, error) {
+	if amount <= 0 {
+		return nil, &ValidationError{Field: "amount", Value: fmt.Sprint(amount), Reason: "Must be greater than zero"}
+	}
+
+	mspID, err := ctx.GetClientIdentity().GetMSPID()
+
+	if err != nil {
+		return nil, err
+	}
+
+	tokenAdminMSP := ctx.GetSettings().TokenAdminMSP
+
+	if mspID != tokenAdminMSP {
+		return nil, phrerror.Errorf(phrerror.Forbidden, "Only MSP %s can mint tokens. Submitter MSP = %s", tokenAdminMSP, mspID)
+	}
+
+	balance, err := ctx.GetTokenList().GetBalance(owner)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if balance.Amount+amount < balance.Amount {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "Minting %d overflows the balance of %s", amount, owner)
+	}
+
+	balance.Amount += amount
+
+	err = ctx.GetTokenList().UpdateBalance(balance)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return balance, nil
+}
+
+// Transfer moves payment tokens from the submitter to another owner
+func (c *Contract) Transfer(ctx TransactionContextInterface, to string, amount int) (*Balance, error) {
+	if amount <= 0 {
+		return nil, &ValidationError{Field: "amount", Value: fmt.Sprint(amount), Reason: "Must be greater than zero"}
+	}
+
+	caller, err := ctx.GetCaller()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = settle(ctx, caller, payment{to: to, amount: amount})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx.GetTokenList().GetBalance(caller)
+}
+
+// TransferFrom moves payment tokens from an owner to another owner
+// within the allowance approved for the submitter by the owner
+func (c *Contract) TransferFrom(ctx TransactionContextInterface, from string, to string, amount int) (*Allowance, error) {
+	if amount <= 0 {
+		return nil, &ValidationError{Field: "amount", Value: fmt.Sprint(amount), Reason: "Must be greater than zero"}
+	}
+
+	caller, err := ctx.GetCaller()
+
+	if err != nil {
+		return nil, err
+	}
+
+	allowance, err := spendAllowance(ctx, from, caller, amount)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = settle(ctx, from, payment{to: to, amount: amount})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return allowance, nil
+}
+
+// Approve sets the amount of payment tokens a spender may
+// transfer on behalf of the submitter, replacing any earlier
+// allowance. An amount of zero revokes the allowance
+func (c *Contract) Approve(ctx TransactionContextInterface, spender string, amount int) (*Allowance, error) {
+	if amount < 0 {
+		return nil, &ValidationError{Field: "amount", Value: fmt.Sprint(amount), Reason: "Must not be negative"}
+	}
+
+	caller, err := ctx.GetCaller()
+
+	if err != nil {
+		return nil, err
+	}
+
+	allowance := Allowance{Owner: caller, Spender: spender, Amount: amount}
+
+	err = ctx.GetTokenList().UpdateAllowance(&allowance)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &allowance, nil
+}
+
+// BalanceOf returns the payment token balance of an owner
+func (c *Contract) BalanceOf(ctx TransactionContextInterface, owner string) (*Balance, error) {
+	return ctx.GetTokenList().GetBalance(owner)
+}
+
+// GetAllowance returns the allowance of a spender on behalf of an owner
+func (c *Contract) GetAllowance(ctx TransactionContextInterface, owner string, spender string) (*Allowance, error) {
+	return ctx.GetTokenList().GetAllowance(owner, spender)
+}
+
+// spendAllowance reduces the allowance of a spender on behalf of
+// an owner by the amount. Errors when the allowance is insufficient
+func spendAllowance(ctx TransactionContextInterface, owner string, spender string, amount int) (*Allowance, error) {
+	allowance, err := ctx.GetTokenList().GetAllowance(owner, spender)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if allowance.Amount < amount {
+		return nil, phrerror.Errorf(phrerror.InvalidState, "Insufficient allowance. Allowance of %s for %s is %d, needs %d", owner, spender, allowance.Amount, amount)
+	}
+
+	allowance.Amount -= amount
+
+	err = ctx.GetTokenList().UpdateAllowance(allowance)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return allowance, nil
+}
+
+// checkFunds checks the balance of an owner covers the amount
+func checkFunds(ctx TransactionContextInterface, owner string, amount int) error {
+	balance, err := ctx.GetTokenList().GetBalance(owner)
+
+	if err != nil {
+		return err
+	}
+
+	if balance.Amount < amount {
+		return phrerror.Errorf(phrerror.InvalidState, "Insufficient funds. Balance of %s is %d, needs %d", owner, balance.Amount, amount)
+	}
+
+	return nil
+}
+
+// payment an amount settle credits to an owner
+type payment struct {
+	to     string
+	amount int
+}
+
+// settle debits the total of the payments from one balance and credits
+// each payment to its owner. Reads and writes every balance once, as
+// reads in a transaction do not see its own writes. Errors when the
+// debited balance is insufficient
+func settle(ctx TransactionContextInterface, from string, payments ...payment) error {
+	debit, err := ctx.GetTokenList().GetBalance(from)
+
+	if err != nil {
+		return err
+	}
+
+	total := 0
+	credits := map[string]*Balance{}
+	amounts := map[string]int{}
+	order := []string{}
+
+	for _, p := range payments {
+		if p.to == from || p.amount == 0 {
+			continue
+		}
+
+		credit, ok := credits[p.to]
+
+		if !ok {
+			credit, err = ctx.GetTokenList().GetBalance(p.to)
+
+			if err != nil {
+				return err
+			}
+
+			credits[p.to] = credit
+			order = append(order, p.to)
+		}
+
+		pending := credit.Amount + amounts[p.to]
+
+		if pending+p.amount < pending {
+			return phrerror.Errorf(phrerror.InvalidState, "Crediting %d overflows the balance of %s", p.amount, p.to)
+		}
+
+		amounts[p.to] += p.amount
+		total += p.amount
+	}
+
+	if debit.Amount < total {
+		return phrerror.Errorf(phrerror.InvalidState, "Insufficient funds. Balance of %s is %d, needs %d", from, debit.Amount, total)
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	debit.Amount -= total
+
+	err = ctx.GetTokenList().UpdateBalance(debit)
+
+	if err != nil {
+		return err
+	}
+
+	for _, owner := range order {
+		credit := credits[owner]
+		credit.Amount += amounts[owner]
+
+		err = ctx.GetTokenList().UpdateBalance(credit)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
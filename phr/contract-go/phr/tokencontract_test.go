@@ -0,0 +1,219 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMint(t *testing.T) {
+	var balance *Balance
+	var err error
+
+	ctx := newMockTransactionContext("hospital")
+	ctx.GetClientIdentity().(*MockClientIdentity).On("GetMSPID").Return(DefaultTokenAdminMSP, nil)
+	mtkl := ctx.tokenList
+
+	contract := new(Contract)
+
+	wsBalance := &Balance{Owner: "someowner", Amount: 100}
+	var emptyBalance *Balance
+	mtkl.On("GetBalance", "someowner").Return(wsBalance, nil)
+	mtkl.On("GetBalance", "somerichowner").Return(&Balance{Owner: "somerichowner", Amount: math.MaxInt64}, nil)
+	mtkl.On("GetBalance", "somebadowner").Return(emptyBalance, errors.New("GetBalance error"))
+	mtkl.On("UpdateBalance", wsBalance).Return(nil)
+
+	balance, err = contract.Mint(ctx, "someowner", 0)
+	assertCodedError(t, err, phrerror.Validation, `Invalid amount "0". Must be greater than zero`, "should error when amount not positive")
+	assert.Nil(t, balance, "should not return balance when amount not positive")
+
+	mspErrCtx := newMockTransactionContext("hospital")
+	mspErrCtx.GetClientIdentity().(*MockClientIdentity).On("GetMSPID").Return("", errors.New("GetMSPID error"))
+	balance, err = contract.Mint(mspErrCtx, "someowner", 50)
+	assert.EqualError(t, err, "GetMSPID error", "should error when GetMSPID errors")
+	assert.Nil(t, balance, "should not return balance when GetMSPID errors")
+
+	otherCtx := newMockTransactionContext("institute")
+	otherCtx.GetClientIdentity().(*MockClientIdentity).On("GetMSPID").Return("Org1MSP", nil)
+	balance, err = contract.Mint(otherCtx, "someowner", 50)
+	assertCodedError(t, err, phrerror.Forbidden, "Only MSP Org3MSP can mint tokens. Submitter MSP = Org1MSP", "should error when submitter not of admin MSP")
+	assert.Nil(t, balance, "should not return balance when submitter not of admin MSP")
+
+	balance, err = contract.Mint(ctx, "somebadowner", 50)
+	assert.EqualError(t, err, "GetBalance error", "should error when GetBalance errors")
+	assert.Nil(t, balance, "should not return balance when GetBalance errors")
+
+	balance, err = contract.Mint(ctx, "somerichowner", 1)
+	assertCodedError(t, err, phrerror.InvalidState, "Minting 1 overflows the balance of somerichowner", "should error when balance overflows")
+	assert.Nil(t, balance, "should not return balance when balance overflows")
+
+	balance, err = contract.Mint(ctx, "someowner", 50)
+	assert.Nil(t, err, "should not error on good mint")
+	assert.Equal(t, &Balance{Owner: "someowner", Amount: 150}, balance, "should add amount to balance")
+	mtkl.AssertCalled(t, "UpdateBalance", wsBalance)
+}
+
+func TestTransfer(t *testing.T) {
+	var balance *Balance
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mtkl := ctx.tokenList
+
+	contract := new(Contract)
+
+	senderBalance := &Balance{Owner: "someowner", Amount: 100}
+	receiverBalance := &Balance{Owner: "someotherowner", Amount: 10}
+	mtkl.On("GetBalance", "someowner").Return(senderBalance, nil)
+	mtkl.On("GetBalance", "someotherowner").Return(receiverBalance, nil)
+	mtkl.On("UpdateBalance", mock.Anything).Return(nil)
+
+	balance, err = contract.Transfer(ctx, "someotherowner", -5)
+	assertCodedError(t, err, phrerror.Validation, `Invalid amount "-5". Must be greater than zero`, "should error when amount not positive")
+	assert.Nil(t, balance, "should not return balance when amount not positive")
+
+	balance, err = contract.Transfer(ctx, "someotherowner", 101)
+	assertCodedError(t, err, phrerror.InvalidState, "Insufficient funds. Balance of someowner is 100, needs 101", "should error when sender cannot pay")
+	assert.Nil(t, balance, "should not return balance when sender cannot pay")
+	mtkl.AssertNotCalled(t, "UpdateBalance", mock.Anything)
+
+	balance, err = contract.Transfer(ctx, "someowner", 50)
+	assert.Nil(t, err, "should not error on transfer to self")
+	assert.Equal(t, 100, balance.Amount, "should not change balance on transfer to self")
+	mtkl.AssertNotCalled(t, "UpdateBalance", mock.Anything)
+
+	balance, err = contract.Transfer(ctx, "someotherowner", 40)
+	assert.Nil(t, err, "should not error on good transfer")
+	assert.Equal(t, &Balance{Owner: "someowner", Amount: 60}, balance, "should return debited balance of sender")
+	assert.Equal(t, 50, receiverBalance.Amount, "should credit receiver")
+	mtkl.AssertCalled(t, "UpdateBalance", senderBalance)
+	mtkl.AssertCalled(t, "UpdateBalance", receiverBalance)
+}
+
+func TestTransferFrom(t *testing.T) {
+	var allowance *Allowance
+	var err error
+
+	ctx := newMockTransactionContext("somespender")
+	mtkl := ctx.tokenList
+
+	contract := new(Contract)
+
+	wsAllowance := &Allowance{Owner: "someowner", Spender: "somespender", Amount: 50}
+	poorAllowance := &Allowance{Owner: "somepoorowner", Spender: "somespender", Amount: 50}
+	ownerBalance := &Balance{Owner: "someowner", Amount: 100}
+	receiverBalance := &Balance{Owner: "someotherowner"}
+	mtkl.On("GetAllowance", "someowner", "somespender").Return(wsAllowance, nil)
+	mtkl.On("GetAllowance", "somepoorowner", "somespender").Return(poorAllowance, nil)
+	mtkl.On("UpdateAllowance", mock.Anything).Return(nil)
+	mtkl.On("GetBalance", "someowner").Return(ownerBalance, nil)
+	mtkl.On("GetBalance", "somepoorowner").Return(&Balance{Owner: "somepoorowner"}, nil)
+	mtkl.On("GetBalance", "someotherowner").Return(receiverBalance, nil)
+	mtkl.On("UpdateBalance", mock.Anything).Return(nil)
+
+	allowance, err = contract.TransferFrom(ctx, "someowner", "someotherowner", 0)
+	assertCodedError(t, err, phrerror.Validation, `Invalid amount "0". Must be greater than zero`, "should error when amount not positive")
+	assert.Nil(t, allowance, "should not return allowance when amount not positive")
+
+	allowance, err = contract.TransferFrom(ctx, "someowner", "someotherowner", 60)
+	assertCodedError(t, err, phrerror.InvalidState, "Insufficient allowance. Allowance of someowner for somespender is 50, needs 60", "should error when allowance too small")
+	assert.Nil(t, allowance, "should not return allowance when allowance too small")
+
+	allowance, err = contract.TransferFrom(ctx, "somepoorowner", "someotherowner", 20)
+	assertCodedError(t, err, phrerror.InvalidState, "Insufficient funds. Balance of somepoorowner is 0, needs 20", "should error when owner cannot pay")
+	assert.Nil(t, allowance, "should not return allowance when owner cannot pay")
+
+	allowance, err = contract.TransferFrom(ctx, "someowner", "someotherowner", 20)
+	assert.Nil(t, err, "should not error on good transfer")
+	assert.Equal(t, &Allowance{Owner: "someowner", Spender: "somespender", Amount: 30}, allowance, "should reduce allowance by amount")
+	assert.Equal(t, 80, ownerBalance.Amount, "should debit owner")
+	assert.Equal(t, 20, receiverBalance.Amount, "should credit receiver")
+}
+
+func TestApprove(t *testing.T) {
+	var allowance *Allowance
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mtkl := ctx.tokenList
+
+	contract := new(Contract)
+
+	var sentAllowance *Allowance
+	mtkl.On("UpdateAllowance", mock.MatchedBy(func(allowance *Allowance) bool { sentAllowance = allowance; return true })).Return(nil)
+
+	allowance, err = contract.Approve(ctx, "somespender", -1)
+	assertCodedError(t, err, phrerror.Validation, `Invalid amount "-1". Must not be negative`, "should error when amount negative")
+	assert.Nil(t, allowance, "should not return allowance when amount negative")
+
+	allowance, err = contract.Approve(ctx, "somespender", 50)
+	assert.Nil(t, err, "should not error on good approve")
+	assert.Equal(t, &Allowance{Owner: "someowner", Spender: "somespender", Amount: 50}, allowance, "should return allowance of submitter")
+	assert.Equal(t, sentAllowance, allowance, "should put same allowance as it returns in the world state")
+}
+
+func TestBalanceOf(t *testing.T) {
+	ctx := newMockTransactionContext("someowner")
+	mtkl := ctx.tokenList
+
+	contract := new(Contract)
+
+	wsBalance := &Balance{Owner: "someowner", Amount: 100}
+	mtkl.On("GetBalance", "someowner").Return(wsBalance, nil)
+
+	balance, err := contract.BalanceOf(ctx, "someowner")
+	assert.Nil(t, err, "should not error when GetBalance does not error")
+	assert.Equal(t, wsBalance, balance, "should return balance from token list")
+}
+
+func TestGetAllowance(t *testing.T) {
+	ctx := newMockTransactionContext("someowner")
+	mtkl := ctx.tokenList
+
+	contract := new(Contract)
+
+	wsAllowance := &Allowance{Owner: "someowner", Spender: "somespender", Amount: 50}
+	mtkl.On("GetAllowance", "someowner", "somespender").Return(wsAllowance, nil)
+
+	allowance, err := contract.GetAllowance(ctx, "someowner", "somespender")
+	assert.Nil(t, err, "should not error when GetAllowance does not error")
+	assert.Equal(t, wsAllowance, allowance, "should return allowance from token list")
+}
+
+func TestSettle(t *testing.T) {
+	var err error
+
+	ctx := newMockTransactionContext("someowner")
+	mtkl := ctx.tokenList
+
+	payerBalance := &Balance{Owner: "someowner", Amount: 100}
+	payeeBalance := &Balance{Owner: "someotherowner", Amount: 10}
+	mtkl.On("GetBalance", "someowner").Return(payerBalance, nil)
+	mtkl.On("GetBalance", "someotherowner").Return(payeeBalance, nil)
+	mtkl.On("GetBalance", "somerichowner").Return(&Balance{Owner: "somerichowner", Amount: math.MaxInt64}, nil)
+	mtkl.On("UpdateBalance", mock.Anything).Return(nil)
+
+	err = settle(ctx, "someowner", payment{to: "somerichowner", amount: 1})
+	assertCodedError(t, err, phrerror.InvalidState, "Crediting 1 overflows the balance of somerichowner", "should error when credit overflows")
+	mtkl.AssertNotCalled(t, "UpdateBalance", mock.Anything)
+
+	err = settle(ctx, "someowner", payment{to: "someotherowner", amount: 60}, payment{to: "someotherowner", amount: 50})
+	assertCodedError(t, err, phrerror.InvalidState, "Insufficient funds. Balance of someowner is 100, needs 110", "should error when payer cannot pay the total")
+	assert.Equal(t, 100, payerBalance.Amount, "should not debit payer when payer cannot pay")
+	mtkl.AssertNotCalled(t, "UpdateBalance", mock.Anything)
+
+	err = settle(ctx, "someowner", payment{to: "someotherowner", amount: 30}, payment{to: "someowner", amount: 500}, payment{to: "someotherowner", amount: 20})
+	assert.Nil(t, err, "should not error on good settle")
+	assert.Equal(t, 50, payerBalance.Amount, "should debit the total paid to others from payer")
+	assert.Equal(t, 60, payeeBalance.Amount, "should credit every payment of a payee")
+	mtkl.AssertNumberOfCalls(t, "GetBalance", 6)
+	mtkl.AssertNumberOfCalls(t, "UpdateBalance", 2)
+}
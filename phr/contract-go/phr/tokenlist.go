@@ -0,0 +1,93 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+
+// TokenListInterface defines functionality needed
+// to interact with the world state on behalf
+// of payment token balances and allowances
+type TokenListInterface interface {
+	GetBalance(string) (*Balance, error)
+	UpdateBalance(*Balance) error
+	GetAllowance(string, string) (*Allowance, error)
+	UpdateAllowance(*Allowance) error
+}
+
+// balanceListName namespace of balances in world state
+const balanceListName = "org.phrnet.balancelist"
+
+// allowanceListName namespace of allowances in world state
+const allowanceListName = "org.phrnet.allowancelist"
+
+type tokenList struct {
+	stateList          ledgerapi.StateListInterface
+	allowanceStateList ledgerapi.StateListInterface
+}
+
+// GetBalance returns the balance of an owner. An owner
+// never credited has a zero balance
+func (tl *tokenList) GetBalance(owner string) (*Balance, error) {
+	balance := new(Balance)
+
+	err := tl.stateList.GetState(ledgerapi.MakeKey(owner), balance)
+
+	if _, ok := err.(*ledgerapi.StateNotFoundError); ok {
+		return &Balance{Owner: owner}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return balance, nil
+}
+
+// UpdateBalance puts the balance of an owner
+func (tl *tokenList) UpdateBalance(balance *Balance) error {
+	return tl.stateList.UpdateState(balance)
+}
+
+// GetAllowance returns the allowance of a spender on behalf of
+// an owner. A spender never approved has a zero allowance
+func (tl *tokenList) GetAllowance(owner string, spender string) (*Allowance, error) {
+	allowance := new(Allowance)
+
+	err := tl.allowanceStateList.GetState(ledgerapi.MakeKey(owner, spender), allowance)
+
+	if _, ok := err.(*ledgerapi.StateNotFoundError); ok {
+		return &Allowance{Owner: owner, Spender: spender}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return allowance, nil
+}
+
+// UpdateAllowance puts the allowance of a spender
+func (tl *tokenList) UpdateAllowance(allowance *Allowance) error {
+	return tl.allowanceStateList.UpdateState(allowance)
+}
+
+// newTokenList create a new token list from context
+func newTokenList(ctx TransactionContextInterface) *tokenList {
+	stateList := new(ledgerapi.StateList)
+	stateList.Ctx = ctx
+	stateList.Name = balanceListName
+	stateList.Deserialize = func(bytes []byte, state ledgerapi.StateInterface) error {
+		return DeserializeBalance(bytes, state.(*Balance))
+	}
+
+	allowanceStateList := new(ledgerapi.StateList)
+	allowanceStateList.Ctx = ctx
+	allowanceStateList.Name = allowanceListName
+	allowanceStateList.Deserialize = func(bytes []byte, state ledgerapi.StateInterface) error {
+		return DeserializeAllowance(bytes, state.(*Allowance))
+	}
+
+	list := new(tokenList)
+	list.stateList = stateList
+	list.allowanceStateList = allowanceStateList
+
+	return list
+}
@@ -0,0 +1,110 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"errors"
+	"testing"
+
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetBalanceFromList(t *testing.T) {
+	var balance *Balance
+	var err error
+
+	list := new(tokenList)
+	msl := new(MockStateList)
+	isBalance := mock.MatchedBy(func(state ledgerapi.StateInterface) bool { _, ok := state.(*Balance); return ok })
+	msl.On("GetState", "someowner", isBalance).Return(nil)
+	msl.On("GetState", "somenewowner", isBalance).Return(&ledgerapi.StateNotFoundError{Key: "somenewowner"})
+	msl.On("GetState", "somebadowner", isBalance).Return(errors.New("GetState error"))
+	list.stateList = msl
+
+	balance, err = list.GetBalance("someowner")
+	assert.Nil(t, err, "should not error when get state on state list does not error")
+	assert.Equal(t, 100, balance.Amount, "should use state list GetState to fill balance")
+
+	balance, err = list.GetBalance("somenewowner")
+	assert.Nil(t, err, "should not error when owner has no balance")
+	assert.Equal(t, &Balance{Owner: "somenewowner"}, balance, "should return zero balance when owner has no balance")
+
+	balance, err = list.GetBalance("somebadowner")
+	assert.EqualError(t, err, "GetState error", "should return error when state list get state errors")
+	assert.Nil(t, balance, "should not return balance on error")
+}
+
+func TestUpdateBalance(t *testing.T) {
+	balance := new(Balance)
+
+	list := new(tokenList)
+	msl := new(MockStateList)
+	msl.On("UpdateState", balance).Return(errors.New("Called update state correctly"))
+	list.stateList = msl
+
+	err := list.UpdateBalance(balance)
+	assert.EqualError(t, err, "Called update state correctly", "should call state list update state with balance")
+}
+
+func TestGetAllowanceFromList(t *testing.T) {
+	var allowance *Allowance
+	var err error
+
+	list := new(tokenList)
+	msl := new(MockStateList)
+	isAllowance := mock.MatchedBy(func(state ledgerapi.StateInterface) bool { _, ok := state.(*Allowance); return ok })
+	msl.On("GetState", "someowner:somespender", isAllowance).Return(nil)
+	msl.On("GetState", "someowner:somenewspender", isAllowance).Return(&ledgerapi.StateNotFoundError{Key: "someowner:somenewspender"})
+	msl.On("GetState", "someowner:somebadspender", isAllowance).Return(errors.New("GetState error"))
+	list.allowanceStateList = msl
+
+	allowance, err = list.GetAllowance("someowner", "somespender")
+	assert.Nil(t, err, "should not error when get state on allowance state list does not error")
+	assert.Equal(t, 100, allowance.Amount, "should use allowance state list GetState to fill allowance")
+
+	allowance, err = list.GetAllowance("someowner", "somenewspender")
+	assert.Nil(t, err, "should not error when spender not approved")
+	assert.Equal(t, &Allowance{Owner: "someowner", Spender: "somenewspender"}, allowance, "should return zero allowance when spender not approved")
+
+	allowance, err = list.GetAllowance("someowner", "somebadspender")
+	assert.EqualError(t, err, "GetState error", "should return error when allowance state list get state errors")
+	assert.Nil(t, allowance, "should not return allowance on error")
+}
+
+func TestUpdateAllowance(t *testing.T) {
+	allowance := new(Allowance)
+
+	list := new(tokenList)
+	msl := new(MockStateList)
+	msl.On("UpdateState", allowance).Return(errors.New("Called update state correctly"))
+	list.allowanceStateList = msl
+
+	err := list.UpdateAllowance(allowance)
+	assert.EqualError(t, err, "Called update state correctly", "should call allowance state list update state with allowance")
+}
+
+func TestNewTokenList(t *testing.T) {
+	ctx := new(TransactionContext)
+	list := newTokenList(ctx)
+	stateList, ok := list.stateList.(*ledgerapi.StateList)
+	allowanceStateList, allowanceOk := list.allowanceStateList.(*ledgerapi.StateList)
+
+	assert.True(t, ok, "should make statelist of type ledgerapi.StateList")
+	assert.True(t, allowanceOk, "should make allowance statelist of type ledgerapi.StateList")
+	assert.Equal(t, ctx, stateList.Ctx, "should set the context to passed context")
+	assert.Equal(t, ctx, allowanceStateList.Ctx, "should set the allowance context to passed context")
+	assert.Equal(t, "org.phrnet.balancelist", stateList.Name, "should set the name for the list")
+	assert.Equal(t, "org.phrnet.allowancelist", allowanceStateList.Name, "should set the name for the allowance list")
+
+	expectedErr := DeserializeBalance([]byte("bad json"), new(Balance))
+	err := stateList.Deserialize([]byte("bad json"), new(Balance))
+	assert.EqualError(t, err, expectedErr.Error(), "should call DeserializeBalance when stateList.Deserialize called")
+
+	expectedErr = DeserializeAllowance([]byte("bad json"), new(Allowance))
+	err = allowanceStateList.Deserialize([]byte("bad json"), new(Allowance))
+	assert.EqualError(t, err, expectedErr.Error(), "should call DeserializeAllowance when allowance stateList.Deserialize called")
+}
@@ -0,0 +1,83 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Trade defines a purchase of a phr
+type Trade struct {
+	Issuer           string `json:"issuer"`
+	PHRNumber        string `json:"phrNumber"`
+	Sequence         int    `json:"sequence"`
+	Seller           string `json:"seller"`
+	Buyer            string `json:"buyer"`
+	Price            int    `json:"price"`
+	PurchaseDateTime string `json:"purchaseDateTime"`
+	TxID             string `json:"txId"`
+	Timestamp        string `json:"timestamp"`
+}
+
+// TradeStats defines aggregates over the trades of a phr.
+// Each trade moves a single phr so VWAP is the mean price
+type TradeStats struct {
+	TradeCount    int     `json:"tradeCount"`
+	LastPrice     int     `json:"lastPrice"`
+	LastTimestamp string  `json:"lastTimestamp"`
+	VWAP          float64 `json:"vwap"`
+}
+
+// CreateTradeSequence formats a trade sequence number so
+// that trade keys sort in sequence order
+func CreateTradeSequence(sequence int) string {
+	return fmt.Sprintf("%010d", sequence)
+}
+
+// GetSplitKey returns values which should be used to form key
+func (trade *Trade) GetSplitKey() []string {
+	return []string{trade.Issuer, trade.PHRNumber, CreateTradeSequence(trade.Sequence)}
+}
+
+// Serialize formats the trade as JSON bytes
+func (trade *Trade) Serialize() ([]byte, error) {
+	return json.Marshal(trade)
+}
+
+// DeserializeTrade formats the trade from JSON bytes
+func DeserializeTrade(bytes []byte, trade *Trade) error {
+	err := json.Unmarshal(bytes, trade)
+
+	if err != nil {
+		return fmt.Errorf("Error deserializing trade. %s", err.Error())
+	}
+
+	return nil
+}
+
+// NewTradeStats calculates aggregates over trades
+// ordered by sequence
+func NewTradeStats(trades []*Trade) *TradeStats {
+	stats := new(TradeStats)
+	stats.TradeCount = len(trades)
+
+	if stats.TradeCount == 0 {
+		return stats
+	}
+
+	total := 0
+
+	for _, trade := range trades {
+		total += trade.Price
+	}
+
+	last := trades[len(trades)-1]
+	stats.LastPrice = last.Price
+	stats.LastTimestamp = last.Timestamp
+	stats.VWAP = float64(total) / float64(stats.TradeCount)
+
+	return stats
+}
@@ -0,0 +1,50 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTradeSequence(t *testing.T) {
+	assert.Equal(t, "0000000012", CreateTradeSequence(12), "should zero pad sequence")
+}
+
+func TestTradeGetSplitKey(t *testing.T) {
+	trade := &Trade{Issuer: "someissuer", PHRNumber: "somephr", Sequence: 3}
+
+	assert.Equal(t, []string{"someissuer", "somephr", "0000000003"}, trade.GetSplitKey(), "should return issuer, phr number and sequence as split key")
+}
+
+func TestTradeSerialize(t *testing.T) {
+	trade := &Trade{Issuer: "someissuer", PHRNumber: "somephr", Sequence: 1, Seller: "someowner", Buyer: "someotherowner", Price: 100, PurchaseDateTime: "sometime", TxID: "sometx", Timestamp: "2021-12-10T10:00:00Z"}
+
+	bytes, err := trade.Serialize()
+	assert.Nil(t, err, "should not error on serialize")
+	assert.Equal(t, `{"issuer":"someissuer","phrNumber":"somephr","sequence":1,"seller":"someowner","buyer":"someotherowner","price":100,"purchaseDateTime":"sometime","txId":"sometx","timestamp":"2021-12-10T10:00:00Z"}`, string(bytes), "should return JSON formatted value")
+}
+
+func TestDeserializeTrade(t *testing.T) {
+	var trade *Trade
+	var err error
+
+	trade = new(Trade)
+	err = DeserializeTrade([]byte(`{"issuer":"someissuer","phrNumber":"somephr","sequence":1,"price":100}`), trade)
+	assert.Nil(t, err, "should not return error for deserialize")
+	assert.Equal(t, &Trade{Issuer: "someissuer", PHRNumber: "somephr", Sequence: 1, Price: 100}, trade, "should create expected trade")
+
+	trade = new(Trade)
+	err = DeserializeTrade([]byte(`{"price":"NaN"}`), trade)
+	assert.EqualError(t, err, "Error deserializing trade. json: cannot unmarshal string into Go struct field Trade.price of type int", "should return error for bad data")
+}
+
+func TestNewTradeStats(t *testing.T) {
+	assert.Equal(t, &TradeStats{}, NewTradeStats([]*Trade{}), "should return empty stats when no trades")
+
+	trades := []*Trade{{Sequence: 1, Price: 100, Timestamp: "sometime"}, {Sequence: 2, Price: 300, Timestamp: "somelatertime"}, {Sequence: 3, Price: 50, Timestamp: "somelastertime"}}
+	assert.Equal(t, &TradeStats{TradeCount: 3, LastPrice: 50, LastTimestamp: "somelastertime", VWAP: 150}, NewTradeStats(trades), "should aggregate trades")
+}
@@ -0,0 +1,78 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+
+// TradeListInterface defines functionality needed
+// to interact with the world state on behalf
+// of a trade
+type TradeListInterface interface {
+	AddTrade(*Trade) error
+	GetTrades(string, string) ([]*Trade, error)
+}
+
+// tradeListName namespace of trades in world state
+const tradeListName = "org.phrnet.tradelist"
+
+type tradeList struct {
+	stateList ledgerapi.StateListInterface
+}
+
+// AddTrade adds the trade as the next in sequence
+// for its phr
+func (tl *tradeList) AddTrade(trade *Trade) error {
+	trades, err := tl.GetTrades(trade.Issuer, trade.PHRNumber)
+
+	if err != nil {
+		return err
+	}
+
+	trade.Sequence = len(trades) + 1
+
+	return tl.stateList.AddState(trade)
+}
+
+// GetTrades returns the trades of a phr ordered by sequence
+func (tl *tradeList) GetTrades(issuer string, phrNumber string) ([]*Trade, error) {
+	iterator, err := tl.stateList.GetStatesByPartialKey(CreatePHRKey(issuer, phrNumber))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer iterator.Close()
+
+	trades := []*Trade{}
+
+	for iterator.HasNext() {
+		trade := new(Trade)
+
+		err := iterator.Next(trade)
+
+		if err != nil {
+			return nil, err
+		}
+
+		trades = append(trades, trade)
+	}
+
+	return trades, nil
+}
+
+// newTradeList create a new trade list from context
+func newTradeList(ctx TransactionContextInterface) *tradeList {
+	stateList := new(ledgerapi.StateList)
+	stateList.Ctx = ctx
+	stateList.Name = tradeListName
+	stateList.Deserialize = func(bytes []byte, state ledgerapi.StateInterface) error {
+		return DeserializeTrade(bytes, state.(*Trade))
+	}
+
+	list := new(tradeList)
+	list.stateList = stateList
+
+	return list
+}
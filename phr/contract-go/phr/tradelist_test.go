@@ -0,0 +1,112 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"errors"
+	"testing"
+
+	ledgerapi "github.com/Ha-youngPark/phr-trading-system/phr/contract-go/ledger-api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// #########
+// HELPERS
+// #########
+
+type MockTradeIterator struct {
+	prices []int
+	err    error
+	closed bool
+}
+
+func (mti *MockTradeIterator) HasNext() bool {
+	return len(mti.prices) > 0
+}
+
+func (mti *MockTradeIterator) Next(state ledgerapi.StateInterface) error {
+	if mti.err != nil {
+		return mti.err
+	}
+
+	state.(*Trade).Price = mti.prices[0]
+	mti.prices = mti.prices[1:]
+
+	return nil
+}
+
+func (mti *MockTradeIterator) Close() error {
+	mti.closed = true
+
+	return nil
+}
+
+// #########
+// TESTS
+// #########
+
+func TestAddTrade(t *testing.T) {
+	var err error
+
+	list := new(tradeList)
+	msl := new(MockStateList)
+	var emptyIterator *MockTradeIterator
+	msl.On("GetStatesByPartialKey", CreatePHRKey("someissuer", "somephr")).Return(&MockTradeIterator{prices: []int{100, 200}}, nil)
+	msl.On("GetStatesByPartialKey", CreatePHRKey("someotherissuer", "someotherphr")).Return(emptyIterator, errors.New("GetStatesByPartialKey error"))
+	msl.On("AddState", mock.MatchedBy(func(state ledgerapi.StateInterface) bool { return state.(*Trade).Sequence == 3 })).Return(errors.New("Called add state correctly"))
+	list.stateList = msl
+
+	err = list.AddTrade(&Trade{Issuer: "someotherissuer", PHRNumber: "someotherphr"})
+	assert.EqualError(t, err, "GetStatesByPartialKey error", "should return error when state list get states errors")
+
+	trade := &Trade{Issuer: "someissuer", PHRNumber: "somephr"}
+	err = list.AddTrade(trade)
+	assert.EqualError(t, err, "Called add state correctly", "should call state list add state with trade")
+	assert.Equal(t, 3, trade.Sequence, "should set sequence after existing trades")
+}
+
+func TestGetTrades(t *testing.T) {
+	var trades []*Trade
+	var err error
+
+	list := new(tradeList)
+	msl := new(MockStateList)
+	goodIterator := &MockTradeIterator{prices: []int{100, 200}}
+	badIterator := &MockTradeIterator{prices: []int{100}, err: errors.New("Next error")}
+	var emptyIterator *MockTradeIterator
+	msl.On("GetStatesByPartialKey", CreatePHRKey("someissuer", "somephr")).Return(goodIterator, nil)
+	msl.On("GetStatesByPartialKey", CreatePHRKey("someissuer", "somebadphr")).Return(badIterator, nil)
+	msl.On("GetStatesByPartialKey", CreatePHRKey("someotherissuer", "someotherphr")).Return(emptyIterator, errors.New("GetStatesByPartialKey error"))
+	list.stateList = msl
+
+	trades, err = list.GetTrades("someotherissuer", "someotherphr")
+	assert.EqualError(t, err, "GetStatesByPartialKey error", "should return error when state list get states errors")
+	assert.Nil(t, trades, "should not return trades on error")
+
+	trades, err = list.GetTrades("someissuer", "somebadphr")
+	assert.EqualError(t, err, "Next error", "should return error when iterator errors")
+	assert.Nil(t, trades, "should not return trades on iterator error")
+	assert.True(t, badIterator.closed, "should close iterator on error")
+
+	trades, err = list.GetTrades("someissuer", "somephr")
+	assert.Nil(t, err, "should not error when state list get states does not error")
+	assert.Equal(t, []*Trade{{Price: 100}, {Price: 200}}, trades, "should read every trade from iterator")
+	assert.True(t, goodIterator.closed, "should close iterator when done")
+}
+
+func TestNewTradeList(t *testing.T) {
+	ctx := new(TransactionContext)
+	list := newTradeList(ctx)
+	stateList, ok := list.stateList.(*ledgerapi.StateList)
+
+	assert.True(t, ok, "should make statelist of type ledgerapi.StateList")
+	assert.Equal(t, ctx, stateList.Ctx, "should set the context to passed context")
+	assert.Equal(t, "org.phrnet.tradelist", stateList.Name, "should set the name for the list")
+
+	expectedErr := DeserializeTrade([]byte("bad json"), new(Trade))
+	err := stateList.Deserialize([]byte("bad json"), new(Trade))
+	assert.EqualError(t, err, expectedErr.Error(), "should call DeserializeTrade when stateList.Deserialize called")
+}
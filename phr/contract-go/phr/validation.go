@@ -0,0 +1,70 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+)
+
+// sha256HexPattern matches a hex encoded SHA-256 hash
+var sha256HexPattern = regexp.MustCompile("^[0-9a-f]{64}$")
+
+// ValidationError returned when an argument
+// of a transaction is not valid
+type ValidationError struct {
+	Field  string
+	Value  string
+	Reason string
+}
+
+func (err *ValidationError) Error() string {
+	message := fmt.Sprintf("Invalid %s %q. %s", err.Field, err.Value, err.Reason)
+
+	return phrerror.New(phrerror.Validation, message, map[string]string{"field": err.Field, "value": err.Value, "reason": err.Reason}).Error()
+}
+
+// FormatDateTime formats a time as the RFC 3339 string
+// stored on the ledger. Times are stored in UTC to
+// second precision so they sort as strings
+func FormatDateTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// parseDateTime parses an RFC 3339 argument and returns it formatted
+// for the ledger. Empty values are allowed unless required
+func parseDateTime(field string, value string, required bool) (string, error) {
+	if value == "" {
+		if required {
+			return "", &ValidationError{Field: field, Value: value, Reason: "Value is required"}
+		}
+
+		return "", nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+
+	if err != nil {
+		return "", &ValidationError{Field: field, Value: value, Reason: "Expected an RFC 3339 date time"}
+	}
+
+	return FormatDateTime(t), nil
+}
+
+// parseSHA256 parses a hex encoded SHA-256 hash argument and
+// returns it in lower case
+func parseSHA256(field string, value string) (string, error) {
+	hash := strings.ToLower(value)
+
+	if !sha256HexPattern.MatchString(hash) {
+		return "", &ValidationError{Field: field, Value: value, Reason: "Expected a hex encoded SHA-256 hash"}
+	}
+
+	return hash, nil
+}
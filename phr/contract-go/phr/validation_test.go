@@ -0,0 +1,61 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phr
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ha-youngPark/phr-trading-system/phr/contract-go/phrerror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationError(t *testing.T) {
+	err := &ValidationError{Field: "somefield", Value: "somevalue", Reason: "Some reason"}
+
+	assertCodedError(t, err, phrerror.Validation, `Invalid somefield "somevalue". Some reason`, "should describe field, value and reason")
+
+	decoded, _ := phrerror.DecodeError(err)
+	assert.Equal(t, map[string]string{"field": "somefield", "value": "somevalue", "reason": "Some reason"}, decoded.Details, "should detail field, value and reason")
+}
+
+func TestFormatDateTime(t *testing.T) {
+	assert.Equal(t, "2021-12-10T01:00:00Z", FormatDateTime(time.Date(2021, 12, 10, 10, 0, 0, 500, time.FixedZone("KST", 9*60*60))), "should format in UTC to second precision")
+}
+
+func TestParseDateTime(t *testing.T) {
+	var value string
+	var err error
+
+	value, err = parseDateTime("somefield", "", false)
+	assert.Nil(t, err, "should not error for empty optional value")
+	assert.Equal(t, "", value, "should return empty value for empty optional value")
+
+	value, err = parseDateTime("somefield", "", true)
+	assertCodedError(t, err, phrerror.Validation, `Invalid somefield "". Value is required`, "should error for empty required value")
+	assert.Equal(t, "", value, "should return empty value for empty required value")
+
+	value, err = parseDateTime("somefield", "10/12/2021", false)
+	assertCodedError(t, err, phrerror.Validation, `Invalid somefield "10/12/2021". Expected an RFC 3339 date time`, "should error for value which does not parse")
+	assert.Equal(t, "", value, "should return empty value for value which does not parse")
+
+	value, err = parseDateTime("somefield", "2021-12-10T19:00:00+09:00", true)
+	assert.Nil(t, err, "should not error for RFC 3339 value")
+	assert.Equal(t, "2021-12-10T10:00:00Z", value, "should return value formatted for the ledger")
+}
+
+func TestParseSHA256(t *testing.T) {
+	var value string
+	var err error
+
+	value, err = parseSHA256("somefield", "somehash")
+	assertCodedError(t, err, phrerror.Validation, `Invalid somefield "somehash". Expected a hex encoded SHA-256 hash`, "should error for value which is not a hash")
+	assert.Equal(t, "", value, "should return empty value for value which is not a hash")
+
+	value, err = parseSHA256("somefield", strings.ToUpper("25c1db4edf964a5af1ef18f2efe118bfa24c2d419ee7e81e605a93aeaca2d78a"))
+	assert.Nil(t, err, "should not error for upper case hash")
+	assert.Equal(t, "25c1db4edf964a5af1ef18f2efe118bfa24c2d419ee7e81e605a93aeaca2d78a", value, "should return hash in lower case")
+}
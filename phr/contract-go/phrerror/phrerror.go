@@ -0,0 +1,117 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phrerror
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Code a stable, machine readable code of an error
+type Code string
+
+// Codes of the error catalogue
+const (
+	// NotFound a state or record does not exist
+	NotFound Code = "NOT_FOUND"
+	// AlreadyExists a state with the same key already exists
+	AlreadyExists Code = "ALREADY_EXISTS"
+	// NotOwner the submitter or named owner does not own the asset
+	NotOwner Code = "NOT_OWNER"
+	// InvalidState the asset is not in a state that allows the transaction
+	InvalidState Code = "INVALID_STATE"
+	// Forbidden the submitter is not allowed to run the transaction
+	Forbidden Code = "FORBIDDEN"
+	// Validation an argument of the transaction is not valid
+	Validation Code = "VALIDATION"
+)
+
+// Codes returns all codes of the catalogue
+func Codes() []Code {
+	return []Code{NotFound, AlreadyExists, NotOwner, InvalidState, Forbidden, Validation}
+}
+
+// envelopePrefix starts the JSON envelope of every error
+const envelopePrefix = `{"code":`
+
+// Error an error of the catalogue. Its message is the JSON
+// envelope of the error, so clients can decode it from the
+// message of a failed transaction
+type Error struct {
+	Code    Code              `json:"code"`
+	Message string            `json:"message"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+func (err *Error) Error() string {
+	envelope, _ := json.Marshal(err)
+
+	return string(envelope)
+}
+
+// New create a new error with the passed code, message and details
+func New(code Code, message string, details map[string]string) *Error {
+	return &Error{Code: code, Message: message, Details: details}
+}
+
+// Errorf create a new error with the passed code
+// and the message formatted as fmt.Sprintf does
+func Errorf(code Code, format string, args ...interface{}) *Error {
+	return New(code, fmt.Sprintf(format, args...), nil)
+}
+
+// Decode returns the error whose envelope is in the message. The
+// envelope may be prefixed or followed by other text, as when a
+// peer or gateway wraps the message of a failed transaction.
+// Returns false when the message holds no envelope
+func Decode(message string) (*Error, bool) {
+	for i := strings.Index(message, envelopePrefix); i >= 0; {
+		decoded := new(Error)
+		err := json.NewDecoder(strings.NewReader(message[i:])).Decode(decoded)
+
+		if err == nil && decoded.Code != "" {
+			return decoded, true
+		}
+
+		next := strings.Index(message[i+1:], envelopePrefix)
+
+		if next < 0 {
+			break
+		}
+
+		i += next + 1
+	}
+
+	return nil, false
+}
+
+// DecodeError returns the error whose envelope is in the
+// message of err. Returns false when err is nil or its
+// message holds no envelope
+func DecodeError(err error) (*Error, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	return Decode(err.Error())
+}
+
+// CodeOf returns the code of err, or an empty
+// code when err is not an error of the catalogue
+func CodeOf(err error) Code {
+	decoded, ok := DecodeError(err)
+
+	if !ok {
+		return ""
+	}
+
+	return decoded.Code
+}
+
+// Is reports whether err is an error of the catalogue with the passed code
+func Is(err error, code Code) bool {
+	return CodeOf(err) == code
+}
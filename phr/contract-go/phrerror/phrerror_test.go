@@ -0,0 +1,79 @@
+/*
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package phrerror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError(t *testing.T) {
+	err := New(NotFound, "No state found for someissuer:somephr", map[string]string{"key": "someissuer:somephr"})
+	assert.EqualError(t, err, `{"code":"NOT_FOUND","message":"No state found for someissuer:somephr","details":{"key":"someissuer:somephr"}}`, "should serialize envelope as message")
+
+	err = Errorf(NotOwner, "PHR %s:%s is not owned by %s", "someissuer", "somephr", "someowner")
+	assert.Equal(t, &Error{Code: NotOwner, Message: "PHR someissuer:somephr is not owned by someowner"}, err, "should format message")
+	assert.EqualError(t, err, `{"code":"NOT_OWNER","message":"PHR someissuer:somephr is not owned by someowner"}`, "should omit empty details")
+}
+
+func TestCodes(t *testing.T) {
+	codes := []string{}
+
+	for _, code := range Codes() {
+		codes = append(codes, string(code))
+	}
+
+	assert.Equal(t, []string{"NOT_FOUND", "ALREADY_EXISTS", "NOT_OWNER", "INVALID_STATE", "FORBIDDEN", "VALIDATION"}, codes, "should keep codes stable")
+}
+
+func TestDecode(t *testing.T) {
+	var decoded *Error
+	var ok bool
+
+	expected := New(Validation, `Invalid issuer "". Value is required`, map[string]string{"field": "issuer"})
+
+	decoded, ok = Decode(expected.Error())
+	assert.True(t, ok, "should decode envelope")
+	assert.Equal(t, expected, decoded, "should decode code, message and details")
+
+	decoded, ok = Decode("transaction returned with failure: " + expected.Error())
+	assert.True(t, ok, "should decode envelope after other text")
+	assert.Equal(t, expected, decoded, "should decode wrapped envelope")
+
+	decoded, ok = Decode(`rpc error: code = Aborted desc = failed {"code":} then ` + expected.Error() + " trailing")
+	assert.True(t, ok, "should skip malformed envelope")
+	assert.Equal(t, expected, decoded, "should decode following envelope")
+
+	decoded, ok = Decode("No state found for someissuer:somephr")
+	assert.False(t, ok, "should not decode message without envelope")
+	assert.Nil(t, decoded, "should not return error without envelope")
+
+	decoded, ok = Decode(`{"code":""}`)
+	assert.False(t, ok, "should not decode envelope without code")
+	assert.Nil(t, decoded, "should not return error without code")
+}
+
+func TestDecodeError(t *testing.T) {
+	decoded, ok := DecodeError(nil)
+	assert.False(t, ok, "should not decode nil error")
+	assert.Nil(t, decoded, "should not return error for nil error")
+
+	decoded, ok = DecodeError(fmt.Errorf("Error submitting transaction. %s", Errorf(Forbidden, "Only MSP Org2MSP can mint tokens")))
+	assert.True(t, ok, "should decode envelope in error message")
+	assert.Equal(t, Errorf(Forbidden, "Only MSP Org2MSP can mint tokens"), decoded, "should decode wrapped error")
+}
+
+func TestCodeOf(t *testing.T) {
+	assert.Equal(t, InvalidState, CodeOf(Errorf(InvalidState, "PHR someissuer:somephr is already expired")), "should return code of error")
+	assert.Equal(t, Code(""), CodeOf(errors.New("GetState error")), "should return empty code for other errors")
+	assert.Equal(t, Code(""), CodeOf(nil), "should return empty code for nil error")
+
+	assert.True(t, Is(Errorf(AlreadyExists, "State already exists for somekey"), AlreadyExists), "should match code of error")
+	assert.False(t, Is(Errorf(AlreadyExists, "State already exists for somekey"), NotFound), "should not match other code")
+	assert.False(t, Is(errors.New("GetState error"), NotFound), "should not match other errors")
+}
@@ -1,13 +0,0 @@
-module github.com/Ha-youngPark/phr-trading-system/phr/organization/institute/contract-go
-
-go 1.13
-
-require (
-	github.com/go-openapi/jsonreference v0.19.3 // indirect
-	github.com/hyperledger/fabric-contract-api-go v1.1.0
-	github.com/mailru/easyjson v0.7.0 // indirect
-	github.com/stretchr/testify v1.5.1
-	golang.org/x/net v0.0.0-20191028085509-fe3aa8a45271 // indirect
-	google.golang.org/genproto v0.0.0-20191028173616-919d9bdd9fe6 // indirect
-	google.golang.org/grpc v1.24.0 // indirect
-)